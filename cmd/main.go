@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
 	"net/http"
@@ -14,45 +15,80 @@ import (
 	"github.com/joho/godotenv"
 	"radarfutebol-sse/internal/config"
 	"radarfutebol-sse/internal/handlers"
+	"radarfutebol-sse/internal/handoff"
 	"radarfutebol-sse/internal/services"
 )
 
 func main() {
+	configFile := flag.String("config", os.Getenv("SSE_CONFIG_FILE"), "arquivo de configuracao TOML (variaveis de ambiente tem precedencia)")
+	printConfig := flag.Bool("print-config", false, "mostra a configuracao efetiva (com a origem de cada valor) e sai")
+	flag.Parse()
+
 	// Carrega .env (ignora erro se nao existir)
 	godotenv.Load()
 
+	// Carrega configuracoes (padrao < arquivo < env) e valida
+	cfg, err := config.Load(*configFile)
+	if *printConfig {
+		cfg.Print(os.Stdout)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "\nConfiguracao invalida:\n%v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+	if err != nil {
+		log.Fatalf("Erro ao carregar configuracoes: %v", err)
+	}
+
 	// Configura GOMAXPROCS para usar todos os CPUs disponiveis
 	numCPU := runtime.NumCPU()
 	runtime.GOMAXPROCS(numCPU)
 	log.Printf("Iniciando servidor SSE Go (CPUs: %d, GOMAXPROCS: %d)...", numCPU, runtime.GOMAXPROCS(0))
 
-	// Carrega configuracoes
-	cfg, err := config.Load()
-	if err != nil {
-		log.Fatalf("Erro ao carregar configuracoes: %v", err)
-	}
-
 	// Inicializa MySQL (opcional - dados vêm do Redis)
 	if err := services.InitMySQL(cfg.MySQL); err != nil {
 		log.Printf("Aviso: MySQL não disponível: %v", err)
 		log.Println("Continuando sem MySQL (dados vêm do Redis)")
 	} else {
 		log.Println("MySQL inicializado")
-		// Inicializa cache de autenticacao (requer MySQL)
-		services.InitAuthCache()
+	}
+
+	// Inicializa cache de autenticacao (chave HMAC dos tokens no Redis)
+	services.InitAuthCache(cfg)
+
+	// Inicializa validacao de JWT (opcional - assinantes continuam logados sem MySQL)
+	if err := services.InitJWT(cfg.Auth); err != nil {
+		log.Fatalf("Erro ao configurar JWT: %v", err)
 	}
 
 	// Inicializa Redis (database 0 - cache principal)
+	// Sem a fonte redis (replay/arquivo local) o servidor sobe mesmo com o Redis fora
 	if err := services.InitRedis(cfg.Redis); err != nil {
-		log.Fatalf("Erro ao inicializar Redis: %v", err)
+		if cfg.EventSource.Usa("redis") {
+			log.Fatalf("Erro ao inicializar Redis: %v", err)
+		}
+		log.Printf("Aviso: Redis não disponível: %v", err)
+		log.Printf("Continuando sem Redis (eventos de %v)", cfg.EventSource.Order)
 	}
 
 	// Inicializa Redis preferencias (database 2 - favoritos do usuario)
-	if err := services.InitRedisPreferencias(cfg.Redis.Host, cfg.Redis.Port, cfg.Redis.Password); err != nil {
+	if err := services.InitRedisPreferencias(cfg.Redis); err != nil {
 		log.Printf("Aviso: Erro ao inicializar Redis preferencias: %v", err)
 		// Nao fatal - continua sem preferencias
 	}
 
+	// Cadencia e caches do oraculo
+	services.InitOraculo(cfg.Oraculo)
+
+	// Fontes da lista de eventos (Redis, com failover para MySQL/arquivo)
+	if err := services.InitFontesEventos(cfg.EventSource); err != nil {
+		log.Fatalf("Erro nas fontes de eventos: %v", err)
+	}
+
+	// Snapshot em disco para o restart nao comecar vazio
+	services.InitWarmStart(cfg.WarmStart)
+
 	// Inicia o Broadcaster (cache em memoria + atualizacao periodica)
 	broadcaster := services.GetBroadcaster()
 	broadcaster.Start()
@@ -60,7 +96,12 @@ func main() {
 	log.Println("Broadcaster iniciado (cache em memoria)")
 
 	// Cria handler SSE
-	sseHandler := handlers.NewSSEHandler()
+	sseHandler := handlers.NewSSEHandler(cfg)
+
+	// Escuta revogacoes/mudancas de plano publicadas pelo Laravel (Redis pub/sub)
+	authCtx, stopAuthListener := context.WithCancel(context.Background())
+	defer stopAuthListener()
+	go services.ListenAuthChanges(authCtx, sseHandler.HandleAuthChange)
 
 	// Configura rotas
 	mux := http.NewServeMux()
@@ -84,44 +125,136 @@ func main() {
 		MaxHeaderBytes: 1 << 16, // 64KB max headers
 	}
 
-	// Canal para shutdown graceful
+	// Socket de escuta: herdado do processo anterior (upgrade), do systemd ou aberto agora
+	listener, err := handoff.Listen(server.Addr)
+	if err != nil {
+		log.Fatalf("Erro ao abrir porta %d: %v", cfg.Server.Port, err)
+	}
+
+	// Canal para shutdown graceful (SIGHUP = recarrega configuracao, SIGUSR2 = upgrade sem fechar a porta)
 	stop := make(chan os.Signal, 1)
-	signal.Notify(stop, os.Interrupt, syscall.SIGTERM, syscall.SIGQUIT)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM, syscall.SIGQUIT, syscall.SIGHUP, syscall.SIGUSR2)
 
 	// Inicia servidor em goroutine
 	go func() {
 		log.Printf("Servidor SSE rodando na porta %d", cfg.Server.Port)
-		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
 			log.Fatalf("Erro ao iniciar servidor: %v", err)
 		}
 	}()
 
-	// Aguarda sinal de shutdown
-	sig := <-stop
-	log.Printf("Recebido sinal de shutdown (%v)...", sig)
+	// Cold start: avisa o systemd assim que a porta esta aberta (sem Redis o servico sobe
+	// e o /readyz segura o trafego ate o snapshot carregar)
+	// Upgrade: so libera o processo anterior depois do primeiro snapshot lido da fonte
+	// (o do warm start nao conta: e do disco e pode estar desatualizado)
+	if handoff.Upgrading() {
+		go func() {
+			if waitLiveSnapshot(broadcaster, cfg.Server.HandoffTimeout) {
+				handoff.Ready()
+			} else {
+				log.Printf("Aviso: eventos nao carregados em %v, processo nao sinalizado como pronto", cfg.Server.HandoffTimeout)
+			}
+		}()
+	} else {
+		handoff.Ready()
+	}
+
+	// Aguarda sinal de shutdown, reload ou upgrade
+	handedOff := false
+	for !handedOff {
+		sig := <-stop
+		if sig == syscall.SIGHUP {
+			cfg = reloadConfig(cfg, *configFile, sseHandler)
+			continue
+		}
+		if sig != syscall.SIGUSR2 {
+			log.Printf("Recebido sinal de shutdown (%v)...", sig)
+			break
+		}
+
+		log.Println("Recebido SIGUSR2: iniciando upgrade (handoff do socket)...")
+		process, err := handoff.Upgrade(listener, cfg.Server.HandoffTimeout)
+		if err != nil {
+			log.Printf("Upgrade abortado, processo atual continua atendendo: %v", err)
+			continue
+		}
+		log.Printf("Upgrade: processo novo (pid %d) pronto, encerrando este processo", process.Pid)
+		handedOff = true
+	}
 
-	// Graceful shutdown com timeout de 30 segundos
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	// Graceful shutdown: drain + 10 segundos para os handlers terminarem
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Server.DrainWindow+10*time.Second)
 	defer cancel()
 
+	shutdownDone := make(chan error, 1)
+	shutdown := func() { shutdownDone <- server.Shutdown(ctx) }
+	if handedOff {
+		// Processo novo ja atende no mesmo socket: para de aceitar conexoes na hora
+		go shutdown()
+	} else {
+		handoff.Stopping()
+	}
+
+	// Drain: recusa novos streams e encerra os abertos aos poucos ao longo da janela
+	drainCtx, cancelDrain := context.WithTimeout(context.Background(), cfg.Server.DrainWindow)
+	sseHandler.Drain(drainCtx, cfg.Server.DrainWindow)
+	cancelDrain()
+
 	// Para de aceitar novas conexoes e espera as existentes terminarem
-	if err := server.Shutdown(ctx); err != nil {
+	if !handedOff {
+		go shutdown()
+	}
+	if err := <-shutdownDone; err != nil {
 		log.Printf("Erro no shutdown graceful: %v", err)
 	}
 
-	// Para o broadcaster
+	// Para o broadcaster (salva o snapshot para o warm start)
 	broadcaster.Stop()
 
 	log.Println("Servidor SSE encerrado graciosamente")
 }
 
+// reloadConfig rele o arquivo e aplica os valores recarregaveis sem derrubar conexoes
+// O ambiente do processo nao muda: chave definida por env continua com o valor da env
+// Configuracao invalida e descartada; valores que exigem restart sao mantidos (com aviso)
+func reloadConfig(atual *config.Config, configFile string, sseHandler *handlers.SSEHandler) *config.Config {
+	log.Println("Recebido SIGHUP: recarregando configuracao...")
+
+	next, err := config.Load(configFile)
+	if err != nil {
+		log.Printf("Reload abortado, configuracao atual mantida: %v", err)
+		return atual
+	}
+
+	aplicada, ignorados := atual.Reload(next)
+	for _, chave := range ignorados {
+		log.Printf("Reload: %s alterado mas so vale apos restart/upgrade (SIGUSR2)", chave)
+	}
+	sseHandler.ApplyConfig(aplicada)
+
+	log.Println("Configuracao recarregada")
+	return aplicada
+}
+
+// waitLiveSnapshot aguarda o Broadcaster publicar o primeiro snapshot lido da fonte de eventos
+func waitLiveSnapshot(broadcaster *services.Broadcaster, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for !broadcaster.Live() {
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	return true
+}
+
 // corsMiddleware adiciona headers CORS
 func corsMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Headers CORS
 		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-API-Key")
 
 		// Preflight request
 		if r.Method == "OPTIONS" {
@@ -0,0 +1,145 @@
+// recorder grava as chaves lidas pelo servidor SSE (JSON dos eventos e oraculo-cache:idJogo-*)
+// em um arquivo compactado, para reproduzir depois com a fonte replay:
+//
+//	go run ./cmd/recorder -out rodada.jsonl.gz -duration 2h
+//	SSE_EVENT_SOURCES=replay SSE_REPLAY_FILE=rodada.jsonl.gz SSE_REPLAY_SPEED=10 go run ./cmd/main.go
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/redis/go-redis/v9"
+	"radarfutebol-sse/internal/config"
+	"radarfutebol-sse/internal/replay"
+)
+
+const oraculoPrefix = "oraculo-cache:idJogo-"
+
+func main() {
+	configFile := flag.String("config", os.Getenv("SSE_CONFIG_FILE"), "arquivo de configuracao TOML (mesmo do servidor, para o Redis)")
+	out := flag.String("out", "recording-"+time.Now().Format("20060102-150405")+".jsonl.gz", "arquivo de saida")
+	interval := flag.Duration("interval", 2*time.Second, "intervalo entre capturas")
+	duration := flag.Duration("duration", 0, "tempo de gravacao (0 = ate Ctrl+C)")
+	flag.Parse()
+
+	godotenv.Load()
+	cfg, err := config.Load(*configFile)
+	if err != nil {
+		log.Fatalf("Erro ao carregar configuracoes: %v", err)
+	}
+	if *interval < 100*time.Millisecond {
+		log.Fatalf("Intervalo minimo 100ms: %v", *interval)
+	}
+
+	rdb := redis.NewClient(&redis.Options{
+		Addr:     fmt.Sprintf("%s:%d", cfg.Redis.Host, cfg.Redis.Port),
+		Password: cfg.Redis.Password,
+		DB:       cfg.Redis.DB,
+	})
+	defer rdb.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if *duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *duration)
+		defer cancel()
+	}
+
+	if err := rdb.Ping(ctx).Err(); err != nil {
+		log.Fatalf("Erro ao conectar Redis: %v", err)
+	}
+
+	f, err := os.Create(*out)
+	if err != nil {
+		log.Fatalf("Erro ao criar %s: %v", *out, err)
+	}
+	defer f.Close()
+
+	inicio := time.Now()
+	w, err := replay.NewWriter(f, replay.Header{
+		StartedAt:  inicio,
+		EventosKey: cfg.Redis.EventosKey,
+		IntervalMs: interval.Milliseconds(),
+	})
+	if err != nil {
+		log.Fatalf("Erro ao gravar %s: %v", *out, err)
+	}
+	log.Printf("Gravando %s e %s* em %s a cada %v", cfg.Redis.EventosKey, oraculoPrefix, *out, *interval)
+
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+	capturas, falhas := 0, 0
+	for {
+		eventos, oraculos, err := capturar(ctx, rdb, cfg.Redis.EventosKey)
+		switch {
+		case ctx.Err() != nil:
+			// Encerrando: captura interrompida no meio e descartada
+		case err != nil:
+			falhas++
+			log.Printf("Erro na captura: %v", err)
+		default:
+			if err := w.Gravar(time.Now(), eventos, oraculos); err != nil {
+				log.Fatalf("Erro ao gravar %s: %v", *out, err)
+			}
+			capturas++
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			if err := w.Close(); err != nil {
+				log.Fatalf("Erro ao finalizar %s: %v", *out, err)
+			}
+			info, _ := f.Stat()
+			var tamanho int64
+			if info != nil {
+				tamanho = info.Size()
+			}
+			log.Printf("Gravacao encerrada: %v, %d capturas (%d com mudancas, %d falhas), %d KB",
+				time.Since(inicio).Round(time.Second), capturas, w.Frames, falhas, tamanho/1024)
+			return
+		}
+	}
+}
+
+// capturar le a chave dos eventos e todos os oraculos (SCAN + MGET)
+func capturar(ctx context.Context, rdb *redis.Client, eventosKey string) (string, map[string]string, error) {
+	eventos, err := rdb.Get(ctx, eventosKey).Result()
+	if err != nil && err != redis.Nil {
+		return "", nil, fmt.Errorf("eventos: %w", err)
+	}
+
+	var keys []string
+	iter := rdb.Scan(ctx, 0, oraculoPrefix+"*", 1000).Iterator()
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		return "", nil, fmt.Errorf("scan oraculos: %w", err)
+	}
+
+	oraculos := make(map[string]string, len(keys))
+	for inicio := 0; inicio < len(keys); inicio += 500 {
+		lote := keys[inicio:min(inicio+500, len(keys))]
+		valores, err := rdb.MGet(ctx, lote...).Result()
+		if err != nil {
+			return "", nil, fmt.Errorf("oraculos: %w", err)
+		}
+		for i, valor := range valores {
+			if data, ok := valor.(string); ok {
+				oraculos[strings.TrimPrefix(lote[i], oraculoPrefix)] = data
+			}
+		}
+	}
+	return eventos, oraculos, nil
+}
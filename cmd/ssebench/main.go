@@ -0,0 +1,331 @@
+// ssebench teste de carga dos endpoints SSE: abre N conexoes simultaneas (como EventSource,
+// reconectando ao cair) em /sse/painel, /sse/home e /sse/oraculo/{id} com uma mistura de
+// filtros, tokens e tiers, e imprime percentis de connect, primeiro frame, intervalo entre
+// frames e bytes por frame, alem de erros e desconexoes por endpoint
+//
+// Contra um servidor local alimentado por arquivo (sem Redis/MySQL):
+//
+//	SSE_EVENT_SOURCES=file SSE_EVENT_SOURCE_FILE=eventos.json RATE_LIMIT_ENABLED=false go run ./cmd/main.go
+//	go run ./cmd/ssebench -n 2000 -ramp 20s -duration 2m -mix painel=60,home=30,oraculo=10
+//
+// Com rate limit ligado, -xff distribui as conexoes entre IPs ficticios (X-Forwarded-For,
+// aceito quando o benchmark roda de um proxy confiavel, ex: 127.0.0.1)
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"math/rand"
+	"net/http"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// filtrosPadrao combinacoes de filtros do painel/home quando -filters nao e informado
+var filtrosPadrao = []string{
+	"",
+	"mostrarApenasJogosLive=true",
+	"countJogosMostrar=50&ordemInicio=true",
+	"mostrarApenasJogosOraculo=true&filtroPressao=true",
+	"mostrarFiltroAcrescimo=true&filtroAcrescimoHt=2&filtroAcrescimoHtOperador=%3E%3D",
+}
+
+// alvo URL e credencial de uma conexao simulada
+type alvo struct {
+	endpoint string
+	tier     string
+	url      string
+	token    string
+}
+
+func main() {
+	baseURL := flag.String("url", "http://localhost:3005", "endereco do servidor SSE")
+	n := flag.Int("n", 100, "conexoes simultaneas")
+	ramp := flag.Duration("ramp", 10*time.Second, "tempo para abrir todas as conexoes")
+	duration := flag.Duration("duration", time.Minute, "duracao do teste (apos o ramp)")
+	mix := flag.String("mix", "painel=70,home=20,oraculo=10", "peso de cada endpoint")
+	tiers := flag.String("tiers", "anon=100", "peso de cada tier: anon, free e assinante (ex: anon=80,free=15,assinante=5; free e assinante usam -tokens)")
+	tokensFile := flag.String("tokens", "", "arquivo com \"<tier> <token>\" por linha")
+	filtersFile := flag.String("filters", "", "arquivo com uma query string de filtros por linha (painel/home)")
+	oraculoIds := flag.String("oraculo-ids", "", "idWilliamhill dos oraculos, separados por virgula (vazio = descobre em /api/painel)")
+	reconnect := flag.Bool("reconnect", true, "reconecta ao cair, respeitando o retry: do servidor")
+	xff := flag.Bool("xff", false, "X-Forwarded-For distinto por conexao (distribui entre IPs para o rate limit)")
+	seed := flag.Int64("seed", 1, "semente da distribuicao (mesmo valor = mesma mistura)")
+	flag.Parse()
+
+	rng := rand.New(rand.NewSource(*seed))
+	pesosEndpoint, err := parsePesos(*mix, "painel", "home", "oraculo")
+	if err != nil {
+		log.Fatalf("-mix: %v", err)
+	}
+	pesosTier, err := parsePesos(*tiers, "anon", "free", "assinante")
+	if err != nil {
+		log.Fatalf("-tiers: %v", err)
+	}
+	tokens, err := lerTokens(*tokensFile)
+	if err != nil {
+		log.Fatalf("-tokens: %v", err)
+	}
+	for _, tier := range []string{"free", "assinante"} {
+		if pesosTier[tier] > 0 && len(tokens[tier]) == 0 {
+			log.Fatalf("-tiers: %s com peso mas sem tokens em -tokens", tier)
+		}
+	}
+	filtros := filtrosPadrao
+	if *filtersFile != "" {
+		if filtros, err = lerLinhas(*filtersFile); err != nil {
+			log.Fatalf("-filters: %v", err)
+		}
+	}
+
+	transport := &http.Transport{
+		MaxIdleConnsPerHost: *n,
+		DisableCompression:  true, // bytes por frame sem gzip
+		IdleConnTimeout:     30 * time.Second,
+	}
+	client := &http.Client{Transport: transport}
+
+	var oraculos []string
+	if pesosEndpoint["oraculo"] > 0 {
+		if *oraculoIds != "" {
+			oraculos = strings.Split(*oraculoIds, ",")
+		} else if oraculos, err = descobrirOraculos(client, *baseURL); err != nil {
+			log.Fatalf("Descobrindo oraculos em /api/painel: %v (informe -oraculo-ids)", err)
+		}
+		if len(oraculos) == 0 {
+			log.Fatalf("Nenhum oraculo para /sse/oraculo (informe -oraculo-ids ou tire oraculo do -mix)")
+		}
+	}
+
+	stats := map[string]*statsEndpoint{}
+	for _, endpoint := range []string{"painel", "home", "oraculo"} {
+		stats[endpoint] = novoStatsEndpoint(endpoint)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	ctx, cancel := context.WithTimeout(ctx, *ramp+*duration)
+	defer cancel()
+
+	log.Printf("Abrindo %d conexoes em %v contra %s (mix %s, tiers %s, %d filtros, %d oraculos)",
+		*n, *ramp, *baseURL, *mix, *tiers, len(filtros), len(oraculos))
+
+	inicio := time.Now()
+	var wg sync.WaitGroup
+	go progresso(ctx, stats, inicio)
+	for i := 0; i < *n && ctx.Err() == nil; i++ {
+		a := montarAlvo(rng, *baseURL, pesosEndpoint, pesosTier, tokens, filtros, oraculos)
+		c := &conexao{
+			client: client,
+			alvo:   a,
+			stats:  stats[a.endpoint],
+			retry:  time.Second,
+			recon:  *reconnect,
+		}
+		if *xff {
+			c.xff = fmt.Sprintf("10.%d.%d.%d", (i>>16)&0xff, (i>>8)&0xff, i&0xff)
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.run(ctx)
+		}()
+
+		if *n > 1 {
+			select {
+			case <-time.After(*ramp / time.Duration(*n)):
+			case <-ctx.Done():
+			}
+		}
+	}
+	wg.Wait()
+	total := time.Since(inicio)
+
+	fmt.Printf("\nDuracao: %v (%d conexoes, ramp %v)\n", total.Round(time.Millisecond), *n, *ramp)
+	for _, endpoint := range []string{"painel", "home", "oraculo"} {
+		if pesosEndpoint[endpoint] > 0 {
+			stats[endpoint].relatorio(os.Stdout, total)
+		}
+	}
+}
+
+// progresso streams abertos e frames/s a cada 5s
+func progresso(ctx context.Context, stats map[string]*statsEndpoint, inicio time.Time) {
+	ticker := time.NewTicker(5 * time.Second)
+	defer ticker.Stop()
+	var anterior int64
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+		var ativas, frames int64
+		for _, s := range stats {
+			ativas += s.ativas.Load()
+			frames += s.framesTotal()
+		}
+		log.Printf("%v: %d streams abertos, %.1f frames/s", time.Since(inicio).Round(time.Second), ativas, float64(frames-anterior)/5)
+		anterior = frames
+	}
+}
+
+// montarAlvo sorteia endpoint, tier, token e filtro de uma conexao
+func montarAlvo(rng *rand.Rand, baseURL string, pesosEndpoint, pesosTier map[string]int,
+	tokens map[string][]string, filtros, oraculos []string) alvo {
+	a := alvo{endpoint: sortear(rng, pesosEndpoint), tier: sortear(rng, pesosTier)}
+	if lista := tokens[a.tier]; a.tier != "anon" && len(lista) > 0 {
+		a.token = lista[rng.Intn(len(lista))]
+	}
+
+	base := strings.TrimRight(baseURL, "/")
+	switch a.endpoint {
+	case "oraculo":
+		a.url = base + "/sse/oraculo/" + oraculos[rng.Intn(len(oraculos))]
+	default:
+		a.url = base + "/sse/" + a.endpoint
+		if filtro := filtros[rng.Intn(len(filtros))]; filtro != "" {
+			a.url += "?" + filtro
+		}
+	}
+	return a
+}
+
+// parsePesos "a=70,b=30" (nomes aceitos em validos; ausente = peso 0)
+func parsePesos(s string, validos ...string) (map[string]int, error) {
+	pesos := make(map[string]int)
+	total := 0
+	for _, parte := range strings.Split(s, ",") {
+		nome, valor, ok := strings.Cut(strings.TrimSpace(parte), "=")
+		peso, err := strconv.Atoi(valor)
+		if !ok || err != nil || peso < 0 {
+			return nil, fmt.Errorf("esperado nome=peso: %q", parte)
+		}
+		valido := false
+		for _, v := range validos {
+			valido = valido || v == nome
+		}
+		if !valido {
+			return nil, fmt.Errorf("%q desconhecido (validos: %s)", nome, strings.Join(validos, ", "))
+		}
+		pesos[nome] = peso
+		total += peso
+	}
+	if total == 0 {
+		return nil, fmt.Errorf("soma dos pesos deve ser positiva")
+	}
+	return pesos, nil
+}
+
+// sortear nome proporcional ao peso (ordem fixa para a semente reproduzir a mistura)
+func sortear(rng *rand.Rand, pesos map[string]int) string {
+	nomes := make([]string, 0, len(pesos))
+	total := 0
+	for nome, peso := range pesos {
+		nomes = append(nomes, nome)
+		total += peso
+	}
+	sort.Strings(nomes)
+	r := rng.Intn(total)
+	for _, nome := range nomes {
+		if r < pesos[nome] {
+			return nome
+		}
+		r -= pesos[nome]
+	}
+	return nomes[len(nomes)-1]
+}
+
+// lerTokens tokens por tier ("free <token>" ou "assinante <token>")
+func lerTokens(path string) (map[string][]string, error) {
+	tokens := make(map[string][]string)
+	if path == "" {
+		return tokens, nil
+	}
+	linhas, err := lerLinhas(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, linha := range linhas {
+		tier, token, ok := strings.Cut(linha, " ")
+		if !ok || (tier != "free" && tier != "assinante") {
+			return nil, fmt.Errorf("esperado \"free|assinante <token>\": %q", linha)
+		}
+		tokens[tier] = append(tokens[tier], strings.TrimSpace(token))
+	}
+	return tokens, nil
+}
+
+// lerLinhas linhas nao vazias, ignorando comentarios (#)
+func lerLinhas(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var linhas []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		linha := strings.TrimSpace(scanner.Text())
+		if linha != "" && !strings.HasPrefix(linha, "#") {
+			linhas = append(linhas, linha)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(linhas) == 0 {
+		return nil, fmt.Errorf("%s vazio", path)
+	}
+	return linhas, nil
+}
+
+// descobrirOraculos coleta os idWilliamhill do snapshot do painel
+func descobrirOraculos(client *http.Client, baseURL string) ([]string, error) {
+	resp, err := client.Get(strings.TrimRight(baseURL, "/") + "/api/painel?countJogosMostrar=1000")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	var snapshot interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&snapshot); err != nil {
+		return nil, err
+	}
+
+	vistos := make(map[string]bool)
+	var ids []string
+	var coletar func(v interface{})
+	coletar = func(v interface{}) {
+		switch t := v.(type) {
+		case map[string]interface{}:
+			if id, ok := t["idWilliamhill"].(string); ok && id != "" && !vistos[id] {
+				vistos[id] = true
+				ids = append(ids, id)
+			}
+			for _, filho := range t {
+				coletar(filho)
+			}
+		case []interface{}:
+			for _, filho := range t {
+				coletar(filho)
+			}
+		}
+	}
+	coletar(snapshot)
+	sort.Strings(ids)
+	return ids, nil
+}
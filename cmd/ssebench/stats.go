@@ -0,0 +1,199 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// statsEndpoint metricas agregadas das conexoes de um endpoint
+type statsEndpoint struct {
+	nome   string
+	ativas atomic.Int64
+
+	mu          sync.Mutex
+	tentativas  int
+	conexoes    int
+	erros       map[string]int // status HTTP ou erro de rede
+	desconexoes map[string]int // stream encerrado antes do fim do teste
+	eventos     map[string]int // frames por tipo de evento
+	heartbeats  int
+	frames      int64
+	bytes       int64
+
+	connect  []time.Duration // ate os headers da resposta
+	ttff     []time.Duration // ate o primeiro frame com data
+	intervs  []time.Duration // entre frames consecutivos da mesma conexao
+	tamanhos []float64       // bytes por frame
+}
+
+func novoStatsEndpoint(nome string) *statsEndpoint {
+	return &statsEndpoint{
+		nome:        nome,
+		erros:       make(map[string]int),
+		desconexoes: make(map[string]int),
+		eventos:     make(map[string]int),
+	}
+}
+
+func (s *statsEndpoint) tentativa() {
+	s.mu.Lock()
+	s.tentativas++
+	s.mu.Unlock()
+}
+
+func (s *statsEndpoint) conectou(d time.Duration) {
+	s.mu.Lock()
+	s.conexoes++
+	s.connect = append(s.connect, d)
+	s.mu.Unlock()
+}
+
+func (s *statsEndpoint) erro(motivo string) {
+	s.mu.Lock()
+	s.erros[motivo]++
+	s.mu.Unlock()
+}
+
+func (s *statsEndpoint) desconexao(motivo string) {
+	s.mu.Lock()
+	s.desconexoes[motivo]++
+	s.mu.Unlock()
+}
+
+func (s *statsEndpoint) primeiroFrame(d time.Duration) {
+	s.mu.Lock()
+	s.ttff = append(s.ttff, d)
+	s.mu.Unlock()
+}
+
+func (s *statsEndpoint) intervalo(d time.Duration) {
+	s.mu.Lock()
+	s.intervs = append(s.intervs, d)
+	s.mu.Unlock()
+}
+
+func (s *statsEndpoint) frame(event string, bytes int) {
+	s.mu.Lock()
+	s.frames++
+	s.bytes += int64(bytes)
+	s.eventos[event]++
+	s.tamanhos = append(s.tamanhos, float64(bytes))
+	s.mu.Unlock()
+}
+
+func (s *statsEndpoint) heartbeat() {
+	s.mu.Lock()
+	s.heartbeats++
+	s.mu.Unlock()
+}
+
+// framesTotal frames recebidos ate agora (progresso)
+func (s *statsEndpoint) framesTotal() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.frames
+}
+
+// relatorio imprime contadores e percentis do endpoint
+func (s *statsEndpoint) relatorio(w io.Writer, duracao time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fmt.Fprintf(w, "\n== %s ==\n", s.nome)
+	fmt.Fprintf(w, "conexoes: %d tentativas, %d abertas, %d erros, %d desconexoes\n",
+		s.tentativas, s.conexoes, somar(s.erros), somar(s.desconexoes))
+	if len(s.erros) > 0 {
+		fmt.Fprintf(w, "  erros: %s\n", formatarContagem(s.erros))
+	}
+	if len(s.desconexoes) > 0 {
+		fmt.Fprintf(w, "  desconexoes: %s\n", formatarContagem(s.desconexoes))
+	}
+	fmt.Fprintf(w, "frames: %d (%.1f/s), %d KB, %d heartbeats\n",
+		s.frames, float64(s.frames)/duracao.Seconds(), s.bytes/1024, s.heartbeats)
+	if len(s.eventos) > 0 {
+		fmt.Fprintf(w, "  eventos: %s\n", formatarContagem(s.eventos))
+	}
+
+	fmt.Fprintf(w, "%-16s %10s %10s %10s %10s %10s\n", "", "p50", "p90", "p99", "max", "amostras")
+	linhaDuracoes(w, "connect", s.connect)
+	linhaDuracoes(w, "primeiro frame", s.ttff)
+	linhaDuracoes(w, "entre frames", s.intervs)
+	linhaValores(w, "bytes/frame", s.tamanhos)
+}
+
+func linhaDuracoes(w io.Writer, nome string, amostras []time.Duration) {
+	valores := make([]float64, len(amostras))
+	for i, d := range amostras {
+		valores[i] = float64(d)
+	}
+	p := percentis(valores)
+	if p == nil {
+		fmt.Fprintf(w, "%-16s %10s\n", nome, "-")
+		return
+	}
+	fmt.Fprintf(w, "%-16s %10v %10v %10v %10v %10d\n", nome,
+		arredondar(p[0]), arredondar(p[1]), arredondar(p[2]), arredondar(p[3]), len(amostras))
+}
+
+func linhaValores(w io.Writer, nome string, valores []float64) {
+	p := percentis(valores)
+	if p == nil {
+		fmt.Fprintf(w, "%-16s %10s\n", nome, "-")
+		return
+	}
+	fmt.Fprintf(w, "%-16s %10.0f %10.0f %10.0f %10.0f %10d\n", nome, p[0], p[1], p[2], p[3], len(valores))
+}
+
+// percentis p50, p90, p99 e max (nil sem amostras); ordena a copia
+func percentis(valores []float64) []float64 {
+	if len(valores) == 0 {
+		return nil
+	}
+	ordenados := append([]float64(nil), valores...)
+	sort.Float64s(ordenados)
+	p := func(q float64) float64 {
+		return ordenados[int(q*float64(len(ordenados)-1))]
+	}
+	return []float64{p(0.50), p(0.90), p(0.99), ordenados[len(ordenados)-1]}
+}
+
+// arredondar duracao legivel (ms com uma casa abaixo de 10s)
+func arredondar(ns float64) time.Duration {
+	d := time.Duration(ns)
+	if d < 10*time.Second {
+		return d.Round(100 * time.Microsecond)
+	}
+	return d.Round(time.Millisecond)
+}
+
+func somar(m map[string]int) int {
+	total := 0
+	for _, n := range m {
+		total += n
+	}
+	return total
+}
+
+// formatarContagem "motivo=n" em ordem decrescente de ocorrencias
+func formatarContagem(m map[string]int) string {
+	chaves := make([]string, 0, len(m))
+	for k := range m {
+		chaves = append(chaves, k)
+	}
+	sort.Slice(chaves, func(i, j int) bool {
+		if m[chaves[i]] != m[chaves[j]] {
+			return m[chaves[i]] > m[chaves[j]]
+		}
+		return chaves[i] < chaves[j]
+	})
+	partes := make([]string, len(chaves))
+	for i, k := range chaves {
+		partes[i] = fmt.Sprintf("%s=%d", k, m[k])
+	}
+	return strings.Join(partes, ", ")
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// frame evento SSE completo (terminado por linha em branco)
+type frame struct {
+	event string
+	bytes int // tamanho no fio (sem compressao), incluindo as linhas de campo
+}
+
+// leitorSSE callbacks do parser: eventos com data, comentarios (heartbeat) e retry:
+type leitorSSE struct {
+	frame     func(frame)
+	heartbeat func()
+	retry     func(time.Duration)
+}
+
+// lerFrames le o stream ate o fim, despachando cada evento na linha em branco
+func lerFrames(r io.Reader, l leitorSSE) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+
+	var atual frame
+	temData := false
+	for scanner.Scan() {
+		linha := scanner.Text()
+		atual.bytes += len(linha) + 1
+
+		switch {
+		case linha == "":
+			if temData {
+				if atual.event == "" {
+					atual.event = "message"
+				}
+				l.frame(atual)
+			}
+			atual, temData = frame{}, false
+		case strings.HasPrefix(linha, ":"):
+			l.heartbeat()
+			atual.bytes -= len(linha) + 1
+		case strings.HasPrefix(linha, "event:"):
+			atual.event = strings.TrimSpace(linha[len("event:"):])
+		case strings.HasPrefix(linha, "data:"):
+			temData = true
+		case strings.HasPrefix(linha, "retry:"):
+			if ms, err := strconv.Atoi(strings.TrimSpace(linha[len("retry:"):])); err == nil {
+				l.retry(time.Duration(ms) * time.Millisecond)
+			}
+		}
+	}
+	return scanner.Err()
+}
+
+// conexao uma conexao simulada (EventSource): reconecta ao cair, como o navegador
+type conexao struct {
+	client *http.Client
+	alvo   alvo
+	xff    string
+	stats  *statsEndpoint
+	retry  time.Duration
+	recon  bool
+}
+
+func (c *conexao) run(ctx context.Context) {
+	for ctx.Err() == nil {
+		c.tentar(ctx)
+		if !c.recon || ctx.Err() != nil {
+			return
+		}
+		select {
+		case <-time.After(c.retry):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// tentar abre o stream e consome ate cair ou o teste acabar
+func (c *conexao) tentar(ctx context.Context) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.alvo.url, nil)
+	if err != nil {
+		c.stats.erro(err.Error())
+		return
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Cache-Control", "no-cache")
+	if c.alvo.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.alvo.token)
+	}
+	if c.xff != "" {
+		req.Header.Set("X-Forwarded-For", c.xff)
+	}
+
+	inicio := time.Now()
+	c.stats.tentativa()
+	resp, err := c.client.Do(req)
+	if err != nil {
+		if ctx.Err() == nil {
+			c.stats.erro(resumirErro(err))
+		}
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+		c.stats.erro(fmt.Sprintf("HTTP %d", resp.StatusCode))
+		if ra, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && ra > 0 {
+			c.retry = time.Duration(ra) * time.Second
+		}
+		return
+	}
+	c.stats.conectou(time.Since(inicio))
+
+	var ultimo time.Time
+	c.stats.ativas.Add(1)
+	err = lerFrames(resp.Body, leitorSSE{
+		frame: func(f frame) {
+			agora := time.Now()
+			if ultimo.IsZero() {
+				c.stats.primeiroFrame(agora.Sub(inicio))
+			} else {
+				c.stats.intervalo(agora.Sub(ultimo))
+			}
+			ultimo = agora
+			c.stats.frame(f.event, f.bytes)
+		},
+		heartbeat: c.stats.heartbeat,
+		retry:     func(d time.Duration) { c.retry = d },
+	})
+	c.stats.ativas.Add(-1)
+
+	// Fim do stream antes do fim do teste = desconexao
+	if ctx.Err() == nil {
+		motivo := "EOF"
+		if err != nil {
+			motivo = resumirErro(err)
+		}
+		c.stats.desconexao(motivo)
+	}
+}
+
+// resumirErro remove a URL dos erros do client para agrupar no relatorio
+func resumirErro(err error) string {
+	msg := err.Error()
+	if i := strings.LastIndex(msg, ": "); i >= 0 {
+		msg = msg[i+2:]
+	}
+	return msg
+}
@@ -24,11 +24,18 @@ echo "[2/4] Build (otimizado para producao)..."
 go build -ldflags="-s -w" -o bin/radarfutebol-sse ./cmd/main.go
 
 echo ""
-echo "[3/4] Copiando binario e reiniciando..."
-systemctl stop sse-go
-sleep 1
-cp bin/radarfutebol-sse sse-server
-systemctl start sse-go
+echo "[3/4] Copiando binario e fazendo upgrade..."
+# mv (rename) troca o binario sem "Text file busy" com o processo rodando
+cp bin/radarfutebol-sse sse-server.new
+mv -f sse-server.new sse-server
+if systemctl is-active --quiet sse-go; then
+    # SIGUSR2 = upgrade: processo novo herda a porta e, quando pronto, o antigo
+    # faz o drain (SSE_DRAIN_WINDOW_SECONDS, padrao 20s) sem fechar a porta
+    # (systemctl reload = SIGHUP apenas recarrega a configuracao)
+    systemctl kill --kill-who=main --signal=SIGUSR2 sse-go
+else
+    systemctl start sse-go
+fi
 
 echo ""
 echo "[4/4] Verificando..."
@@ -0,0 +1,82 @@
+package codec
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// Major types CBOR (RFC 8949)
+const (
+	cborUint   = 0 << 5
+	cborNegInt = 1 << 5
+	cborBytes  = 2 << 5
+	cborText   = 3 << 5
+	cborArray  = 4 << 5
+	cborMap    = 5 << 5
+	cborSimple = 7 << 5
+)
+
+// cborWriter encoder CBOR (apenas itens de tamanho definido)
+type cborWriter struct {
+	buf []byte
+}
+
+func (w *cborWriter) bytes() []byte { return w.buf }
+
+// writeHead escreve o major type com o argumento no menor tamanho possivel
+func (w *cborWriter) writeHead(major byte, arg uint64) {
+	switch {
+	case arg < 24:
+		w.buf = append(w.buf, major|byte(arg))
+	case arg <= math.MaxUint8:
+		w.buf = append(w.buf, major|24, byte(arg))
+	case arg <= math.MaxUint16:
+		w.buf = append(w.buf, major|25)
+		w.buf = binary.BigEndian.AppendUint16(w.buf, uint16(arg))
+	case arg <= math.MaxUint32:
+		w.buf = append(w.buf, major|26)
+		w.buf = binary.BigEndian.AppendUint32(w.buf, uint32(arg))
+	default:
+		w.buf = append(w.buf, major|27)
+		w.buf = binary.BigEndian.AppendUint64(w.buf, arg)
+	}
+}
+
+func (w *cborWriter) writeNil() { w.buf = append(w.buf, cborSimple|22) }
+
+func (w *cborWriter) writeBool(b bool) {
+	if b {
+		w.buf = append(w.buf, cborSimple|21)
+	} else {
+		w.buf = append(w.buf, cborSimple|20)
+	}
+}
+
+func (w *cborWriter) writeInt(i int64) {
+	if i >= 0 {
+		w.writeHead(cborUint, uint64(i))
+		return
+	}
+	w.writeHead(cborNegInt, uint64(-1-i))
+}
+
+func (w *cborWriter) writeUint(u uint64) { w.writeHead(cborUint, u) }
+
+func (w *cborWriter) writeFloat(f float64) {
+	w.buf = append(w.buf, cborSimple|27)
+	w.buf = binary.BigEndian.AppendUint64(w.buf, math.Float64bits(f))
+}
+
+func (w *cborWriter) writeString(s string) {
+	w.writeHead(cborText, uint64(len(s)))
+	w.buf = append(w.buf, s...)
+}
+
+func (w *cborWriter) writeBinary(b []byte) {
+	w.writeHead(cborBytes, uint64(len(b)))
+	w.buf = append(w.buf, b...)
+}
+
+func (w *cborWriter) writeArrayHeader(n int) { w.writeHead(cborArray, uint64(n)) }
+
+func (w *cborWriter) writeMapHeader(n int) { w.writeHead(cborMap, uint64(n)) }
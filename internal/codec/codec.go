@@ -0,0 +1,307 @@
+// Package codec serializa os responses em JSON, MessagePack ou CBOR
+// Os encoders binarios seguem as mesmas tags json dos models, entao o cliente
+// recebe as mesmas chaves do JSON. FlexValue/FlexInt/FlexBool saem como
+// string/int/bool, igual ao MarshalJSON deles.
+package codec
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Formatos suportados (parametro encoding=)
+const (
+	JSON    = "json"
+	MsgPack = "msgpack"
+	CBOR    = "cbor"
+)
+
+// ParseFormat valida o parametro encoding= (vazio = json)
+func ParseFormat(s string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", JSON:
+		return JSON, nil
+	case MsgPack:
+		return MsgPack, nil
+	case CBOR:
+		return CBOR, nil
+	}
+	return "", fmt.Errorf("encoding nao suportado: %q (use json, msgpack ou cbor)", s)
+}
+
+// ContentType retorna o Content-Type do formato
+func ContentType(format string) string {
+	switch format {
+	case MsgPack:
+		return "application/msgpack"
+	case CBOR:
+		return "application/cbor"
+	}
+	return "application/json"
+}
+
+// Marshal serializa v no formato pedido
+func Marshal(format string, v interface{}) ([]byte, error) {
+	var w writer
+	switch format {
+	case "", JSON:
+		return json.Marshal(v)
+	case MsgPack:
+		w = &msgpackWriter{}
+	case CBOR:
+		w = &cborWriter{}
+	default:
+		return nil, fmt.Errorf("encoding nao suportado: %q", format)
+	}
+
+	if err := encode(w, reflect.ValueOf(v)); err != nil {
+		return nil, err
+	}
+	return w.bytes(), nil
+}
+
+// writer primitivas comuns a MessagePack e CBOR (ambos com tamanho definido no header)
+type writer interface {
+	writeNil()
+	writeBool(b bool)
+	writeInt(i int64)
+	writeUint(u uint64)
+	writeFloat(f float64)
+	writeString(s string)
+	writeBinary(b []byte)
+	writeArrayHeader(n int)
+	writeMapHeader(n int)
+	bytes() []byte
+}
+
+var jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+// encode percorre o valor por reflection respeitando as tags json
+func encode(w writer, v reflect.Value) error {
+	if !v.IsValid() {
+		w.writeNil()
+		return nil
+	}
+
+	// Tipos com MarshalJSON proprio que nao sao escalares (ex: projecao, RawMessage):
+	// usa o JSON deles como fonte. Flex* sao escalares e seguem pelo Kind.
+	if v.Type().Implements(jsonMarshalerType) && !isScalarKind(v.Kind()) {
+		if v.Kind() == reflect.Ptr && v.IsNil() {
+			w.writeNil()
+			return nil
+		}
+		data, err := v.Interface().(json.Marshaler).MarshalJSON()
+		if err != nil {
+			return err
+		}
+		var generic interface{}
+		if err := json.Unmarshal(data, &generic); err != nil {
+			return err
+		}
+		return encode(w, reflect.ValueOf(generic))
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			w.writeNil()
+			return nil
+		}
+		return encode(w, v.Elem())
+	case reflect.Bool:
+		w.writeBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		w.writeInt(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		w.writeUint(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		// Inteiros vindos de JSON generico (oraculo) saem como int, igual ao JSON
+		if f == math.Trunc(f) && math.Abs(f) < 1<<53 {
+			w.writeInt(int64(f))
+		} else {
+			w.writeFloat(f)
+		}
+	case reflect.String:
+		w.writeString(v.String())
+	case reflect.Slice:
+		if v.IsNil() {
+			w.writeNil()
+			return nil
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			w.writeBinary(v.Bytes())
+			return nil
+		}
+		fallthrough
+	case reflect.Array:
+		w.writeArrayHeader(v.Len())
+		for i := 0; i < v.Len(); i++ {
+			if err := encode(w, v.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if v.IsNil() {
+			w.writeNil()
+			return nil
+		}
+		return encodeMap(w, v)
+	case reflect.Struct:
+		return encodeStruct(w, v)
+	default:
+		return fmt.Errorf("tipo nao suportado: %s", v.Type())
+	}
+	return nil
+}
+
+func isScalarKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// encodeMap serializa mapa com chaves em ordem (saida deterministica, igual ao JSON)
+func encodeMap(w writer, v reflect.Value) error {
+	keys := v.MapKeys()
+	names := make([]string, len(keys))
+	for i, k := range keys {
+		names[i] = fmt.Sprint(k.Interface())
+	}
+	order := make([]int, len(keys))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(a, b int) bool { return names[order[a]] < names[order[b]] })
+
+	w.writeMapHeader(len(keys))
+	for _, i := range order {
+		w.writeString(names[i])
+		if err := encode(w, v.MapIndex(keys[i])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// structField campo serializavel de um struct
+type structField struct {
+	index     []int
+	name      string
+	omitEmpty bool
+}
+
+var structFieldsCache sync.Map // reflect.Type -> []structField
+
+// fieldsOf retorna os campos do struct conforme as tags json (com cache por tipo)
+// Campos embutidos sao promovidos; campo externo com mesmo nome prevalece
+func fieldsOf(t reflect.Type) []structField {
+	if cached, ok := structFieldsCache.Load(t); ok {
+		return cached.([]structField)
+	}
+
+	var fields []structField
+	seen := make(map[string]bool)
+	var embedded []reflect.StructField
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous {
+			embedded = append(embedded, f)
+			continue
+		}
+		if f.PkgPath != "" {
+			continue // nao exportado
+		}
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		parts := strings.Split(tag, ",")
+		name := parts[0]
+		if name == "" {
+			name = f.Name
+		}
+		omit := false
+		for _, opt := range parts[1:] {
+			if opt == "omitempty" {
+				omit = true
+			}
+		}
+		fields = append(fields, structField{index: f.Index, name: name, omitEmpty: omit})
+		seen[name] = true
+	}
+
+	for _, e := range embedded {
+		et := e.Type
+		if et.Kind() == reflect.Ptr {
+			et = et.Elem()
+		}
+		if et.Kind() != reflect.Struct {
+			continue
+		}
+		for _, inner := range fieldsOf(et) {
+			if seen[inner.name] {
+				continue
+			}
+			fields = append(fields, structField{
+				index:     append([]int{e.Index[0]}, inner.index...),
+				name:      inner.name,
+				omitEmpty: inner.omitEmpty,
+			})
+			seen[inner.name] = true
+		}
+	}
+
+	structFieldsCache.Store(t, fields)
+	return fields
+}
+
+// encodeStruct serializa struct como mapa chave -> valor
+func encodeStruct(w writer, v reflect.Value) error {
+	fields := fieldsOf(v.Type())
+
+	values := make([]reflect.Value, 0, len(fields))
+	names := make([]string, 0, len(fields))
+	for _, f := range fields {
+		fv, ok := fieldByIndex(v, f.index)
+		if !ok || (f.omitEmpty && fv.IsZero()) {
+			continue
+		}
+		values = append(values, fv)
+		names = append(names, f.name)
+	}
+
+	w.writeMapHeader(len(values))
+	for i, fv := range values {
+		w.writeString(names[i])
+		if err := encode(w, fv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fieldByIndex igual a reflect.Value.FieldByIndex, mas sem panic em ponteiro embutido nil
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, idx := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(idx)
+	}
+	return v, true
+}
@@ -0,0 +1,113 @@
+package codec
+
+import (
+	"bytes"
+	"testing"
+
+	"radarfutebol-sse/internal/models"
+)
+
+func TestMarshal_MsgPackVetores(t *testing.T) {
+	casos := []struct {
+		valor interface{}
+		want  []byte
+	}{
+		{nil, []byte{0xc0}},
+		{true, []byte{0xc3}},
+		{5, []byte{0x05}},
+		{-1, []byte{0xff}},
+		{300, []byte{0xcd, 0x01, 0x2c}},
+		{"ab", []byte{0xa2, 'a', 'b'}},
+		{[]int{1, 2}, []byte{0x92, 0x01, 0x02}},
+		{map[string]int{"b": 2, "a": 1}, []byte{0x82, 0xa1, 'a', 0x01, 0xa1, 'b', 0x02}},
+	}
+
+	for _, c := range casos {
+		got, err := Marshal(MsgPack, c.valor)
+		if err != nil {
+			t.Fatalf("%v: erro %v", c.valor, err)
+		}
+		if !bytes.Equal(got, c.want) {
+			t.Errorf("%v: got % x, want % x", c.valor, got, c.want)
+		}
+	}
+}
+
+func TestMarshal_CBORVetores(t *testing.T) {
+	casos := []struct {
+		valor interface{}
+		want  []byte
+	}{
+		{nil, []byte{0xf6}},
+		{false, []byte{0xf4}},
+		{23, []byte{0x17}},
+		{24, []byte{0x18, 0x18}},
+		{-10, []byte{0x29}},
+		{"a", []byte{0x61, 'a'}},
+		{[]string{"a"}, []byte{0x81, 0x61, 'a'}},
+		{1.5, []byte{0xfb, 0x3f, 0xf8, 0, 0, 0, 0, 0, 0}},
+	}
+
+	for _, c := range casos {
+		got, err := Marshal(CBOR, c.valor)
+		if err != nil {
+			t.Fatalf("%v: erro %v", c.valor, err)
+		}
+		if !bytes.Equal(got, c.want) {
+			t.Errorf("%v: got % x, want % x", c.valor, got, c.want)
+		}
+	}
+}
+
+// Flex* devem sair como escalares (igual ao JSON), com as chaves das tags json
+func TestMarshal_TiposFlexETags(t *testing.T) {
+	v := struct {
+		Odd    models.FlexValue `json:"odd"`
+		Gols   models.FlexInt   `json:"gols"`
+		Ativo  models.FlexBool  `json:"ativo"`
+		Oculto string           `json:"-"`
+	}{Odd: "1.5", Gols: 2, Ativo: true, Oculto: "x"}
+
+	got, err := Marshal(MsgPack, v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []byte{0x83,
+		0xa3, 'o', 'd', 'd', 0xa3, '1', '.', '5',
+		0xa4, 'g', 'o', 'l', 's', 0x02,
+		0xa5, 'a', 't', 'i', 'v', 'o', 0xc3,
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("got % x, want % x", got, want)
+	}
+}
+
+// Projecao (json.Marshaler) gera o mesmo conteudo do struct com os mesmos campos
+func TestMarshal_Projecao(t *testing.T) {
+	projecao, err := models.ParseProjecao("idEvento,timeCasa")
+	if err != nil {
+		t.Fatal(err)
+	}
+	evento := &models.Evento{IdEvento: 7, TimeCasa: "Time A"}
+
+	got, err := Marshal(CBOR, projecao.Evento(evento))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := Marshal(CBOR, map[string]interface{}{"idEvento": 7, "timeCasa": "Time A"})
+	if !bytes.Equal(got, want) {
+		t.Errorf("got % x, want % x", got, want)
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	if f, err := ParseFormat(""); err != nil || f != JSON {
+		t.Errorf("vazio deveria ser json, got %q %v", f, err)
+	}
+	if f, err := ParseFormat("MsgPack"); err != nil || f != MsgPack {
+		t.Errorf("got %q %v", f, err)
+	}
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Error("xml deveria ser rejeitado")
+	}
+}
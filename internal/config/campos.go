@@ -0,0 +1,244 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// campo valor configuravel do Config (folha da arvore de structs)
+type campo struct {
+	chave  string // caminho no TOML: "rate_limit.ip.rate"
+	env    string // variavel de ambiente: "RATE_LIMIT_IP_RATE"
+	unit   string // unidade da env de duracao legada (s, ms); vazio = "2s", "500ms"...
+	reload bool
+	secret bool
+	v      reflect.Value
+}
+
+// camposOrdenados lista os campos na ordem de declaracao
+// Structs aninhados viram secoes; a tag env deles e prefixo das envs internas
+func camposOrdenados(c *Config) []*campo {
+	var campos []*campo
+	var walk func(v reflect.Value, secao, prefixoEnv string)
+	walk = func(v reflect.Value, secao, prefixoEnv string) {
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			chave := f.Tag.Get("toml")
+			if chave == "" {
+				continue
+			}
+			if secao != "" {
+				chave = secao + "." + chave
+			}
+			env := f.Tag.Get("env")
+			if prefixoEnv != "" && env != "" {
+				env = prefixoEnv + "_" + env
+			}
+
+			if f.Type.Kind() == reflect.Struct && f.Type != durationType {
+				walk(v.Field(i), chave, env)
+				continue
+			}
+			campos = append(campos, &campo{
+				chave:  chave,
+				env:    env,
+				unit:   f.Tag.Get("unit"),
+				reload: f.Tag.Get("reload") == "true",
+				secret: f.Tag.Get("secret") == "true",
+				v:      v.Field(i),
+			})
+		}
+	}
+	walk(reflect.ValueOf(c).Elem(), "", "")
+	return campos
+}
+
+// camposDe indexa os campos pela chave do TOML
+func camposDe(c *Config) map[string]*campo {
+	campos := make(map[string]*campo)
+	for _, f := range camposOrdenados(c) {
+		campos[f.chave] = f
+	}
+	return campos
+}
+
+// setTOML atribui o valor lido do arquivo, conferindo o tipo
+func (f *campo) setTOML(valor interface{}) error {
+	switch {
+	case f.v.Type() == durationType:
+		s, ok := valor.(string)
+		if !ok {
+			return fmt.Errorf("esperado duracao entre aspas (ex: \"2s\"), obtido %v", valor)
+		}
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		f.v.SetInt(int64(d))
+	case f.v.Kind() == reflect.String:
+		s, ok := valor.(string)
+		if !ok {
+			return fmt.Errorf("esperado string, obtido %v", valor)
+		}
+		f.v.SetString(s)
+	case f.v.Kind() == reflect.Int:
+		i, ok := valor.(int64)
+		if !ok {
+			return fmt.Errorf("esperado inteiro, obtido %v", valor)
+		}
+		f.v.SetInt(i)
+	case f.v.Kind() == reflect.Float64:
+		switch n := valor.(type) {
+		case int64:
+			f.v.SetFloat(float64(n))
+		case float64:
+			f.v.SetFloat(n)
+		default:
+			return fmt.Errorf("esperado numero, obtido %v", valor)
+		}
+	case f.v.Kind() == reflect.Bool:
+		b, ok := valor.(bool)
+		if !ok {
+			return fmt.Errorf("esperado true ou false, obtido %v", valor)
+		}
+		f.v.SetBool(b)
+	case f.v.Kind() == reflect.Slice:
+		itens, ok := valor.([]interface{})
+		if !ok {
+			return fmt.Errorf("esperado array de strings, obtido %v", valor)
+		}
+		list := make([]string, 0, len(itens))
+		for _, item := range itens {
+			s, ok := item.(string)
+			if !ok {
+				return fmt.Errorf("esperado array de strings, item %v", item)
+			}
+			list = append(list, s)
+		}
+		f.v.Set(reflect.ValueOf(list))
+	default:
+		return fmt.Errorf("tipo nao suportado: %s", f.v.Type())
+	}
+	return nil
+}
+
+// setEnv atribui o valor da variavel de ambiente (listas separadas por virgula)
+func (f *campo) setEnv(value string) error {
+	switch {
+	case f.v.Type() == durationType:
+		var d time.Duration
+		switch f.unit {
+		case "s", "ms":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("esperado inteiro (%s): %q", f.unit, value)
+			}
+			d = time.Duration(n) * time.Second
+			if f.unit == "ms" {
+				d = time.Duration(n) * time.Millisecond
+			}
+		default:
+			var err error
+			if d, err = time.ParseDuration(value); err != nil {
+				return err
+			}
+		}
+		f.v.SetInt(int64(d))
+	case f.v.Kind() == reflect.String:
+		f.v.SetString(value)
+	case f.v.Kind() == reflect.Int:
+		i, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("esperado inteiro: %q", value)
+		}
+		f.v.SetInt(int64(i))
+	case f.v.Kind() == reflect.Float64:
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("esperado numero: %q", value)
+		}
+		f.v.SetFloat(n)
+	case f.v.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("esperado true ou false: %q", value)
+		}
+		f.v.SetBool(b)
+	case f.v.Kind() == reflect.Slice:
+		var list []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+		f.v.Set(reflect.ValueOf(list))
+	default:
+		return fmt.Errorf("tipo nao suportado: %s", f.v.Type())
+	}
+	return nil
+}
+
+func (f *campo) equal(other *campo) bool {
+	return reflect.DeepEqual(f.v.Interface(), other.v.Interface())
+}
+
+func (f *campo) set(other *campo) {
+	f.v.Set(other.v)
+}
+
+// formatTOML valor no formato do arquivo de configuracao
+func (f *campo) formatTOML() string {
+	if f.secret && f.v.String() != "" {
+		return `"********"`
+	}
+	switch {
+	case f.v.Type() == durationType:
+		return strconv.Quote(time.Duration(f.v.Int()).String())
+	case f.v.Kind() == reflect.String:
+		return strconv.Quote(f.v.String())
+	case f.v.Kind() == reflect.Float64:
+		return strconv.FormatFloat(f.v.Float(), 'g', -1, 64)
+	case f.v.Kind() == reflect.Slice:
+		itens := make([]string, f.v.Len())
+		for i := range itens {
+			itens[i] = strconv.Quote(f.v.Index(i).String())
+		}
+		return "[" + strings.Join(itens, ", ") + "]"
+	}
+	return fmt.Sprint(f.v.Interface())
+}
+
+// Print escreve a configuracao efetiva em TOML, com a origem de cada valor
+// (padrao, arquivo ou env) e se e recarregavel no SIGHUP. Segredos sao mascarados
+func (c *Config) Print(w io.Writer) {
+	secao := ""
+	for _, f := range camposOrdenados(c) {
+		s, chave := "", f.chave
+		if i := strings.LastIndex(f.chave, "."); i >= 0 {
+			s, chave = f.chave[:i], f.chave[i+1:]
+		}
+		if s != secao {
+			if secao != "" {
+				fmt.Fprintln(w)
+			}
+			fmt.Fprintf(w, "[%s]\n", s)
+			secao = s
+		}
+
+		fonte := c.fontes[f.chave]
+		if fonte == "" {
+			fonte = "padrao"
+		}
+		if f.reload {
+			fonte += ", recarregavel"
+		}
+		fmt.Fprintf(w, "%s = %s # %s\n", chave, f.formatTOML(), fonte)
+	}
+}
@@ -1,66 +1,405 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"os"
-	"strconv"
+	"sort"
+	"time"
 )
 
+// Config configuracao do servidor
+// Ordem de precedencia: padrao < arquivo TOML (--config) < variaveis de ambiente
+// Tags: toml = chave no arquivo, env = variavel de ambiente, reload = aplicado no SIGHUP
+// sem reiniciar, secret = mascarado no --print-config, unit = unidade da env de duracao
 type Config struct {
-	MySQL MySQLConfig
-	Redis RedisConfig
-	Server ServerConfig
+	MySQL     MySQLConfig     `toml:"mysql"`
+	Redis     RedisConfig     `toml:"redis"`
+	Server    ServerConfig    `toml:"server"`
+	Stream    StreamConfig    `toml:"stream"`
+	RateLimit RateLimitConfig `toml:"rate_limit"`
+	Auth      AuthConfig      `toml:"auth"`
+	Oraculo   OraculoConfig   `toml:"oraculo"`
+	Breaker   BreakerConfig   `toml:"breaker"`
+	WarmStart WarmStartConfig `toml:"warm_start"`
+
+	EventSource EventSourceConfig `toml:"event_source"`
+
+	// fontes origem de cada valor (padrao, arquivo ou env) para o --print-config
+	fontes map[string]string
 }
 
 type MySQLConfig struct {
-	Host     string
-	Port     int
-	User     string
-	Password string
-	Database string
+	Host     string `toml:"host" env:"MYSQL_HOST"`
+	Port     int    `toml:"port" env:"MYSQL_PORT"`
+	User     string `toml:"user" env:"MYSQL_USER"`
+	Password string `toml:"password" env:"MYSQL_PASSWORD" secret:"true"`
+	Database string `toml:"database" env:"MYSQL_DATABASE"`
+
+	QueryTimeout time.Duration `toml:"query_timeout" env:"MYSQL_QUERY_TIMEOUT"` // token e status de evento; lento conta como falha no breaker
 }
 
 type RedisConfig struct {
-	Host     string
-	Port     int
-	Password string
+	Host     string `toml:"host" env:"REDIS_HOST"`
+	Port     int    `toml:"port" env:"REDIS_PORT"`
+	Password string `toml:"password" env:"REDIS_PASSWORD" secret:"true"`
+
+	DB           int `toml:"db" env:"REDIS_DB"` // cache principal (eventos e oraculo)
+	PoolSize     int `toml:"pool_size" env:"REDIS_POOL_SIZE"`
+	MinIdleConns int `toml:"min_idle_conns" env:"REDIS_MIN_IDLE_CONNS"`
+
+	PrefsDB           int `toml:"prefs_db" env:"REDIS_PREFS_DB"` // preferencias/favoritos do usuario
+	PrefsPoolSize     int `toml:"prefs_pool_size" env:"REDIS_PREFS_POOL_SIZE"`
+	PrefsMinIdleConns int `toml:"prefs_min_idle_conns" env:"REDIS_PREFS_MIN_IDLE_CONNS"`
+
+	EventosKey string `toml:"eventos_key" env:"REDIS_EVENTOS_KEY"` // JSON dos eventos gravado pelo Laravel
 }
 
 type ServerConfig struct {
-	Port int
+	Port     int    `toml:"port" env:"SERVER_PORT"`
+	MaxConns int    `toml:"max_conns" env:"SSE_MAX_CONNS" reload:"true"` // 0 = sem limite
+	LogLevel string `toml:"log_level" env:"LOG_LEVEL" reload:"true"`     // debug, info ou warn
+
+	// /readyz falha se a ultima leitura dos eventos no Redis for mais velha que isso
+	ReadyMaxSnapshotAge time.Duration `toml:"ready_max_snapshot_age" env:"SSE_READY_MAX_SNAPSHOT_AGE" reload:"true"`
+
+	// Compressao dos streams SSE (gzip/deflate negociado por Accept-Encoding)
+	// Cada stream comprimido reserva ~800 KB (janela e tabelas do deflate) enquanto estiver aberto
+	Compression           bool `toml:"compression" env:"SSE_COMPRESSION"`
+	CompressionLevel      int  `toml:"compression_level" env:"SSE_COMPRESSION_LEVEL"`                           // 1 (rapido) a 9 (menor payload)
+	CompressionMaxStreams int  `toml:"compression_max_streams" env:"SSE_COMPRESSION_MAX_STREAMS" reload:"true"` // acima disso o stream vai sem compressao
+
+	// Drain no deploy: streams encerrados aos poucos ao longo da janela,
+	// cada um com retry: sorteado entre DrainRetryMin e DrainRetryMax
+	DrainWindow   time.Duration `toml:"drain_window" env:"SSE_DRAIN_WINDOW_SECONDS" unit:"s" reload:"true"`
+	DrainRetryMin time.Duration `toml:"drain_retry_min" env:"SSE_DRAIN_RETRY_MIN_MS" unit:"ms" reload:"true"`
+	DrainRetryMax time.Duration `toml:"drain_retry_max" env:"SSE_DRAIN_RETRY_MAX_MS" unit:"ms" reload:"true"`
+
+	// Upgrade por SIGUSR2: tempo maximo para o processo novo ficar pronto
+	HandoffTimeout time.Duration `toml:"handoff_timeout" env:"SSE_HANDOFF_TIMEOUT_SECONDS" unit:"s" reload:"true"`
+}
+
+// StreamConfig cadencia dos updates por tier e limites do event: status
+type StreamConfig struct {
+	TickerAssinante time.Duration `toml:"ticker_assinante" env:"SSE_TICKER_ASSINANTE" reload:"true"`
+	TickerFree      time.Duration `toml:"ticker_free" env:"SSE_TICKER_FREE" reload:"true"`
+
+	DegradedAfter time.Duration `toml:"degraded_after" env:"SSE_DEGRADED_AFTER" reload:"true"` // sem leitura bem sucedida do Redis
+	StaleAfter    time.Duration `toml:"stale_after" env:"SSE_STALE_AFTER" reload:"true"`       // JSON parado com jogos ao vivo
+}
+
+// AuthConfig configuracoes de autenticacao
+type AuthConfig struct {
+	CacheSecret string `toml:"cache_secret" env:"AUTH_CACHE_SECRET" secret:"true"` // Chave HMAC usada para gerar as chaves sse-auth: no Redis
+
+	CacheTTL         time.Duration `toml:"cache_ttl" env:"AUTH_CACHE_TTL"`                   // Cache do token valido no Redis
+	NegativeCacheTTL time.Duration `toml:"negative_cache_ttl" env:"AUTH_NEGATIVE_CACHE_TTL"` // Cache de token invalido (evita brute-force no MySQL)
+
+	// JWT (opcional): tokens assinados dispensam consulta ao MySQL
+	JWTSecret        string `toml:"jwt_secret" env:"JWT_SECRET" secret:"true"`     // Segredo HS256
+	JWTPublicKeyFile string `toml:"jwt_public_key_file" env:"JWT_PUBLIC_KEY_FILE"` // Chave publica RS256 em PEM
+	JWTJWKSFile      string `toml:"jwt_jwks_file" env:"JWT_JWKS_FILE"`             // Arquivo JWKS (recarregado ao mudar, para rotacao de chaves)
+	JWTIssuer        string `toml:"jwt_issuer" env:"JWT_ISSUER"`                   // Se definido, exige claim iss igual
+
+	// Revogacao/mudanca de plano fica no Redis por este tempo e vale para JWTs emitidos antes dela
+	// Deve cobrir a validade dos JWTs emitidos pelo Laravel (depois disso os tokens antigos ja expiraram)
+	JWTRevocationTTL time.Duration `toml:"jwt_revocation_ttl" env:"JWT_REVOCATION_TTL"`
+
+	// MySQL indisponivel (erro ou circuito aberto) e token fora do cache: conecta como anonimo
+	// em vez de responder 503. Desligado, o cliente reconecta depois (Retry-After)
+	AnonymousFallback bool `toml:"anonymous_fallback" env:"AUTH_ANONYMOUS_FALLBACK" reload:"true"`
+}
+
+// OraculoConfig cadencia e caches do oraculo
+type OraculoConfig struct {
+	HubInterval   time.Duration `toml:"hub_interval" env:"ORACULO_HUB_INTERVAL"`       // Atualizacao de cada jogo assistido
+	EventoInfoTTL time.Duration `toml:"evento_info_ttl" env:"ORACULO_EVENTO_INFO_TTL"` // Cache do status de jogos fora do snapshot (MySQL)
 }
 
-func Load() (*Config, error) {
+// BreakerConfig circuit breakers de Redis, Redis de preferencias e MySQL
+type BreakerConfig struct {
+	FailureThreshold int           `toml:"failure_threshold" env:"BREAKER_FAILURE_THRESHOLD" reload:"true"` // Falhas seguidas para abrir o circuito
+	OpenTimeout      time.Duration `toml:"open_timeout" env:"BREAKER_OPEN_TIMEOUT" reload:"true"`           // Espera ate a primeira sondagem (half-open)
+	MaxOpenTimeout   time.Duration `toml:"max_open_timeout" env:"BREAKER_MAX_OPEN_TIMEOUT" reload:"true"`   // Teto da espera (dobra a cada sondagem que falha)
+}
+
+// EventSourceConfig fontes da lista de eventos em ordem de preferencia (failover automatico
+// quando a fonte esta fora, sem dados ou com JSON invalido)
+type EventSourceConfig struct {
+	Order         []string      `toml:"order" env:"SSE_EVENT_SOURCES"`                        // redis, mysql, file e/ou replay
+	File          string        `toml:"file" env:"SSE_EVENT_SOURCE_FILE"`                     // JSON local no formato da chave do Laravel
+	MySQLInterval time.Duration `toml:"mysql_interval" env:"SSE_EVENT_SOURCE_MYSQL_INTERVAL"` // Consulta no maximo uma vez por intervalo
+
+	ReplayFile  string  `toml:"replay_file" env:"SSE_REPLAY_FILE"`   // gravacao do cmd/recorder (eventos e oraculos)
+	ReplaySpeed float64 `toml:"replay_speed" env:"SSE_REPLAY_SPEED"` // 1 = tempo real, 10 = 10x mais rapido
+	ReplayLoop  bool    `toml:"replay_loop" env:"SSE_REPLAY_LOOP"`   // recomeca do inicio ao chegar no fim
+}
+
+// Usa indica se a fonte de eventos esta na ordem configurada
+func (c EventSourceConfig) Usa(nome string) bool {
+	for _, fonte := range c.Order {
+		if fonte == nome {
+			return true
+		}
+	}
+	return false
+}
+
+// WarmStartConfig snapshot em disco para o restart nao comecar vazio
+type WarmStartConfig struct {
+	File     string        `toml:"file" env:"SSE_WARM_START_FILE"`         // vazio = desligado
+	Interval time.Duration `toml:"interval" env:"SSE_WARM_START_INTERVAL"` // alem de salvar no shutdown
+	MaxAge   time.Duration `toml:"max_age" env:"SSE_WARM_START_MAX_AGE"`   // arquivo mais velho que isso e ignorado
+}
+
+// RateLimitConfig limites de novas conexoes por IP, por usuario (por tier) e streams simultaneos por IP
+// Os snapshots REST (/api/...) tem limite proprio por IP e nao ocupam stream
+type RateLimitConfig struct {
+	Enabled         bool     `toml:"enabled" env:"RATE_LIMIT_ENABLED" reload:"true"`
+	TrustedProxies  []string `toml:"trusted_proxies" env:"TRUSTED_PROXIES"`                        // IPs dos proxies (nginx) dos quais aceitamos X-Forwarded-For
+	MaxStreamsPerIP int      `toml:"max_streams_ip" env:"RATE_LIMIT_MAX_STREAMS_IP" reload:"true"` // Streams simultaneos por IP (0 = sem limite)
+
+	IP        TierLimit `toml:"ip" env:"RATE_LIMIT_IP"`               // Aplicado a toda nova conexao, chave = IP do cliente
+	Free      TierLimit `toml:"free" env:"RATE_LIMIT_FREE"`           // Usuario logado nao assinante, chave = idUsuario
+	Assinante TierLimit `toml:"assinante" env:"RATE_LIMIT_ASSINANTE"` // Usuario assinante, chave = idUsuario
+	Requests  TierLimit `toml:"requests" env:"RATE_LIMIT_REQUESTS"`   // Snapshots REST, chave = IP do cliente
+}
+
+// TierLimit token bucket: Rate conexoes por minuto, com rajada de ate Burst
+type TierLimit struct {
+	Rate  float64 `toml:"rate" env:"RATE" reload:"true"`
+	Burst int     `toml:"burst" env:"BURST" reload:"true"`
+}
+
+// Defaults configuracao padrao (valores usados antes do arquivo de configuracao)
+func Defaults() *Config {
 	return &Config{
 		MySQL: MySQLConfig{
-			Host:     getEnv("MYSQL_HOST", "127.0.0.1"),
-			Port:     getEnvInt("MYSQL_PORT", 3306),
-			User:     getEnv("MYSQL_USER", "radar"),
-			Password: getEnv("MYSQL_PASSWORD", ""),
-			Database: getEnv("MYSQL_DATABASE", "radarfutebol"),
+			Host:     "127.0.0.1",
+			Port:     3306,
+			User:     "radar",
+			Database: "radarfutebol",
+
+			QueryTimeout: 2 * time.Second,
 		},
 		Redis: RedisConfig{
-			Host:     getEnv("REDIS_HOST", "127.0.0.1"),
-			Port:     getEnvInt("REDIS_PORT", 6379),
-			Password: getEnv("REDIS_PASSWORD", ""),
+			Host:              "127.0.0.1",
+			Port:              6379,
+			DB:                0,
+			PoolSize:          100,
+			MinIdleConns:      10,
+			PrefsDB:           2,
+			PrefsPoolSize:     50,
+			PrefsMinIdleConns: 5,
+			EventosKey:        "eventos-painel-json",
 		},
 		Server: ServerConfig{
-			Port: getEnvInt("SERVER_PORT", 3005),
+			Port:     3005,
+			MaxConns: 10000,
+			LogLevel: "info",
+
+			ReadyMaxSnapshotAge:   30 * time.Second,
+			Compression:           false,
+			CompressionLevel:      1,
+			CompressionMaxStreams: 500,
+			DrainWindow:           20 * time.Second,
+			DrainRetryMin:         5 * time.Second,
+			DrainRetryMax:         30 * time.Second,
+			HandoffTimeout:        30 * time.Second,
+		},
+		Stream: StreamConfig{
+			TickerAssinante: 2 * time.Second,
+			TickerFree:      10 * time.Second,
+			DegradedAfter:   10 * time.Second,
+			StaleAfter:      90 * time.Second,
+		},
+		Auth: AuthConfig{
+			CacheTTL:         5 * time.Minute,
+			NegativeCacheTTL: 30 * time.Second,
+			JWTRevocationTTL: time.Hour,
 		},
-	}, nil
+		Oraculo: OraculoConfig{
+			HubInterval:   2 * time.Second,
+			EventoInfoTTL: 10 * time.Second,
+		},
+		Breaker: BreakerConfig{
+			FailureThreshold: 5,
+			OpenTimeout:      2 * time.Second,
+			MaxOpenTimeout:   time.Minute,
+		},
+		EventSource: EventSourceConfig{
+			Order:         []string{"redis", "mysql"},
+			MySQLInterval: 10 * time.Second,
+			ReplaySpeed:   1,
+		},
+		WarmStart: WarmStartConfig{
+			Interval: 30 * time.Second,
+			MaxAge:   6 * time.Hour,
+		},
+		RateLimit: RateLimitConfig{
+			Enabled:         true,
+			TrustedProxies:  []string{"127.0.0.1", "::1"},
+			MaxStreamsPerIP: 50,
+			IP:              TierLimit{Rate: 60, Burst: 30},
+			Free:            TierLimit{Rate: 12, Burst: 10},
+			Assinante:       TierLimit{Rate: 30, Burst: 20},
+			Requests:        TierLimit{Rate: 120, Burst: 60},
+		},
+	}
+}
+
+// Load monta a configuracao: padrao, arquivo TOML (path vazio = sem arquivo) e env
+// Retorna erro se o arquivo ou alguma variavel for invalida ou se a validacao falhar;
+// nesse caso a configuracao tambem e retornada (para o --print-config mostrar o que foi lido)
+func Load(path string) (*Config, error) {
+	cfg := Defaults()
+	cfg.fontes = make(map[string]string)
+
+	var erros []error
+	if path != "" {
+		if err := cfg.loadFile(path); err != nil {
+			erros = append(erros, err)
+		}
+	}
+	erros = append(erros, cfg.loadEnv()...)
+	if err := cfg.Validate(); err != nil {
+		erros = append(erros, err)
+	}
+
+	return cfg, errors.Join(erros...)
+}
+
+// loadFile aplica o arquivo TOML (chave desconhecida e erro)
+func (c *Config) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("arquivo de configuracao: %w", err)
+	}
+	valores, err := parseTOML(string(data))
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	campos := camposDe(c)
+	var erros []error
+	for chave, valor := range valores {
+		campo, ok := campos[chave]
+		if !ok {
+			erros = append(erros, fmt.Errorf("%s: chave desconhecida: %s", path, chave))
+			continue
+		}
+		if err := campo.setTOML(valor); err != nil {
+			erros = append(erros, fmt.Errorf("%s: %s: %w", path, chave, err))
+			continue
+		}
+		c.fontes[chave] = "arquivo"
+	}
+	return errors.Join(erros...)
+}
+
+// loadEnv aplica as variaveis de ambiente definidas (valor vazio = nao definida)
+func (c *Config) loadEnv() []error {
+	var erros []error
+	for chave, campo := range camposDe(c) {
+		value := os.Getenv(campo.env)
+		if value == "" {
+			continue
+		}
+		if err := campo.setEnv(value); err != nil {
+			erros = append(erros, fmt.Errorf("%s: %w", campo.env, err))
+			continue
+		}
+		c.fontes[chave] = "env " + campo.env
+	}
+	return erros
 }
 
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
+// Validate verifica limites e combinacoes dos valores
+func (c *Config) Validate() error {
+	var erros []error
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			erros = append(erros, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(c.Server.Port > 0 && c.Server.Port <= 65535, "server.port fora do intervalo 1-65535: %d", c.Server.Port)
+	check(c.Server.MaxConns >= 0, "server.max_conns nao pode ser negativo")
+	check(c.Server.LogLevel == "debug" || c.Server.LogLevel == "info" || c.Server.LogLevel == "warn",
+		"server.log_level deve ser debug, info ou warn: %q", c.Server.LogLevel)
+	check(c.Server.CompressionMaxStreams >= 0, "server.compression_max_streams nao pode ser negativo")
+	check(c.Server.CompressionLevel >= 1 && c.Server.CompressionLevel <= 9, "server.compression_level deve ser de 1 a 9: %d", c.Server.CompressionLevel)
+	check(c.Server.DrainWindow >= 0, "server.drain_window nao pode ser negativo")
+	check(c.Server.DrainRetryMin > 0 && c.Server.DrainRetryMin <= c.Server.DrainRetryMax,
+		"server.drain_retry_min (%v) deve ser positivo e <= drain_retry_max (%v)", c.Server.DrainRetryMin, c.Server.DrainRetryMax)
+	check(c.Server.HandoffTimeout > 0, "server.handoff_timeout deve ser positivo")
+	check(c.Server.ReadyMaxSnapshotAge > 0, "server.ready_max_snapshot_age deve ser positivo")
+
+	check(c.Stream.TickerAssinante >= 500*time.Millisecond, "stream.ticker_assinante minimo 500ms: %v", c.Stream.TickerAssinante)
+	check(c.Stream.TickerFree >= c.Stream.TickerAssinante, "stream.ticker_free (%v) nao pode ser menor que ticker_assinante (%v)", c.Stream.TickerFree, c.Stream.TickerAssinante)
+	check(c.Stream.DegradedAfter >= 2*time.Second, "stream.degraded_after minimo 2s (ciclo de leitura do Redis): %v", c.Stream.DegradedAfter)
+	check(c.Stream.StaleAfter >= time.Minute, "stream.stale_after minimo 1m (relogio dos jogos muda a cada minuto): %v", c.Stream.StaleAfter)
+
+	check(c.MySQL.Port > 0 && c.MySQL.Port <= 65535, "mysql.port fora do intervalo 1-65535: %d", c.MySQL.Port)
+	check(c.MySQL.QueryTimeout > 0, "mysql.query_timeout deve ser positivo")
+
+	check(c.Redis.Port > 0 && c.Redis.Port <= 65535, "redis.port fora do intervalo 1-65535: %d", c.Redis.Port)
+	check(c.Redis.DB >= 0 && c.Redis.DB <= 15, "redis.db deve ser de 0 a 15: %d", c.Redis.DB)
+	check(c.Redis.PrefsDB >= 0 && c.Redis.PrefsDB <= 15, "redis.prefs_db deve ser de 0 a 15: %d", c.Redis.PrefsDB)
+	check(c.Redis.PoolSize > 0 && c.Redis.MinIdleConns >= 0 && c.Redis.MinIdleConns <= c.Redis.PoolSize,
+		"redis.pool_size deve ser positivo e >= min_idle_conns")
+	check(c.Redis.PrefsPoolSize > 0 && c.Redis.PrefsMinIdleConns >= 0 && c.Redis.PrefsMinIdleConns <= c.Redis.PrefsPoolSize,
+		"redis.prefs_pool_size deve ser positivo e >= prefs_min_idle_conns")
+	check(c.Redis.EventosKey != "", "redis.eventos_key obrigatorio")
+
+	check(c.Auth.CacheTTL > 0 && c.Auth.NegativeCacheTTL > 0, "auth.cache_ttl e auth.negative_cache_ttl devem ser positivos")
+	check(c.Auth.JWTRevocationTTL > 0, "auth.jwt_revocation_ttl deve ser positivo")
+	check(c.Oraculo.HubInterval >= 500*time.Millisecond, "oraculo.hub_interval minimo 500ms: %v", c.Oraculo.HubInterval)
+	check(c.Oraculo.EventoInfoTTL > 0, "oraculo.evento_info_ttl deve ser positivo")
+
+	check(c.Breaker.FailureThreshold >= 1, "breaker.failure_threshold minimo 1: %d", c.Breaker.FailureThreshold)
+	check(c.Breaker.OpenTimeout > 0 && c.Breaker.OpenTimeout <= c.Breaker.MaxOpenTimeout,
+		"breaker.open_timeout (%v) deve ser positivo e <= max_open_timeout (%v)", c.Breaker.OpenTimeout, c.Breaker.MaxOpenTimeout)
+	check(c.WarmStart.Interval >= time.Second, "warm_start.interval minimo 1s: %v", c.WarmStart.Interval)
+	check(c.WarmStart.MaxAge > 0, "warm_start.max_age deve ser positivo")
+
+	check(len(c.EventSource.Order) > 0, "event_source.order obrigatorio")
+	vistas := make(map[string]bool)
+	for _, fonte := range c.EventSource.Order {
+		check(fonte == "redis" || fonte == "mysql" || fonte == "file" || fonte == "replay", "event_source.order: fonte desconhecida %q (redis, mysql, file ou replay)", fonte)
+		check(!vistas[fonte], "event_source.order: fonte %q repetida", fonte)
+		vistas[fonte] = true
 	}
-	return defaultValue
+	check(!vistas["file"] || c.EventSource.File != "", "event_source.file obrigatorio com a fonte file")
+	check(!vistas["replay"] || c.EventSource.ReplayFile != "", "event_source.replay_file obrigatorio com a fonte replay")
+	check(c.EventSource.ReplaySpeed > 0, "event_source.replay_speed deve ser positivo: %v", c.EventSource.ReplaySpeed)
+	check(c.EventSource.MySQLInterval >= time.Second, "event_source.mysql_interval minimo 1s: %v", c.EventSource.MySQLInterval)
+
+	check(c.RateLimit.MaxStreamsPerIP >= 0, "rate_limit.max_streams_ip nao pode ser negativo")
+	for nome, limit := range map[string]TierLimit{"ip": c.RateLimit.IP, "free": c.RateLimit.Free, "assinante": c.RateLimit.Assinante, "requests": c.RateLimit.Requests} {
+		check(limit.Rate >= 0 && limit.Burst >= 0, "rate_limit.%s: rate e burst nao podem ser negativos", nome)
+	}
+
+	return errors.Join(erros...)
 }
 
-func getEnvInt(key string, defaultValue int) int {
-	if value := os.Getenv(key); value != "" {
-		if intValue, err := strconv.Atoi(value); err == nil {
-			return intValue
+// Reload retorna a configuracao a aplicar no SIGHUP: valores com reload:"true" vem de
+// next, os demais continuam os de c. ignorados lista as chaves alteradas que exigem restart
+func (c *Config) Reload(next *Config) (aplicada *Config, ignorados []string) {
+	aplicada = Defaults()
+	*aplicada = *next
+
+	atuais := camposDe(c)
+	for chave, campo := range camposDe(aplicada) {
+		if campo.reload {
+			continue
+		}
+		atual := atuais[chave]
+		if !campo.equal(atual) {
+			ignorados = append(ignorados, chave)
 		}
+		campo.set(atual)
 	}
-	return defaultValue
+	sort.Strings(ignorados)
+	return aplicada, ignorados
 }
@@ -0,0 +1,116 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func escreverConfig(t *testing.T, conteudo string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte(conteudo), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad_PadraoArquivoEnv(t *testing.T) {
+	path := escreverConfig(t, `
+# comentario
+[server]
+max_conns = 5_000 # limite menor
+log_level = "debug"
+
+[stream]
+ticker_free = "15s"
+
+[rate_limit]
+trusted_proxies = ["10.0.0.1", '10.0.0.2']
+
+[rate_limit.free]
+rate = 6
+`)
+	t.Setenv("SSE_MAX_CONNS", "7000")
+	t.Setenv("RATE_LIMIT_FREE_BURST", "3")
+	t.Setenv("SSE_DRAIN_WINDOW_SECONDS", "5")
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cfg.Server.MaxConns != 7000 {
+		t.Errorf("Env deveria prevalecer sobre o arquivo: %d", cfg.Server.MaxConns)
+	}
+	if cfg.Server.LogLevel != "debug" || cfg.Stream.TickerFree != 15*time.Second {
+		t.Errorf("Valores do arquivo nao aplicados: %q %v", cfg.Server.LogLevel, cfg.Stream.TickerFree)
+	}
+	if cfg.Stream.TickerAssinante != 2*time.Second || cfg.Redis.EventosKey != "eventos-painel-json" {
+		t.Error("Padroes deveriam valer para chaves ausentes")
+	}
+	if cfg.RateLimit.Free.Rate != 6 || cfg.RateLimit.Free.Burst != 3 {
+		t.Errorf("Secao aninhada/env com prefixo: %+v", cfg.RateLimit.Free)
+	}
+	if !reflect.DeepEqual(cfg.RateLimit.TrustedProxies, []string{"10.0.0.1", "10.0.0.2"}) {
+		t.Errorf("Array: %v", cfg.RateLimit.TrustedProxies)
+	}
+	if cfg.Server.DrainWindow != 5*time.Second {
+		t.Errorf("Env legada em segundos: %v", cfg.Server.DrainWindow)
+	}
+
+	var out strings.Builder
+	cfg.Print(&out)
+	for _, linha := range []string{
+		`max_conns = 7000 # env SSE_MAX_CONNS, recarregavel`,
+		`ticker_free = "15s" # arquivo, recarregavel`,
+		`port = 3005 # padrao`,
+		"[rate_limit.free]",
+	} {
+		if !strings.Contains(out.String(), linha) {
+			t.Errorf("--print-config sem %q:\n%s", linha, out.String())
+		}
+	}
+}
+
+func TestLoad_Invalido(t *testing.T) {
+	path := escreverConfig(t, `
+[server]
+max_conns = "muitas"
+compression_level = 12
+portt = 1
+`)
+	_, err := Load(path)
+	if err == nil {
+		t.Fatal("Esperava erro de validacao")
+	}
+	for _, trecho := range []string{"esperado inteiro", "compression_level", "chave desconhecida: server.portt"} {
+		if !strings.Contains(err.Error(), trecho) {
+			t.Errorf("Erro sem %q: %v", trecho, err)
+		}
+	}
+}
+
+func TestReload_ApenasValoresRecarregaveis(t *testing.T) {
+	atual := Defaults()
+	next := Defaults()
+	next.Server.MaxConns = 500
+	next.Stream.TickerAssinante = 3 * time.Second
+	next.Server.Port = 4000
+	next.Redis.EventosKey = "outra-chave"
+
+	aplicada, ignorados := atual.Reload(next)
+
+	if aplicada.Server.MaxConns != 500 || aplicada.Stream.TickerAssinante != 3*time.Second {
+		t.Error("Valores recarregaveis deveriam ser aplicados")
+	}
+	if aplicada.Server.Port != 3005 || aplicada.Redis.EventosKey != "eventos-painel-json" {
+		t.Error("Valores que exigem restart deveriam ser mantidos")
+	}
+	if !reflect.DeepEqual(ignorados, []string{"redis.eventos_key", "server.port"}) {
+		t.Errorf("Ignorados: %v", ignorados)
+	}
+}
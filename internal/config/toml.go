@@ -0,0 +1,153 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parseTOML le o subconjunto de TOML usado no arquivo de configuracao:
+// [secao] e [secao.sub], chave = valor com strings ("..." ou '...'), inteiros,
+// floats, booleanos e arrays de uma linha. Retorna as chaves com o caminho
+// completo ("rate_limit.ip.rate")
+func parseTOML(data string) (map[string]interface{}, error) {
+	valores := make(map[string]interface{})
+	secao := ""
+
+	for n, linha := range strings.Split(data, "\n") {
+		linha = strings.TrimSpace(stripComment(linha))
+		if linha == "" {
+			continue
+		}
+
+		if strings.HasPrefix(linha, "[") {
+			if !strings.HasSuffix(linha, "]") || strings.HasPrefix(linha, "[[") {
+				return nil, fmt.Errorf("linha %d: secao invalida: %s", n+1, linha)
+			}
+			secao = strings.TrimSpace(linha[1 : len(linha)-1])
+			if secao == "" {
+				return nil, fmt.Errorf("linha %d: secao vazia", n+1)
+			}
+			continue
+		}
+
+		eq := strings.Index(linha, "=")
+		if eq <= 0 {
+			return nil, fmt.Errorf("linha %d: esperado chave = valor", n+1)
+		}
+		chave := strings.TrimSpace(linha[:eq])
+		if secao != "" {
+			chave = secao + "." + chave
+		}
+		if _, dup := valores[chave]; dup {
+			return nil, fmt.Errorf("linha %d: chave repetida: %s", n+1, chave)
+		}
+
+		valor, err := parseValorTOML(strings.TrimSpace(linha[eq+1:]))
+		if err != nil {
+			return nil, fmt.Errorf("linha %d (%s): %w", n+1, chave, err)
+		}
+		valores[chave] = valor
+	}
+
+	return valores, nil
+}
+
+// stripComment remove o comentario (#) fora de strings
+func stripComment(linha string) string {
+	var aspas byte
+	for i := 0; i < len(linha); i++ {
+		c := linha[i]
+		switch {
+		case aspas != 0:
+			if c == '\\' && aspas == '"' {
+				i++
+			} else if c == aspas {
+				aspas = 0
+			}
+		case c == '"' || c == '\'':
+			aspas = c
+		case c == '#':
+			return linha[:i]
+		}
+	}
+	return linha
+}
+
+// parseValorTOML converte o valor para string, int64, float64, bool ou []interface{}
+func parseValorTOML(s string) (interface{}, error) {
+	switch {
+	case s == "":
+		return nil, fmt.Errorf("valor vazio")
+	case s == "true":
+		return true, nil
+	case s == "false":
+		return false, nil
+	case s[0] == '"':
+		if len(s) < 2 || s[len(s)-1] != '"' {
+			return nil, fmt.Errorf("string sem fechamento: %s", s)
+		}
+		return strconv.Unquote(s)
+	case s[0] == '\'':
+		if len(s) < 2 || s[len(s)-1] != '\'' {
+			return nil, fmt.Errorf("string sem fechamento: %s", s)
+		}
+		return s[1 : len(s)-1], nil
+	case s[0] == '[':
+		if s[len(s)-1] != ']' {
+			return nil, fmt.Errorf("array sem fechamento (apenas arrays de uma linha): %s", s)
+		}
+		return parseArrayTOML(s[1 : len(s)-1])
+	}
+
+	numero := strings.ReplaceAll(s, "_", "")
+	if i, err := strconv.ParseInt(numero, 10, 64); err == nil {
+		return i, nil
+	}
+	if f, err := strconv.ParseFloat(numero, 64); err == nil {
+		return f, nil
+	}
+	return nil, fmt.Errorf("valor invalido: %s", s)
+}
+
+// parseArrayTOML separa os itens do array respeitando strings
+func parseArrayTOML(s string) ([]interface{}, error) {
+	itens := []interface{}{}
+	var aspas byte
+	inicio := 0
+	for i := 0; i <= len(s); i++ {
+		if i < len(s) {
+			c := s[i]
+			if aspas != 0 {
+				if c == '\\' && aspas == '"' {
+					i++
+				} else if c == aspas {
+					aspas = 0
+				}
+				continue
+			}
+			if c == '"' || c == '\'' {
+				aspas = c
+				continue
+			}
+			if c != ',' {
+				continue
+			}
+		}
+
+		item := strings.TrimSpace(s[inicio:i])
+		inicio = i + 1
+		if item == "" {
+			if i == len(s) {
+				break // virgula final
+			}
+			return nil, fmt.Errorf("item vazio no array")
+		}
+		valor, err := parseValorTOML(item)
+		if err != nil {
+			return nil, err
+		}
+		itens = append(itens, valor)
+	}
+	return itens, nil
+}
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// streamCompressor compressor de um stream (gzip.Writer ou zlib.Writer)
+type streamCompressor interface {
+	io.WriteCloser
+	Flush() error
+	Reset(w io.Writer)
+}
+
+// compressedStream ResponseWriter que comprime cada frame SSE
+// Flush faz sync flush no compressor (o frame sai inteiro para o cliente) sem
+// reiniciar a janela: as chaves repetidas do Evento viram referencias aos frames anteriores
+type compressedStream struct {
+	http.ResponseWriter
+	compressor streamCompressor
+	flusher    http.Flusher
+}
+
+func (c *compressedStream) Write(p []byte) (int, error) {
+	return c.compressor.Write(p)
+}
+
+func (c *compressedStream) Flush() {
+	if err := c.compressor.Flush(); err != nil {
+		return
+	}
+	c.flusher.Flush()
+}
+
+// streamCompression pools de compressores por Content-Encoding
+// Cada compressor ativo reserva a janela e as tabelas do deflate (~800 KB, fixo no compress/flate)
+// ate o stream fechar; maxStreams limita quantos ficam ativos ao mesmo tempo
+type streamCompression struct {
+	enabled    bool
+	maxStreams atomic.Int64 // 0 = sem limite (server.compression_max_streams, recarregavel)
+	ativos     atomic.Int64
+	gzip       sync.Pool
+	deflate    sync.Pool
+}
+
+func newStreamCompression(enabled bool, level, maxStreams int) *streamCompression {
+	if level < flate.BestSpeed || level > flate.BestCompression {
+		level = flate.BestSpeed
+	}
+	s := &streamCompression{
+		enabled: enabled,
+		gzip: sync.Pool{New: func() interface{} {
+			w, _ := gzip.NewWriterLevel(nil, level)
+			return w
+		}},
+		deflate: sync.Pool{New: func() interface{} {
+			w, _ := zlib.NewWriterLevel(nil, level)
+			return w
+		}},
+	}
+	s.setMaxStreams(maxStreams)
+	return s
+}
+
+// setMaxStreams limite de streams comprimidos simultaneos (streams ja abertos nao mudam)
+func (s *streamCompression) setMaxStreams(n int) {
+	s.maxStreams.Store(int64(n))
+}
+
+// Ativos streams comprimidos abertos (para o /stats)
+func (s *streamCompression) Ativos() int64 {
+	return s.ativos.Load()
+}
+
+// wrap negocia a compressao pelo Accept-Encoding (gzip preferido, depois deflate)
+// Deve ser chamado apos os headers SSE e antes do primeiro frame
+// O close devolvido finaliza o stream comprimido e devolve o compressor ao pool
+func (s *streamCompression) wrap(w http.ResponseWriter, r *http.Request, flusher http.Flusher) (http.ResponseWriter, http.Flusher, func()) {
+	w.Header().Add("Vary", "Accept-Encoding")
+	if !s.enabled {
+		return w, flusher, func() {}
+	}
+
+	var pool *sync.Pool
+	var encoding string
+	accept := r.Header.Get("Accept-Encoding")
+	switch {
+	case acceptsEncoding(accept, "gzip"):
+		pool, encoding = &s.gzip, "gzip"
+	case acceptsEncoding(accept, "deflate"):
+		pool, encoding = &s.deflate, "deflate"
+	default:
+		return w, flusher, func() {}
+	}
+
+	// Limite de memoria: acima do maximo o stream segue sem compressao
+	if n := s.ativos.Add(1); s.maxStreams.Load() > 0 && n > s.maxStreams.Load() {
+		s.ativos.Add(-1)
+		return w, flusher, func() {}
+	}
+
+	compressor := pool.Get().(streamCompressor)
+	compressor.Reset(w)
+
+	w.Header().Set("Content-Encoding", encoding)
+	w.Header().Del("Content-Length")
+
+	stream := &compressedStream{ResponseWriter: w, compressor: compressor, flusher: flusher}
+	return stream, stream, func() {
+		compressor.Close()
+		compressor.Reset(io.Discard)
+		pool.Put(compressor)
+		s.ativos.Add(-1)
+	}
+}
+
+// acceptsEncoding verifica se o Accept-Encoding aceita a codificacao (q=0 recusa)
+func acceptsEncoding(header, encoding string) bool {
+	wildcard := false
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		name := strings.ToLower(strings.TrimSpace(fields[0]))
+		if name != encoding && name != "*" {
+			continue
+		}
+		q := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if v, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					q = v
+				}
+			}
+		}
+		if name == encoding {
+			return q > 0
+		}
+		wildcard = q > 0
+	}
+	return wildcard
+}
@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"radarfutebol-sse/internal/models"
+)
+
+func TestAcceptsEncoding(t *testing.T) {
+	casos := []struct {
+		header, encoding string
+		want             bool
+	}{
+		{"gzip, deflate, br", "gzip", true},
+		{"deflate", "gzip", false},
+		{"gzip;q=0, deflate", "gzip", false},
+		{"*", "gzip", true},
+		{"*, gzip;q=0", "gzip", false},
+		{"", "gzip", false},
+	}
+	for _, c := range casos {
+		if got := acceptsEncoding(c.header, c.encoding); got != c.want {
+			t.Errorf("acceptsEncoding(%q, %q) = %v, want %v", c.header, c.encoding, got, c.want)
+		}
+	}
+}
+
+// Cada Flush deve entregar o frame completo, decodificavel sem esperar o fim do stream
+func TestStreamCompression_FlushPorFrame(t *testing.T) {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/sse/painel", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	w, flusher, closeCompression := newStreamCompression(true, 1, 0).wrap(rec, req, rec)
+	defer closeCompression()
+
+	if rec.Header().Get("Content-Encoding") != "gzip" {
+		t.Fatalf("Content-Encoding esperado gzip, recebeu %q", rec.Header().Get("Content-Encoding"))
+	}
+
+	fmt.Fprintf(w, "event: update\ndata: {\"a\":1}\n\n")
+	flusher.Flush()
+
+	gz, err := gzip.NewReader(bytes.NewReader(rec.Body.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	line, err := bufio.NewReader(gz).ReadString('\n')
+	if err != nil {
+		t.Fatalf("Frame deveria estar disponivel apos o Flush: %v", err)
+	}
+	if line != "event: update\n" {
+		t.Errorf("Frame inesperado: %q", line)
+	}
+}
+
+func TestStreamCompression_SemAcceptEncoding(t *testing.T) {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/sse/painel", nil)
+
+	w, _, closeCompression := newStreamCompression(true, 1, 0).wrap(rec, req, rec)
+	defer closeCompression()
+
+	if w != http.ResponseWriter(rec) || rec.Header().Get("Content-Encoding") != "" {
+		t.Error("Sem Accept-Encoding o stream nao deveria ser comprimido")
+	}
+}
+
+// BenchmarkBandaPainel bytes por frame de 30 updates do painel (100 eventos):
+// sem compressao, gzip com janela compartilhada entre frames e gzip independente por frame
+func BenchmarkBandaPainel(b *testing.B) {
+	frames := make([][]byte, 30)
+	for f := range frames {
+		eventos := make([]*models.Evento, 100)
+		for i := range eventos {
+			eventos[i] = &models.Evento{
+				IdEvento:   i + 1,
+				TimeCasa:   fmt.Sprintf("Time Casa %d", i),
+				TimeFora:   fmt.Sprintf("Time Fora %d", i),
+				Status:     "inprogress",
+				TempoAtual: fmt.Sprint((i + f) % 90),
+			}
+		}
+		data, _ := json.Marshal(&models.PainelResponse{Eventos: eventos})
+		frames[f] = []byte(fmt.Sprintf("event: update\ndata: %s\n\n", data))
+	}
+
+	b.Run("sem_compressao", func(b *testing.B) {
+		var total int
+		for n := 0; n < b.N; n++ {
+			total = 0
+			for _, frame := range frames {
+				total += len(frame)
+			}
+		}
+		b.ReportMetric(float64(total/len(frames)), "bytes/frame")
+	})
+
+	b.Run("gzip_janela_compartilhada", func(b *testing.B) {
+		var out countingWriter
+		for n := 0; n < b.N; n++ {
+			out = 0
+			gz, _ := gzip.NewWriterLevel(&out, gzip.BestSpeed)
+			for _, frame := range frames {
+				gz.Write(frame)
+				gz.Flush()
+			}
+		}
+		b.ReportMetric(float64(int(out)/len(frames)), "bytes/frame")
+	})
+
+	b.Run("gzip_por_frame", func(b *testing.B) {
+		var out countingWriter
+		for n := 0; n < b.N; n++ {
+			out = 0
+			for _, frame := range frames {
+				gz, _ := gzip.NewWriterLevel(&out, gzip.BestSpeed)
+				gz.Write(frame)
+				gz.Close()
+			}
+		}
+		b.ReportMetric(float64(int(out)/len(frames)), "bytes/frame")
+	})
+}
+
+// countingWriter conta bytes escritos
+type countingWriter int
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	*c += countingWriter(len(p))
+	return len(p), nil
+}
+
+// Acima de compression_max_streams o stream vai sem compressao (memoria limitada)
+func TestStreamCompression_LimiteDeStreams(t *testing.T) {
+	s := newStreamCompression(true, 1, 1)
+	req := httptest.NewRequest(http.MethodGet, "/sse/painel", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	primeiro := httptest.NewRecorder()
+	_, _, fechar := s.wrap(primeiro, req, primeiro)
+	if primeiro.Header().Get("Content-Encoding") != "gzip" {
+		t.Fatal("Primeiro stream deveria ser comprimido")
+	}
+
+	segundo := httptest.NewRecorder()
+	_, _, fecharSegundo := s.wrap(segundo, req, segundo)
+	defer fecharSegundo()
+	if segundo.Header().Get("Content-Encoding") != "" || s.Ativos() != 1 {
+		t.Fatalf("Segundo stream acima do limite deveria ir sem compressao (ativos=%d)", s.Ativos())
+	}
+
+	// Vaga liberada ao fechar o primeiro
+	fechar()
+	terceiro := httptest.NewRecorder()
+	_, _, fecharTerceiro := s.wrap(terceiro, req, terceiro)
+	defer fecharTerceiro()
+	if terceiro.Header().Get("Content-Encoding") != "gzip" {
+		t.Fatal("Stream apos liberar a vaga deveria ser comprimido")
+	}
+}
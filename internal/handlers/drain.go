@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// drainer encerramento gradual dos streams no deploy
+// Em vez de derrubar todos de uma vez no Shutdown (e todos reconectarem no mesmo segundo,
+// cada um validando token no MySQL), cada stream recebe event: reload com um retry:
+// sorteado, em momentos espalhados ao longo da janela de drain
+type drainer struct {
+	draining atomic.Bool
+
+	mu      sync.Mutex
+	streams map[*drainStream]struct{}
+
+	retryMin atomic.Int64 // time.Duration (recarregavel)
+	retryMax atomic.Int64
+}
+
+// drainStream inscricao de um stream aberto
+type drainStream struct {
+	// C recebe o retry sorteado quando chega a vez do stream ser encerrado
+	C chan time.Duration
+}
+
+func newDrainer(retryMin, retryMax time.Duration) *drainer {
+	d := &drainer{streams: make(map[*drainStream]struct{})}
+	d.setRetry(retryMin, retryMax)
+	return d
+}
+
+// setRetry altera o intervalo do retry sorteado
+func (d *drainer) setRetry(retryMin, retryMax time.Duration) {
+	if retryMax < retryMin {
+		retryMax = retryMin
+	}
+	d.retryMin.Store(int64(retryMin))
+	d.retryMax.Store(int64(retryMax))
+}
+
+// register inscreve o stream; chamar a funcao devolvida ao encerrar
+// Stream que entra depois do inicio do drain e encerrado imediatamente
+func (d *drainer) register() (*drainStream, func()) {
+	stream := &drainStream{C: make(chan time.Duration, 1)}
+
+	d.mu.Lock()
+	if d.draining.Load() {
+		stream.C <- d.retry()
+	} else {
+		d.streams[stream] = struct{}{}
+	}
+	d.mu.Unlock()
+
+	return stream, func() {
+		d.mu.Lock()
+		delete(d.streams, stream)
+		d.mu.Unlock()
+	}
+}
+
+// retry sorteia o intervalo de reconexao entre retryMin e retryMax
+func (d *drainer) retry() time.Duration {
+	retryMin, retryMax := d.retryMin.Load(), d.retryMax.Load()
+	if retryMax <= retryMin {
+		return time.Duration(retryMin)
+	}
+	return time.Duration(retryMin + rand.Int63n(retryMax-retryMin))
+}
+
+// rejectNew responde 503 com Retry-After sorteado se o servidor estiver em drain
+func (d *drainer) rejectNew(w http.ResponseWriter) bool {
+	if !d.draining.Load() {
+		return false
+	}
+	w.Header().Set("Retry-After", strconv.Itoa(int(d.retry().Seconds())+1))
+	http.Error(w, "Servidor em manutencao, reconecte em instantes", http.StatusServiceUnavailable)
+	return true
+}
+
+// drain para de aceitar streams e sinaliza os abertos em ordem aleatoria ao longo da janela
+// Se ctx expirar antes do fim, sinaliza todos os restantes de uma vez
+// Retorna quantos streams foram sinalizados
+func (d *drainer) drain(ctx context.Context, window time.Duration) int {
+	d.mu.Lock()
+	d.draining.Store(true)
+	streams := make([]*drainStream, 0, len(d.streams))
+	for stream := range d.streams {
+		streams = append(streams, stream)
+	}
+	d.mu.Unlock()
+
+	rand.Shuffle(len(streams), func(i, j int) { streams[i], streams[j] = streams[j], streams[i] })
+
+	start := time.Now()
+	expirado := false
+	for i, stream := range streams {
+		if !expirado {
+			// Stream i encerra em start + window*i/n
+			if wait := time.Until(start.Add(window * time.Duration(i) / time.Duration(len(streams)))); wait > 0 {
+				select {
+				case <-time.After(wait):
+				case <-ctx.Done():
+					expirado = true
+				}
+			}
+		}
+
+		select {
+		case stream.C <- d.retry():
+		default:
+		}
+	}
+
+	return len(streams)
+}
+
+// sendDrainReload avisa o cliente para reconectar em outro momento (stream sera encerrado)
+func sendDrainReload(w http.ResponseWriter, flusher http.Flusher, retry time.Duration) {
+	fmt.Fprintf(w, "retry: %d\nevent: reload\ndata: {\"reason\": \"server_drain\", \"retry\": %d}\n\n", retry.Milliseconds(), retry.Milliseconds())
+	flusher.Flush()
+}
+
+// Drain encerra os streams gradualmente ao longo da janela (deploy/restart)
+// Chamar antes do server.Shutdown: sem isso os handlers SSE so terminam quando o cliente desconecta
+func (h *SSEHandler) Drain(ctx context.Context, window time.Duration) {
+	conns := atomic.LoadInt64(&h.connections)
+	log.Printf("Drain iniciado: %d conexoes serao encerradas ao longo de %v", conns, window)
+
+	n := h.drain.drain(ctx, window)
+
+	log.Printf("Drain concluido: %d streams sinalizados", n)
+}
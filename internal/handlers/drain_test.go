@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDrainer_EspalhaEncerramentoNaJanela(t *testing.T) {
+	d := newDrainer(time.Second, 2*time.Second)
+
+	var streams []*drainStream
+	for i := 0; i < 4; i++ {
+		stream, unregister := d.register()
+		defer unregister()
+		streams = append(streams, stream)
+	}
+
+	start := time.Now()
+	if n := d.drain(context.Background(), 40*time.Millisecond); n != 4 {
+		t.Fatalf("Esperava 4 streams sinalizados, obteve %d", n)
+	}
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Errorf("Drain deveria se espalhar pela janela, levou %v", elapsed)
+	}
+
+	for _, stream := range streams {
+		select {
+		case retry := <-stream.C:
+			if retry < time.Second || retry >= 2*time.Second {
+				t.Errorf("Retry fora do intervalo: %v", retry)
+			}
+		default:
+			t.Error("Stream nao sinalizado")
+		}
+	}
+
+	// Stream aberto depois do inicio do drain e encerrado na hora
+	late, unregister := d.register()
+	defer unregister()
+	select {
+	case <-late.C:
+	default:
+		t.Error("Stream registrado durante o drain deveria ser sinalizado")
+	}
+
+	rec := httptest.NewRecorder()
+	if !d.rejectNew(rec) || rec.Code != http.StatusServiceUnavailable || rec.Header().Get("Retry-After") == "" {
+		t.Errorf("Novo stream durante o drain deveria receber 503 com Retry-After (code=%d)", rec.Code)
+	}
+}
@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"radarfutebol-sse/internal/services"
+)
+
+// healthPingTimeout tempo maximo de cada ping nas verificacoes de saude
+const healthPingTimeout = time.Second
+
+// depsCacheTTL tempo que o resultado do /health/deps e reaproveitado
+// Limita os pings em Redis/MySQL mesmo com muitas requisicoes de monitoramento
+const depsCacheTTL = 2 * time.Second
+
+// depsCache ultimo resultado do /health/deps
+type depsCache struct {
+	mu   sync.Mutex
+	at   time.Time
+	code int
+	body map[string]interface{}
+}
+
+// handleLivez GET /livez - processo vivo (sem verificar dependencias)
+func (h *SSEHandler) handleLivez(w http.ResponseWriter, r *http.Request) {
+	writeHealth(w, http.StatusOK, map[string]interface{}{
+		"status":    "alive",
+		"timestamp": time.Now().Unix(),
+	})
+}
+
+// handleReadyz GET /readyz - pronto para receber streams: snapshot atualizado dentro de
+// server.ready_max_snapshot_age, abaixo do limite de conexoes e fora do drain
+// O Redis so e verificado se estiver em event_source.order, e apenas informativo:
+// com failover para outra fonte o snapshot continua atualizado e o servidor segue pronto
+// Responde 503 com o motivo de cada verificacao que falhou
+func (h *SSEHandler) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	checks := make(map[string]string)
+	ready := true
+	fail := func(check, motivo string) {
+		checks[check] = motivo
+		ready = false
+	}
+
+	if h.drain.draining.Load() {
+		fail("drain", "servidor em drain")
+	} else {
+		checks["drain"] = "ok"
+	}
+
+	if h.usaRedis {
+		ctx, cancel := context.WithTimeout(r.Context(), healthPingTimeout)
+		if _, err := services.PingRedis(ctx); err != nil {
+			checks["redis"] = err.Error()
+		} else {
+			checks["redis"] = "ok"
+		}
+		cancel()
+	}
+
+	maxAge := time.Duration(atomic.LoadInt64(&h.readyMaxAge))
+	switch snapshot := h.broadcaster.SnapshotStatus(maxAge); snapshot.Status {
+	case "ok":
+		checks["snapshot"] = "ok"
+	case "empty":
+		fail("snapshot", "eventos ainda nao carregados")
+	default:
+		fail("snapshot", "ultima atualizacao ha "+(time.Duration(snapshot.AgeMs)*time.Millisecond).String())
+	}
+
+	if maxConns := atomic.LoadInt64(&h.maxConns); maxConns > 0 && atomic.LoadInt64(&h.connections) >= maxConns {
+		fail("connections", "limite de conexoes atingido")
+	} else {
+		checks["connections"] = "ok"
+	}
+
+	status, code := "ready", http.StatusOK
+	if !ready {
+		status, code = "not_ready", http.StatusServiceUnavailable
+	}
+	writeHealth(w, code, map[string]interface{}{
+		"status":    status,
+		"checks":    checks,
+		"timestamp": time.Now().Unix(),
+	})
+}
+
+// handleHealthDeps GET /health/deps - estado detalhado de Redis, Redis de preferencias,
+// MySQL e snapshot, com latencia e ultimo erro de cada um
+// 503 se o snapshot (ou o Redis, quando esta em event_source.order) estiver com problema
+// O resultado e reaproveitado por depsCacheTTL
+func (h *SSEHandler) handleHealthDeps(w http.ResponseWriter, r *http.Request) {
+	h.deps.mu.Lock()
+	defer h.deps.mu.Unlock()
+
+	if time.Since(h.deps.at) >= depsCacheTTL {
+		ctx, cancel := context.WithTimeout(r.Context(), healthPingTimeout)
+		deps := services.CheckDeps(ctx)
+		cancel()
+		snapshot := h.broadcaster.SnapshotStatus(time.Duration(atomic.LoadInt64(&h.readyMaxAge)))
+
+		status, code := "ok", http.StatusOK
+		if (h.usaRedis && deps["redis"].Status != "ok") || snapshot.Status != "ok" {
+			status, code = "degraded", http.StatusServiceUnavailable
+		}
+		h.deps.at, h.deps.code = time.Now(), code
+		h.deps.body = map[string]interface{}{
+			"status":      status,
+			"deps":        deps,
+			"snapshot":    snapshot,
+			"connections": atomic.LoadInt64(&h.connections),
+			"maxConns":    atomic.LoadInt64(&h.maxConns),
+			"timestamp":   time.Now().Unix(),
+		}
+	}
+	writeHealth(w, h.deps.code, h.deps.body)
+}
+
+// writeHealth responde JSON sem cache
+func writeHealth(w http.ResponseWriter, code int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(body)
+}
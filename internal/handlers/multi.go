@@ -0,0 +1,433 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"radarfutebol-sse/internal/codec"
+	"radarfutebol-sse/internal/models"
+	"radarfutebol-sse/internal/services"
+)
+
+// maxOraculosMulti limite de jogos do oraculo assinados em um unico stream multiplexado
+const maxOraculosMulti = 10
+
+// multiSession assinaturas de um stream /sse/multi (alteradas pelo endpoint de controle)
+type multiSession struct {
+	id    string
+	token string
+
+	// podeAssinar verifica o escopo da conexao para um jogo (status 0 = liberado)
+	podeAssinar func(idWilliamhill string) (int, string)
+
+	mu       sync.Mutex
+	oraculos []string
+
+	// changed sinaliza o stream para enviar os novos topicos imediatamente
+	changed chan struct{}
+}
+
+// list retorna copia dos jogos assinados
+func (s *multiSession) list() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.oraculos...)
+}
+
+// update adiciona/remove jogos (respeitando maxOraculosMulti) e avisa o stream
+func (s *multiSession) update(add, remove []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	atual := make(map[string]bool, len(s.oraculos))
+	for _, id := range s.oraculos {
+		atual[id] = true
+	}
+	for _, id := range remove {
+		delete(atual, id)
+	}
+	for _, id := range add {
+		atual[id] = true
+	}
+	if len(atual) > maxOraculosMulti {
+		return fmt.Errorf("limite de %d jogos do oraculo por stream", maxOraculosMulti)
+	}
+
+	// Mantem a ordem de assinatura
+	oraculos := make([]string, 0, len(atual))
+	for _, id := range append(s.oraculos, add...) {
+		if atual[id] {
+			oraculos = append(oraculos, id)
+			delete(atual, id)
+		}
+	}
+	s.oraculos = oraculos
+
+	select {
+	case s.changed <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// multiRegistry streams multiplexados abertos, indexados pelo id da sessao
+type multiRegistry struct {
+	mu       sync.Mutex
+	sessions map[string]*multiSession
+}
+
+func newMultiRegistry() *multiRegistry {
+	return &multiRegistry{sessions: make(map[string]*multiSession)}
+}
+
+// register cria a sessao do stream e retorna a funcao que a remove (chamar com defer)
+func (r *multiRegistry) register(token string, oraculos []string, podeAssinar func(string) (int, string)) (*multiSession, func()) {
+	buf := make([]byte, 16)
+	rand.Read(buf)
+
+	session := &multiSession{
+		id:          hex.EncodeToString(buf),
+		token:       token,
+		podeAssinar: podeAssinar,
+		oraculos:    oraculos,
+		changed:     make(chan struct{}, 1),
+	}
+
+	r.mu.Lock()
+	r.sessions[session.id] = session
+	r.mu.Unlock()
+
+	return session, func() {
+		r.mu.Lock()
+		delete(r.sessions, session.id)
+		r.mu.Unlock()
+	}
+}
+
+func (r *multiRegistry) get(id string) *multiSession {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.sessions[id]
+}
+
+// parseIdsList le lista de ids separados por virgula (sem vazios nem repetidos)
+func parseIdsList(param string) []string {
+	var ids []string
+	vistos := make(map[string]bool)
+	for _, id := range strings.Split(param, ",") {
+		id = strings.TrimSpace(id)
+		if id != "" && !vistos[id] {
+			ids = append(ids, id)
+			vistos[id] = true
+		}
+	}
+	return ids
+}
+
+// oraculoAccess verifica se a conexao pode assinar o oraculo do jogo (status 0 = liberado)
+func oraculoAccess(broadcaster *services.Broadcaster, partner *services.APIKey, filtro *models.Filtro, idWilliamhill string) (int, string) {
+	if partner != nil && !partner.PermiteEndpoint("oraculo") {
+		return http.StatusForbidden, "API key sem acesso a este endpoint"
+	}
+	if filtro.CampeonatosPermitidos != nil {
+		evento := broadcaster.FindEventoByIdWilliamhill(idWilliamhill)
+		if evento == nil || !filtro.CampeonatosPermitidos[evento.IdCampeonatoUnico] {
+			return http.StatusForbidden, "API key sem acesso a este jogo"
+		}
+	}
+	return 0, ""
+}
+
+// handleMulti endpoint SSE multiplexado: painel ou home + N jogos do oraculo em uma unica conexao
+// GET /sse/multi?view=painel&oraculo=123,456 (demais parametros iguais ao /sse/painel)
+// Frames: event: painel|home (mesmo payload do update), event: oraculo (com idWilliamhill),
+// event: oraculo_finished, event: session (id para o endpoint de controle) e event: status
+func (h *SSEHandler) handleMulti(w http.ResponseWriter, r *http.Request) {
+	// Verifica limite de conexoes
+	currentConns := atomic.LoadInt64(&h.connections)
+	if maxConns := atomic.LoadInt64(&h.maxConns); maxConns > 0 && currentConns >= maxConns {
+		http.Error(w, "Servidor sobrecarregado, tente novamente", http.StatusServiceUnavailable)
+		return
+	}
+
+	// Em drain (deploy) nao aceita novos streams
+	if h.drain.rejectNew(w) {
+		return
+	}
+
+	filtro := models.ParseFiltroFromRequest(r)
+	if !parseProjecao(w, r, filtro) {
+		return
+	}
+
+	view := r.URL.Query().Get("view")
+	if view != "" && view != "painel" && view != "home" {
+		http.Error(w, "view deve ser painel ou home", http.StatusBadRequest)
+		return
+	}
+	oraculos := parseIdsList(r.URL.Query().Get("oraculo"))
+	if len(oraculos) > maxOraculosMulti {
+		http.Error(w, fmt.Sprintf("limite de %d jogos do oraculo por stream", maxOraculosMulti), http.StatusBadRequest)
+		return
+	}
+
+	// Valida token (ou escopo do parceiro) e aplica limites de conexao
+	releaseStream, ok := h.admitConnection(w, r, filtro)
+	if !ok {
+		return
+	}
+	defer releaseStream()
+
+	// Parceiro: cada topico precisa estar liberado na API key
+	partner := partnerFromContext(r.Context())
+	if partner != nil && view != "" && !partner.PermiteEndpoint(view) {
+		http.Error(w, "API key sem acesso a este endpoint", http.StatusForbidden)
+		return
+	}
+	podeAssinar := func(idWilliamhill string) (int, string) {
+		return oraculoAccess(h.broadcaster, partner, filtro, idWilliamhill)
+	}
+	for _, id := range oraculos {
+		if status, msg := podeAssinar(id); status != 0 {
+			http.Error(w, msg, status)
+			return
+		}
+	}
+
+	// Headers SSE
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("X-Accel-Buffering", "no")
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "SSE not supported", http.StatusInternalServerError)
+		return
+	}
+
+	// Compressao por frame (mantem a janela do deflate entre os updates)
+	w, flusher, closeCompression := h.compression.wrap(w, r, flusher)
+	defer closeCompression()
+
+	connCount := atomic.AddInt64(&h.connections, 1)
+	if logConexao(connCount) {
+		log.Printf("SSE multi: Nova conexao (user=%d, view=%s, oraculos=%d) - Total: %d", filtro.IdUsuario, view, len(oraculos), connCount)
+	}
+	defer func() {
+		newCount := atomic.AddInt64(&h.connections, -1)
+		if logConexao(newCount) {
+			log.Printf("SSE multi: Conexao fechada (user=%d) - Total: %d", filtro.IdUsuario, newCount)
+		}
+	}()
+
+	fmt.Fprintf(w, "retry: 10000\n\n")
+
+	// Ticker diferenciado: 2s para assinantes, 10s para free/anonimo
+	ticker := time.NewTicker(tickerDuration(filtro.IsAssinante))
+	defer ticker.Stop()
+
+	// Registra o stream para receber revogacao/mudanca de plano do usuario
+	session, unregister := h.sessions.register(filtro.IdUsuario, filtro.Token)
+	defer unregister()
+
+	multi, unregisterMulti := h.multi.register(filtro.Token, oraculos, podeAssinar)
+	defer unregisterMulti()
+
+	h.sendMultiFrame(w, "session", map[string]interface{}{
+		"sessao":   multi.id,
+		"oraculos": multi.list(),
+	}, filtro.Encoding)
+
+	ctx := r.Context()
+	broadcaster := h.broadcaster
+	currentReloadChan := getReloadChan()
+
+	drainStream, unregisterDrain := h.drain.register()
+	defer unregisterDrain()
+
+	// Inscricoes nos hubs dos jogos assinados (atualizadas a cada envio)
+	watchers := make(map[string]func())
+	defer func() {
+		for _, unwatch := range watchers {
+			unwatch()
+		}
+	}()
+
+	h.sendMultiUpdate(w, flusher, view, multi, watchers, filtro, broadcaster)
+
+	// Frescor dos dados: avisa na conexao se ja estiver atrasado e a cada mudanca
+	dados, dadosChan := broadcaster.DadosStatus()
+	if dados.Status != "ok" {
+		sendDadosStatus(w, flusher, dados)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-currentReloadChan:
+			fmt.Fprintf(w, "event: reload\ndata: {\"reason\": \"server_update\"}\n\n")
+			flusher.Flush()
+			return
+		case retry := <-drainStream.C:
+			sendDrainReload(w, flusher, retry)
+			return
+		case auth := <-session.authChan:
+			if !auth.IsValid {
+				sendSessionRevoked(w, flusher)
+				return
+			}
+			if auth.IsAssinante != filtro.IsAssinante {
+				filtro.IsAssinante = auth.IsAssinante
+				ticker.Reset(tickerDuration(filtro.IsAssinante))
+				h.sendMultiUpdate(w, flusher, view, multi, watchers, filtro, broadcaster)
+			}
+		case <-multi.changed:
+			// Assinaturas alteradas pelo endpoint de controle: envia os topicos na hora
+			h.sendMultiUpdate(w, flusher, view, multi, watchers, filtro, broadcaster)
+		case <-dadosChan:
+			dados, dadosChan = broadcaster.DadosStatus()
+			sendDadosStatus(w, flusher, dados)
+		case <-ticker.C:
+			ticker.Reset(tickerDuration(filtro.IsAssinante)) // cadencia pode ter mudado no SIGHUP
+			h.sendMultiUpdate(w, flusher, view, multi, watchers, filtro, broadcaster)
+		}
+	}
+}
+
+// sendMultiUpdate envia um frame por topico assinado e faz um unico flush
+func (h *SSEHandler) sendMultiUpdate(w http.ResponseWriter, flusher http.Flusher, view string, multi *multiSession, watchers map[string]func(), filtro *models.Filtro, broadcaster *services.Broadcaster) {
+	defer flusher.Flush()
+
+	oraculos := multi.list()
+	syncOraculoWatchers(broadcaster, oraculos, watchers)
+
+	if view != "" {
+		var data []byte
+		var err error
+		switch view {
+		case "painel":
+			data, err = broadcaster.GetEventosPainelFiltradoCached(filtro)
+		case "home":
+			data, err = broadcaster.GetEventosHomeFiltradoCached(filtro)
+		}
+		if err != nil {
+			log.Printf("SSE multi: Erro ao buscar dados (%s): %v", view, err)
+			h.sendMultiFrame(w, "error", map[string]interface{}{"topico": view, "error": err.Error()}, filtro.Encoding)
+		} else {
+			writeFrame(w, view, data, filtro.Encoding)
+		}
+	}
+
+	var finalizados []string
+	for _, id := range oraculos {
+		response, finished, err := montarOraculoResponse(id, broadcaster, filtro)
+		if err != nil {
+			log.Printf("SSE multi: Erro ao buscar oraculo (jogo=%s): %v", id, err)
+			h.sendMultiFrame(w, "error", map[string]interface{}{"topico": "oraculo", "idWilliamhill": id, "error": err.Error()}, filtro.Encoding)
+			continue
+		}
+		if response == nil {
+			h.sendMultiFrame(w, "error", map[string]interface{}{"topico": "oraculo", "idWilliamhill": id, "error": "Jogo nao encontrado no cache"}, filtro.Encoding)
+			continue
+		}
+
+		response["idWilliamhill"] = id
+		h.sendMultiFrame(w, "oraculo", response, filtro.Encoding)
+
+		if finished {
+			h.sendMultiFrame(w, "oraculo_finished", map[string]interface{}{"idWilliamhill": id}, filtro.Encoding)
+			finalizados = append(finalizados, id)
+		}
+	}
+
+	// Jogo finalizado sai da assinatura (o stream continua com os demais topicos)
+	if len(finalizados) > 0 {
+		multi.update(nil, finalizados)
+		select {
+		case <-multi.changed:
+		default:
+		}
+	}
+}
+
+// syncOraculoWatchers inscreve o stream nos hubs dos jogos assinados e sai dos removidos
+func syncOraculoWatchers(broadcaster *services.Broadcaster, oraculos []string, watchers map[string]func()) {
+	assinados := make(map[string]bool, len(oraculos))
+	for _, id := range oraculos {
+		assinados[id] = true
+		if _, exists := watchers[id]; !exists {
+			_, unwatch := broadcaster.WatchOraculo(id)
+			watchers[id] = unwatch
+		}
+	}
+	for id, unwatch := range watchers {
+		if !assinados[id] {
+			unwatch()
+			delete(watchers, id)
+		}
+	}
+}
+
+// sendMultiFrame serializa o payload no formato da conexao e escreve o frame (sem flush)
+func (h *SSEHandler) sendMultiFrame(w http.ResponseWriter, event string, payload interface{}, encoding string) {
+	data, err := codec.Marshal(encoding, payload)
+	if err != nil {
+		log.Printf("SSE multi: Erro ao serializar %s: %v", event, err)
+		return
+	}
+	writeFrame(w, event, data, encoding)
+}
+
+// handleMultiSubscriptions endpoint de controle das assinaturas de um stream /sse/multi
+// POST /sse/multi/subscriptions?sessao={id}&add=123,456&remove=789 (mesmo token do stream)
+func (h *SSEHandler) handleMultiSubscriptions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	multi := h.multi.get(query.Get("sessao"))
+	if multi == nil {
+		writeJSONError(w, http.StatusNotFound, "Sessao nao encontrada")
+		return
+	}
+
+	// Apenas o dono do stream altera as assinaturas
+	token := models.ParseFiltroFromRequest(r).Token
+	if subtle.ConstantTimeCompare([]byte(token), []byte(multi.token)) != 1 {
+		writeJSONError(w, http.StatusForbidden, "Token nao corresponde ao stream")
+		return
+	}
+
+	add := parseIdsList(query.Get("add"))
+	for _, id := range add {
+		if status, msg := multi.podeAssinar(id); status != 0 {
+			writeJSONError(w, status, msg)
+			return
+		}
+	}
+
+	if err := multi.update(add, parseIdsList(query.Get("remove"))); err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"sessao":   multi.id,
+		"oraculos": multi.list(),
+	})
+}
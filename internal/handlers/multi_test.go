@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func liberado(string) (int, string) { return 0, "" }
+
+func TestMultiSession_Update(t *testing.T) {
+	r := newMultiRegistry()
+	s, unregister := r.register("tok", parseIdsList("1, 2,2,,3"), liberado)
+	defer unregister()
+
+	if got := s.list(); !reflect.DeepEqual(got, []string{"1", "2", "3"}) {
+		t.Fatalf("Assinaturas iniciais inesperadas: %v", got)
+	}
+
+	if err := s.update([]string{"4", "1"}, []string{"2"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := s.list(); !reflect.DeepEqual(got, []string{"1", "3", "4"}) {
+		t.Errorf("Ordem de assinatura nao mantida: %v", got)
+	}
+	select {
+	case <-s.changed:
+	default:
+		t.Error("Stream deveria ser avisado da mudanca")
+	}
+
+	muitos := make([]string, maxOraculosMulti)
+	for i := range muitos {
+		muitos[i] = string(rune('a' + i))
+	}
+	if err := s.update(muitos, nil); err == nil {
+		t.Error("Deveria recusar acima do limite de jogos")
+	}
+	if got := s.list(); len(got) != 3 {
+		t.Errorf("Assinaturas nao deveriam mudar apos erro: %v", got)
+	}
+}
+
+func TestMultiSubscriptions_TokenDoStream(t *testing.T) {
+	h := &SSEHandler{multi: newMultiRegistry()}
+	s, unregister := h.multi.register("tok-dono", nil, liberado)
+	defer unregister()
+
+	req := httptest.NewRequest(http.MethodPost, "/sse/multi/subscriptions?sessao="+s.id+"&add=9&token=outro", nil)
+	rec := httptest.NewRecorder()
+	h.handleMultiSubscriptions(rec, req)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("Token diferente deveria dar 403, recebeu %d", rec.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodPost, "/sse/multi/subscriptions?sessao="+s.id+"&add=9&token=tok-dono", nil)
+	rec = httptest.NewRecorder()
+	h.handleMultiSubscriptions(rec, req)
+	if rec.Code != http.StatusOK || !reflect.DeepEqual(s.list(), []string{"9"}) {
+		t.Errorf("Dono deveria assinar o jogo 9 (status %d, assinaturas %v)", rec.Code, s.list())
+	}
+}
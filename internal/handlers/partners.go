@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"radarfutebol-sse/internal/config"
+	"radarfutebol-sse/internal/services"
+)
+
+// partnerCtxKey chave do contexto com a API key do parceiro autenticado
+type partnerCtxKey struct{}
+
+// partnerFromContext retorna a API key do parceiro (nil = requisicao comum)
+func partnerFromContext(ctx context.Context) *services.APIKey {
+	key, _ := ctx.Value(partnerCtxKey{}).(*services.APIKey)
+	return key
+}
+
+// partnerUsage contadores de uso de uma API key (apenas deste processo)
+type partnerUsage struct {
+	Nome           string `json:"nome"`
+	ConexoesAtivas int64  `json:"conexoesAtivas"`
+	ConexoesTotal  int64  `json:"conexoesTotal"`
+	Recusadas      int64  `json:"recusadas"`
+	UltimoUso      int64  `json:"ultimoUso"` // unix timestamp
+}
+
+// partnerGate aplica rate limit e limite de conexoes por API key
+type partnerGate struct {
+	mu       sync.Mutex
+	limiters map[string]*bucketLimiter
+	limits   map[string]config.TierLimit
+	usage    map[string]*partnerUsage
+}
+
+func newPartnerGate() *partnerGate {
+	return &partnerGate{
+		limiters: make(map[string]*bucketLimiter),
+		limits:   make(map[string]config.TierLimit),
+		usage:    make(map[string]*partnerUsage),
+	}
+}
+
+// usageOf retorna (criando se preciso) os contadores da chave
+func (g *partnerGate) usageOf(key *services.APIKey) *partnerUsage {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	u, exists := g.usage[key.Id]
+	if !exists {
+		u = &partnerUsage{}
+		g.usage[key.Id] = u
+	}
+	u.Nome = key.Nome
+	return u
+}
+
+// limiterOf retorna o token bucket da chave, recriando se os limites mudaram
+func (g *partnerGate) limiterOf(key *services.APIKey) *bucketLimiter {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	limit := config.TierLimit{Rate: key.Rate, Burst: key.Burst}
+	if l, exists := g.limiters[key.Id]; exists && g.limits[key.Id] == limit {
+		return l
+	}
+	l := newBucketLimiter(limit)
+	g.limiters[key.Id] = l
+	g.limits[key.Id] = limit
+	return l
+}
+
+// withAPIKey middleware que autentica parceiros B2B pela API key (header X-API-Key ou ?apiKey=)
+// Requisicoes sem API key seguem direto para o handler
+// endpoint vazio = o handler verifica o acesso por topico (ex: /sse/multi)
+func (h *SSEHandler) withAPIKey(endpoint string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rawKey := r.Header.Get("X-API-Key")
+		if rawKey == "" {
+			rawKey = strings.TrimSpace(r.URL.Query().Get("apiKey"))
+		}
+		if rawKey == "" {
+			next(w, r)
+			return
+		}
+
+		// Limite por IP antes da consulta (chaves aleatorias nao chegam ao Redis)
+		// Snapshots REST usam o limite de requisicoes, streams o de novas conexoes
+		allow := h.limiter.AllowIP
+		if strings.HasPrefix(r.URL.Path, "/api/") {
+			allow = h.limiter.AllowRequest
+		}
+		if !allow(w, h.limiter.ClientIP(r)) {
+			return
+		}
+
+		key, err := h.apiKeys.APIKey(rawKey)
+		if err != nil {
+			log.Printf("APIKey: Erro ao validar chave: %v", err)
+			http.Error(w, "Erro ao validar API key", http.StatusServiceUnavailable)
+			return
+		}
+		if key == nil {
+			http.Error(w, "API key invalida", http.StatusUnauthorized)
+			return
+		}
+
+		usage := h.partners.usageOf(key)
+		if !key.Ativo || (endpoint != "" && !key.PermiteEndpoint(endpoint)) {
+			atomic.AddInt64(&usage.Recusadas, 1)
+			http.Error(w, "API key sem acesso a este endpoint", http.StatusForbidden)
+			return
+		}
+
+		// Limite de novas conexoes por chave
+		if ok, wait := h.partners.limiterOf(key).allow(key.Id, time.Now()); !ok {
+			atomic.AddInt64(&usage.Recusadas, 1)
+			tooManyRequests(w, wait, "Limite de conexoes da API key atingido, tente novamente")
+			return
+		}
+
+		// Limite de conexoes simultaneas por chave
+		ativas := atomic.AddInt64(&usage.ConexoesAtivas, 1)
+		defer atomic.AddInt64(&usage.ConexoesAtivas, -1)
+		if key.MaxConexoes > 0 && ativas > int64(key.MaxConexoes) {
+			atomic.AddInt64(&usage.Recusadas, 1)
+			tooManyRequests(w, 10*time.Second, "Limite de conexoes simultaneas da API key atingido")
+			return
+		}
+
+		atomic.AddInt64(&usage.ConexoesTotal, 1)
+		atomic.StoreInt64(&usage.UltimoUso, time.Now().Unix())
+
+		next(w, r.WithContext(context.WithValue(r.Context(), partnerCtxKey{}, key)))
+	}
+}
+
+// handleAdminAPIKeys retorna contadores de uso por API key (apenas acesso local)
+func (h *SSEHandler) handleAdminAPIKeys(w http.ResponseWriter, r *http.Request) {
+	if !h.isLocalRequest(r) {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+
+	h.partners.mu.Lock()
+	usage := make(map[string]partnerUsage, len(h.partners.usage))
+	for id, u := range h.partners.usage {
+		usage[id[:12]] = partnerUsage{
+			Nome:           u.Nome,
+			ConexoesAtivas: atomic.LoadInt64(&u.ConexoesAtivas),
+			ConexoesTotal:  atomic.LoadInt64(&u.ConexoesTotal),
+			Recusadas:      atomic.LoadInt64(&u.Recusadas),
+			UltimoUso:      atomic.LoadInt64(&u.UltimoUso),
+		}
+	}
+	h.partners.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"apiKeys":   usage,
+		"timestamp": time.Now().Unix(),
+	})
+}
+
+// isLocalRequest verifica se a requisicao veio da propria maquina (nao via nginx publico)
+func (h *SSEHandler) isLocalRequest(r *http.Request) bool {
+	ip := net.ParseIP(h.limiter.ClientIP(r))
+	return ip != nil && ip.IsLoopback()
+}
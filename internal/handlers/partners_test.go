@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"radarfutebol-sse/internal/services"
+)
+
+func TestWithAPIKey_ChaveEEndpoint(t *testing.T) {
+	a := novoAmbienteTeste(t)
+	a.apiKeys.AddAPIKey("key-painel", services.APIKey{Nome: "painel", Ativo: true, Endpoints: []string{"painel"}})
+	a.apiKeys.AddAPIKey("key-inativa", services.APIKey{Nome: "inativa", Ativo: false, Endpoints: []string{"painel"}})
+
+	casos := []struct {
+		path, chave string
+		status      int
+	}{
+		{"/api/painel", "key-inexistente", http.StatusUnauthorized},
+		{"/api/painel", "key-inativa", http.StatusForbidden},
+		{"/api/home", "key-painel", http.StatusForbidden},
+		{"/api/oraculo/111", "key-painel", http.StatusForbidden},
+		{"/api/painel", "key-painel", http.StatusOK},
+		{"/api/painel?apiKey=key-painel", "", http.StatusOK},
+	}
+	for _, c := range casos {
+		headers := map[string]string{}
+		if c.chave != "" {
+			headers["X-API-Key"] = c.chave
+		}
+		if resp, body := a.get(t, c.path, headers); resp.StatusCode != c.status {
+			t.Errorf("%s com %q: status=%d, esperava %d (%s)", c.path, c.chave, resp.StatusCode, c.status, body)
+		}
+	}
+}
+
+func TestWithAPIKey_CampeonatosNoStream(t *testing.T) {
+	a := novoAmbienteTeste(t)
+	a.apiKeys.AddAPIKey("key-camp10", services.APIKey{
+		Nome: "parceiro", Ativo: true, Endpoints: []string{"painel"}, Campeonatos: []string{"10"},
+	})
+
+	// Token do usuario e ignorado: vale o escopo da chave
+	_, frames := a.abrirStream(t, "/sse/painel?apiKey=key-camp10", "tok-assinante")
+	painel := decodePainel(t, proximoFrame(t, frames, "update"))
+	if len(painel.Eventos) != 1 || painel.Eventos[0].IdEvento != 1 {
+		t.Fatalf("Esperava apenas o evento do campeonato 10, obteve %d eventos", len(painel.Eventos))
+	}
+}
+
+func TestWithAPIKey_Limites(t *testing.T) {
+	a := novoAmbienteTeste(t)
+	a.apiKeys.AddAPIKey("key-rate", services.APIKey{Nome: "rate", Ativo: true, Endpoints: []string{"painel"}, Rate: 1, Burst: 1})
+	a.apiKeys.AddAPIKey("key-max", services.APIKey{Nome: "max", Ativo: true, Endpoints: []string{"painel"}, MaxConexoes: 1})
+
+	// Rate da chave: burst de 1, a segunda requisicao no mesmo minuto e recusada
+	a.get(t, "/api/painel", map[string]string{"X-API-Key": "key-rate"})
+	resp, _ := a.get(t, "/api/painel", map[string]string{"X-API-Key": "key-rate"})
+	if resp.StatusCode != http.StatusTooManyRequests || resp.Header.Get("Retry-After") == "" {
+		t.Fatalf("Rate da chave: status=%d Retry-After=%q, esperava 429", resp.StatusCode, resp.Header.Get("Retry-After"))
+	}
+
+	// Conexoes simultaneas: com um stream aberto a segunda conexao e recusada
+	_, frames := a.abrirStream(t, "/sse/painel?apiKey=key-max", "")
+	proximoFrame(t, frames, "update")
+	if resp, _ := a.abrirStream(t, "/sse/painel?apiKey=key-max", ""); resp.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("Segunda conexao simultanea: status=%d, esperava 429", resp.StatusCode)
+	}
+
+	// Recusas contadas por chave no admin (acesso local)
+	_, body := a.get(t, "/sse/admin/api-keys", nil)
+	var admin struct {
+		APIKeys map[string]partnerUsage `json:"apiKeys"`
+	}
+	if err := json.Unmarshal([]byte(body), &admin); err != nil {
+		t.Fatalf("Resposta do admin invalida: %v (%s)", err, body)
+	}
+	recusadas := map[string]int64{}
+	for _, u := range admin.APIKeys {
+		recusadas[u.Nome] = u.Recusadas
+	}
+	if recusadas["rate"] != 1 || recusadas["max"] != 1 {
+		t.Errorf("Recusas por chave inesperadas: %v", recusadas)
+	}
+	if strings.Contains(body, "key-") {
+		t.Errorf("Admin nao pode expor a chave: %s", body)
+	}
+}
@@ -0,0 +1,257 @@
+package handlers
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"radarfutebol-sse/internal/config"
+)
+
+// tokenBucket balde de tokens de uma chave (IP ou usuario)
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// bucketLimiter limita novas conexoes por chave usando token bucket
+type bucketLimiter struct {
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	rate      float64 // tokens por segundo
+	burst     float64
+	lastSweep time.Time
+}
+
+func newBucketLimiter(limit config.TierLimit) *bucketLimiter {
+	l := &bucketLimiter{buckets: make(map[string]*tokenBucket)}
+	l.setLimit(limit)
+	return l
+}
+
+// setLimit altera taxa e rajada (baldes existentes seguem com os tokens que tem)
+func (l *bucketLimiter) setLimit(limit config.TierLimit) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.rate = limit.Rate / 60
+	l.burst = float64(limit.Burst)
+}
+
+// allow consome um token da chave; se nao houver, retorna quanto tempo esperar
+func (l *bucketLimiter) allow(key string, now time.Time) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.rate <= 0 || l.burst <= 0 {
+		return true, 0
+	}
+
+	// Remove baldes cheios a cada minuto para o mapa nao crescer sem limite
+	if now.Sub(l.lastSweep) > time.Minute {
+		for k, b := range l.buckets {
+			if b.tokens+now.Sub(b.last).Seconds()*l.rate >= l.burst {
+				delete(l.buckets, k)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	b, exists := l.buckets[key]
+	if !exists {
+		b = &tokenBucket{tokens: l.burst, last: now}
+		l.buckets[key] = b
+	}
+
+	// Recarrega tokens proporcionalmente ao tempo decorrido
+	b.tokens = math.Min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+
+	wait := time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+	return false, wait
+}
+
+// ConnLimiter aplica limites de taxa por IP/usuario e de streams simultaneos por IP
+type ConnLimiter struct {
+	enabled        atomic.Bool
+	trustedProxies map[string]bool
+
+	ip        *bucketLimiter
+	free      *bucketLimiter
+	assinante *bucketLimiter
+	requests  *bucketLimiter // snapshots REST por IP
+
+	streamsMu       sync.Mutex
+	streamsPerIP    map[string]int
+	maxStreamsPerIP int // protegido por streamsMu
+
+	rejected int64 // atomic: total de conexoes recusadas com 429
+}
+
+// NewConnLimiter cria o limitador a partir da configuracao
+func NewConnLimiter(cfg config.RateLimitConfig) *ConnLimiter {
+	trusted := make(map[string]bool, len(cfg.TrustedProxies))
+	for _, p := range cfg.TrustedProxies {
+		trusted[p] = true
+	}
+
+	l := &ConnLimiter{
+		trustedProxies:  trusted,
+		ip:              newBucketLimiter(cfg.IP),
+		free:            newBucketLimiter(cfg.Free),
+		assinante:       newBucketLimiter(cfg.Assinante),
+		requests:        newBucketLimiter(cfg.Requests),
+		streamsPerIP:    make(map[string]int),
+		maxStreamsPerIP: cfg.MaxStreamsPerIP,
+	}
+	l.enabled.Store(cfg.Enabled)
+	return l
+}
+
+// Update aplica limites recarregados (SIGHUP); proxies confiaveis exigem restart
+func (l *ConnLimiter) Update(cfg config.RateLimitConfig) {
+	l.enabled.Store(cfg.Enabled)
+	l.ip.setLimit(cfg.IP)
+	l.free.setLimit(cfg.Free)
+	l.assinante.setLimit(cfg.Assinante)
+	l.requests.setLimit(cfg.Requests)
+
+	l.streamsMu.Lock()
+	l.maxStreamsPerIP = cfg.MaxStreamsPerIP
+	l.streamsMu.Unlock()
+}
+
+// ClientIP retorna o IP real do cliente
+// So confia no X-Forwarded-For quando a conexao vem de um proxy conhecido (nginx)
+func (l *ConnLimiter) ClientIP(r *http.Request) string {
+	remoteIP, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		remoteIP = r.RemoteAddr
+	}
+
+	if !l.trustedProxies[remoteIP] {
+		return remoteIP
+	}
+
+	// Percorre da direita para a esquerda: o primeiro IP nao confiavel e o cliente
+	xff := r.Header.Get("X-Forwarded-For")
+	if xff == "" {
+		return remoteIP
+	}
+	parts := strings.Split(xff, ",")
+	for i := len(parts) - 1; i >= 0; i-- {
+		ip := strings.TrimSpace(parts[i])
+		if ip != "" && !l.trustedProxies[ip] {
+			return ip
+		}
+	}
+	return remoteIP
+}
+
+// AllowIP verifica o limite de novas conexoes por IP (antes de consultar token)
+// Se recusar, ja escreve a resposta 429
+func (l *ConnLimiter) AllowIP(w http.ResponseWriter, ip string) bool {
+	if !l.enabled.Load() {
+		return true
+	}
+	ok, wait := l.ip.allow(ip, time.Now())
+	if !ok {
+		l.reject(w, wait, "Muitas conexoes deste IP, tente novamente")
+	}
+	return ok
+}
+
+// AllowRequest verifica o limite de snapshots REST por IP (separado das conexoes SSE)
+// Se recusar, ja escreve a resposta 429
+func (l *ConnLimiter) AllowRequest(w http.ResponseWriter, ip string) bool {
+	if !l.enabled.Load() {
+		return true
+	}
+	ok, wait := l.requests.allow(ip, time.Now())
+	if !ok {
+		l.reject(w, wait, "Muitas requisicoes deste IP, tente novamente")
+	}
+	return ok
+}
+
+// AllowUser verifica o limite de novas conexoes por usuario, conforme o tier
+// Anonimos ficam apenas com o limite por IP
+func (l *ConnLimiter) AllowUser(w http.ResponseWriter, idUsuario int, isAssinante bool) bool {
+	if !l.enabled.Load() || idUsuario == 0 {
+		return true
+	}
+
+	limiter := l.free
+	if isAssinante {
+		limiter = l.assinante
+	}
+
+	ok, wait := limiter.allow(strconv.Itoa(idUsuario), time.Now())
+	if !ok {
+		l.reject(w, wait, "Muitas conexoes deste usuario, tente novamente")
+	}
+	return ok
+}
+
+// AcquireStream reserva um stream simultaneo para o IP
+// Retorna a funcao que libera o stream (chamar com defer)
+func (l *ConnLimiter) AcquireStream(w http.ResponseWriter, ip string) (func(), bool) {
+	if !l.enabled.Load() {
+		return func() {}, true
+	}
+
+	l.streamsMu.Lock()
+	if l.maxStreamsPerIP <= 0 {
+		l.streamsMu.Unlock()
+		return func() {}, true
+	}
+	if l.streamsPerIP[ip] >= l.maxStreamsPerIP {
+		l.streamsMu.Unlock()
+		l.reject(w, 10*time.Second, "Limite de conexoes simultaneas deste IP atingido")
+		return nil, false
+	}
+	l.streamsPerIP[ip]++
+	l.streamsMu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.streamsMu.Lock()
+			l.streamsPerIP[ip]--
+			if l.streamsPerIP[ip] <= 0 {
+				delete(l.streamsPerIP, ip)
+			}
+			l.streamsMu.Unlock()
+		})
+	}, true
+}
+
+// Rejected retorna o total de conexoes recusadas por limite de taxa
+func (l *ConnLimiter) Rejected() int64 {
+	return atomic.LoadInt64(&l.rejected)
+}
+
+// reject conta a recusa e responde 429
+func (l *ConnLimiter) reject(w http.ResponseWriter, wait time.Duration, msg string) {
+	atomic.AddInt64(&l.rejected, 1)
+	tooManyRequests(w, wait, msg)
+}
+
+// tooManyRequests responde 429 com Retry-After (em segundos, arredondado para cima)
+func tooManyRequests(w http.ResponseWriter, wait time.Duration, msg string) {
+	retryAfter := int(math.Ceil(wait.Seconds()))
+	if retryAfter < 1 {
+		retryAfter = 1
+	}
+	w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+	http.Error(w, msg, http.StatusTooManyRequests)
+}
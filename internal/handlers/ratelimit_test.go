@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"radarfutebol-sse/internal/config"
+)
+
+func TestBucketLimiter_BurstERecarga(t *testing.T) {
+	l := newBucketLimiter(config.TierLimit{Rate: 60, Burst: 3}) // 1 token por segundo
+	now := time.Now()
+
+	for i := 0; i < 3; i++ {
+		if ok, _ := l.allow("1.2.3.4", now); !ok {
+			t.Fatalf("Conexao %d deveria passar dentro do burst", i+1)
+		}
+	}
+
+	ok, wait := l.allow("1.2.3.4", now)
+	if ok {
+		t.Fatal("Quarta conexao deveria ser recusada")
+	}
+	if wait <= 0 || wait > time.Second {
+		t.Errorf("Espera esperada entre 0 e 1s, recebeu %v", wait)
+	}
+
+	// Outra chave tem balde proprio
+	if ok, _ := l.allow("5.6.7.8", now); !ok {
+		t.Error("IP diferente nao deveria ser afetado")
+	}
+
+	// Apos 1s recarrega 1 token
+	if ok, _ := l.allow("1.2.3.4", now.Add(time.Second)); !ok {
+		t.Error("Deveria recarregar token apos 1s")
+	}
+}
+
+func TestConnLimiter_ClientIP(t *testing.T) {
+	l := NewConnLimiter(config.RateLimitConfig{TrustedProxies: []string{"127.0.0.1"}})
+
+	// Via nginx: usa X-Forwarded-For
+	r := httptest.NewRequest(http.MethodGet, "/sse/painel", nil)
+	r.RemoteAddr = "127.0.0.1:5000"
+	r.Header.Set("X-Forwarded-For", "9.9.9.9, 200.1.1.1")
+	if ip := l.ClientIP(r); ip != "200.1.1.1" {
+		t.Errorf("Esperado 200.1.1.1, recebeu %s", ip)
+	}
+
+	// Acesso direto: ignora X-Forwarded-For forjado
+	r.RemoteAddr = "200.2.2.2:5000"
+	if ip := l.ClientIP(r); ip != "200.2.2.2" {
+		t.Errorf("Esperado 200.2.2.2, recebeu %s", ip)
+	}
+}
+
+func TestConnLimiter_StreamsPorIP(t *testing.T) {
+	l := NewConnLimiter(config.RateLimitConfig{Enabled: true, MaxStreamsPerIP: 1})
+
+	release, ok := l.AcquireStream(httptest.NewRecorder(), "1.2.3.4")
+	if !ok {
+		t.Fatal("Primeiro stream deveria passar")
+	}
+
+	w := httptest.NewRecorder()
+	if _, ok := l.AcquireStream(w, "1.2.3.4"); ok {
+		t.Fatal("Segundo stream simultaneo deveria ser recusado")
+	}
+	if w.Code != http.StatusTooManyRequests || w.Header().Get("Retry-After") == "" {
+		t.Errorf("Esperado 429 com Retry-After, recebeu %d %q", w.Code, w.Header().Get("Retry-After"))
+	}
+
+	release()
+	if _, ok := l.AcquireStream(httptest.NewRecorder(), "1.2.3.4"); !ok {
+		t.Error("Stream deveria passar apos liberar o anterior")
+	}
+}
+
+func TestConnLimiter_RequestsSeparadoDasConexoes(t *testing.T) {
+	l := NewConnLimiter(config.RateLimitConfig{
+		Enabled:  true,
+		IP:       config.TierLimit{Rate: 60, Burst: 1},
+		Requests: config.TierLimit{Rate: 60, Burst: 2},
+	})
+
+	// Snapshots REST nao consomem o balde de novas conexoes do IP
+	for i := 0; i < 2; i++ {
+		if !l.AllowRequest(httptest.NewRecorder(), "1.2.3.4") {
+			t.Fatalf("Requisicao %d deveria passar dentro do burst", i+1)
+		}
+	}
+	if l.AllowRequest(httptest.NewRecorder(), "1.2.3.4") {
+		t.Error("Terceira requisicao deveria ser recusada")
+	}
+	if !l.AllowIP(httptest.NewRecorder(), "1.2.3.4") {
+		t.Error("Conexao SSE nao deveria ser afetada pelas requisicoes REST")
+	}
+}
@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"log"
+	"sync"
+
+	"radarfutebol-sse/internal/services"
+)
+
+// streamSession representa um stream SSE aberto de um usuario logado
+type streamSession struct {
+	idUsuario int
+	token     string
+
+	// authChan recebe o novo resultado de autenticacao (IsValid=false = revogado)
+	authChan chan services.AuthResult
+}
+
+// notify entrega o resultado sem bloquear, substituindo um pendente nao lido
+func (s *streamSession) notify(result services.AuthResult) {
+	for {
+		select {
+		case s.authChan <- result:
+			return
+		default:
+		}
+		select {
+		case <-s.authChan:
+		default:
+		}
+	}
+}
+
+// sessionRegistry indexa os streams abertos por usuario
+type sessionRegistry struct {
+	mu     sync.Mutex
+	byUser map[int]map[*streamSession]struct{}
+
+	// Ultima mudanca de autenticacao por usuario: reavaliacao que terminar depois
+	// de uma mudanca mais nova (ex: revogacao) e descartada
+	seq       uint64
+	mudancaDe map[int]uint64
+}
+
+func newSessionRegistry() *sessionRegistry {
+	return &sessionRegistry{
+		byUser:    make(map[int]map[*streamSession]struct{}),
+		mudancaDe: make(map[int]uint64),
+	}
+}
+
+// register adiciona um stream e retorna a funcao que o remove (chamar com defer)
+// Anonimos nao sao registrados (nao ha o que revogar)
+func (r *sessionRegistry) register(idUsuario int, token string) (*streamSession, func()) {
+	session := &streamSession{
+		idUsuario: idUsuario,
+		token:     token,
+		authChan:  make(chan services.AuthResult, 1),
+	}
+	if idUsuario <= 0 {
+		return session, func() {}
+	}
+
+	r.mu.Lock()
+	if r.byUser[idUsuario] == nil {
+		r.byUser[idUsuario] = make(map[*streamSession]struct{})
+	}
+	r.byUser[idUsuario][session] = struct{}{}
+	r.mu.Unlock()
+
+	return session, func() {
+		r.mu.Lock()
+		delete(r.byUser[idUsuario], session)
+		if len(r.byUser[idUsuario]) == 0 {
+			delete(r.byUser, idUsuario)
+		}
+		r.mu.Unlock()
+	}
+}
+
+// sessionsOf retorna copia dos streams abertos do usuario
+func (r *sessionRegistry) sessionsOf(idUsuario int) []*streamSession {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sessions := make([]*streamSession, 0, len(r.byUser[idUsuario]))
+	for s := range r.byUser[idUsuario] {
+		sessions = append(sessions, s)
+	}
+	return sessions
+}
+
+// iniciarMudanca registra uma nova mudanca de autenticacao do usuario e retorna seu numero
+func (r *sessionRegistry) iniciarMudanca(idUsuario int) uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.seq++
+	r.mudancaDe[idUsuario] = r.seq
+	return r.seq
+}
+
+// concluirMudanca entrega os resultados se a mudanca ainda for a mais recente do usuario
+// Retorna false se uma mudanca mais nova chegou no meio (nada e entregue)
+func (r *sessionRegistry) concluirMudanca(idUsuario int, seq uint64, results map[*streamSession]services.AuthResult) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.mudancaDe[idUsuario] != seq {
+		return false
+	}
+	delete(r.mudancaDe, idUsuario)
+	for s, result := range results {
+		s.notify(result)
+	}
+	return true
+}
+
+// HandleAuthChange propaga revogacao/mudanca de plano para os streams abertos do usuario
+// Chamado no loop do pub/sub: a reavaliacao no MySQL roda em outra goroutine
+func (h *SSEHandler) HandleAuthChange(change services.AuthChange) {
+	h.auth.InvalidarCache(change.IdUsuario)
+
+	seq := h.sessions.iniciarMudanca(change.IdUsuario)
+	sessions := h.sessions.sessionsOf(change.IdUsuario)
+
+	if change.Revogar {
+		h.auth.RegistrarRevogacao(change.IdUsuario)
+
+		results := make(map[*streamSession]services.AuthResult, len(sessions))
+		for _, s := range sessions {
+			results[s] = services.AuthResult{IdUsuario: change.IdUsuario, IsValid: false}
+		}
+		h.sessions.concluirMudanca(change.IdUsuario, seq, results)
+		if len(sessions) > 0 {
+			log.Printf("Auth: Revogados %d streams do usuario %d", len(sessions), change.IdUsuario)
+		}
+		return
+	}
+
+	go h.reavaliarSessoes(change.IdUsuario, seq, sessions)
+}
+
+// reavaliarSessoes consulta o MySQL para cada token distinto (ignorando cache) e entrega o novo tier
+// Grava antes o novo tier para os JWTs do usuario (inclusive de outras instancias e conexoes futuras)
+func (h *SSEHandler) reavaliarSessoes(idUsuario int, seq uint64, sessions []*streamSession) {
+	h.auth.RegistrarMudancaDePlano(idUsuario)
+
+	porToken := make(map[string]services.AuthResult)
+	results := make(map[*streamSession]services.AuthResult, len(sessions))
+	for _, s := range sessions {
+		result, ok := porToken[s.token]
+		if !ok {
+			result = h.auth.RevalidateToken(s.token)
+			porToken[s.token] = result
+		}
+
+		// MySQL indisponivel, mantem o estado atual
+		if result.Unavailable {
+			continue
+		}
+		results[s] = result
+	}
+
+	if h.sessions.concluirMudanca(idUsuario, seq, results) && len(sessions) > 0 {
+		log.Printf("Auth: Plano do usuario %d reavaliado em %d streams", idUsuario, len(sessions))
+	}
+}
@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"radarfutebol-sse/internal/config"
+	"radarfutebol-sse/internal/services"
+)
+
+func TestSessionRegistry_RegistroPorUsuario(t *testing.T) {
+	r := newSessionRegistry()
+
+	a, removeA := r.register(10, "tok-a")
+	_, removeB := r.register(10, "tok-b")
+	_, removeAnonimo := r.register(0, "")
+	defer removeAnonimo()
+
+	if n := len(r.sessionsOf(10)); n != 2 {
+		t.Fatalf("Esperava 2 streams do usuario, obteve %d", n)
+	}
+	if n := len(r.sessionsOf(0)); n != 0 {
+		t.Errorf("Anonimos nao deveriam ser registrados, obteve %d", n)
+	}
+
+	removeB()
+	if s := r.sessionsOf(10); len(s) != 1 || s[0] != a {
+		t.Errorf("Apos remover deveria sobrar apenas o primeiro stream: %v", s)
+	}
+	removeA()
+	if _, existe := r.byUser[10]; existe {
+		t.Error("Usuario sem streams deveria sair do indice")
+	}
+}
+
+func TestHandleAuthChange_RevogacaoEMudancaDePlano(t *testing.T) {
+	store := services.NewAuthMemoria()
+	store.AddToken("tok-assinante", 10, 4)
+	auth := services.NewAutenticador(store)
+	h := NewSSEHandlerWith(config.Defaults(), services.NewBroadcaster(services.Stores{}), auth, services.NewAPIKeysMemoria())
+
+	// Token em cache: a mudanca precisa invalidar via o autenticador injetado
+	if !auth.ValidateToken("tok-assinante").IsAssinante {
+		t.Fatal("Token deveria ser de assinante")
+	}
+	session, remove := h.sessions.register(10, "tok-assinante")
+	defer remove()
+
+	// Plano mudou (assinatura cancelada): reavalia fora do loop e entrega o novo tier
+	store.AddToken("tok-assinante", 10, 5)
+	h.HandleAuthChange(services.AuthChange{IdUsuario: 10})
+	select {
+	case result := <-session.authChan:
+		if !result.IsValid || result.IsAssinante {
+			t.Errorf("Esperava free valido, obteve %+v", result)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Stream nao recebeu a reavaliacao do plano")
+	}
+	if _, ok := store.LerCache("tok-assinante"); !ok {
+		t.Error("Reavaliacao deveria regravar o cache com o novo tier")
+	}
+
+	// Revogacao: entrega na hora e limpa o cache do usuario
+	h.HandleAuthChange(services.AuthChange{IdUsuario: 10, Revogar: true})
+	select {
+	case result := <-session.authChan:
+		if result.IsValid {
+			t.Errorf("Esperava revogacao, obteve %+v", result)
+		}
+	default:
+		t.Fatal("Revogacao deveria ser entregue sem esperar o MySQL")
+	}
+	if _, ok := store.LerCache("tok-assinante"); ok {
+		t.Error("Revogacao deveria invalidar o cache do autenticador injetado")
+	}
+}
+
+func TestSessionRegistry_ReavaliacaoAtrasadaDescartada(t *testing.T) {
+	r := newSessionRegistry()
+	session, remove := r.register(10, "tok")
+	defer remove()
+
+	antiga := r.iniciarMudanca(10)
+	nova := r.iniciarMudanca(10)
+
+	// Revogacao (mais nova) entregue antes da reavaliacao que ainda estava no MySQL
+	r.concluirMudanca(10, nova, map[*streamSession]services.AuthResult{session: {IdUsuario: 10}})
+	if r.concluirMudanca(10, antiga, map[*streamSession]services.AuthResult{session: {IdUsuario: 10, IsValid: true}}) {
+		t.Fatal("Reavaliacao antiga nao deveria ser entregue")
+	}
+	if result := <-session.authChan; result.IsValid {
+		t.Error("Revogacao foi sobrescrita pela reavaliacao antiga")
+	}
+}
@@ -0,0 +1,240 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"radarfutebol-sse/internal/codec"
+	"radarfutebol-sse/internal/models"
+)
+
+// handleSnapshotPainel GET /api/painel - mesmo payload do /sse/painel em uma unica resposta
+func (h *SSEHandler) handleSnapshotPainel(w http.ResponseWriter, r *http.Request) {
+	h.handleSnapshot(w, r, "painel")
+}
+
+// handleSnapshotHome GET /api/home - mesmo payload do /sse/home em uma unica resposta
+func (h *SSEHandler) handleSnapshotHome(w http.ResponseWriter, r *http.Request) {
+	h.handleSnapshot(w, r, "home")
+}
+
+// handleSnapshot responde o snapshot atual filtrado (para crawlers, SSR e widgets)
+func (h *SSEHandler) handleSnapshot(w http.ResponseWriter, r *http.Request, endpoint string) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	filtro := models.ParseFiltroFromRequest(r)
+	if !parseProjecao(w, r, filtro) {
+		return
+	}
+
+	if !h.admitRequest(w, r, filtro) {
+		return
+	}
+
+	// Snapshot nao consome alertas de gol (som e exclusivo do stream)
+	filtro.SomLigado = false
+
+	broadcaster := h.broadcaster
+	generation := broadcaster.Generation()
+
+	var jsonData []byte
+	var err error
+	switch endpoint {
+	case "painel":
+		jsonData, err = broadcaster.GetEventosPainelFiltradoCached(filtro)
+	case "home":
+		jsonData, err = broadcaster.GetEventosHomeFiltradoCached(filtro)
+	}
+	if err != nil {
+		log.Printf("API %s: Erro ao buscar dados: %v", endpoint, err)
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeSnapshot(w, r, generation, jsonData, jsonData, filtro)
+}
+
+// handleSnapshotOraculo GET /api/oraculo/{idWilliamhill}
+func (h *SSEHandler) handleSnapshotOraculo(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	idWilliamhill := strings.TrimPrefix(r.URL.Path, "/api/oraculo/")
+	if idWilliamhill == "" {
+		http.Error(w, "idWilliamhill obrigatorio", http.StatusBadRequest)
+		return
+	}
+
+	filtro := models.ParseFiltroFromRequest(r)
+	if !parseEncoding(w, r, filtro) {
+		return
+	}
+
+	if !h.admitRequest(w, r, filtro) {
+		return
+	}
+
+	broadcaster := h.broadcaster
+	if filtro.CampeonatosPermitidos != nil {
+		evento := broadcaster.FindEventoByIdWilliamhill(idWilliamhill)
+		if evento == nil || !filtro.CampeonatosPermitidos[evento.IdCampeonatoUnico] {
+			http.Error(w, "API key sem acesso a este jogo", http.StatusForbidden)
+			return
+		}
+	}
+
+	generation := broadcaster.Generation()
+	data, err := broadcaster.GetOraculoCached(idWilliamhill)
+	if err != nil {
+		log.Printf("API oraculo: Erro ao buscar dados (jogo=%s): %v", idWilliamhill, err)
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if data == nil {
+		writeJSONError(w, http.StatusNotFound, "Jogo nao encontrado no cache")
+		return
+	}
+
+	data = filtrarOraculo(data, filtro)
+
+	// ETag calculado sobre os dados (o timestamp da resposta muda a cada segundo)
+	dataBytes, err := codec.Marshal(filtro.Encoding, data)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	body, err := codec.Marshal(filtro.Encoding, map[string]interface{}{
+		"oraculo":   data,
+		"timestamp": time.Now().Unix(),
+	})
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeSnapshot(w, r, generation, dataBytes, body, filtro)
+}
+
+// handleSnapshotEvento GET /api/eventos/{idEvento} - um evento ativo do snapshot atual
+// Mesmos campos do evento no /api/painel (tier, escopo de odds e fields=)
+func (h *SSEHandler) handleSnapshotEvento(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	idEvento, err := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/api/eventos/"))
+	if err != nil || idEvento <= 0 {
+		http.Error(w, "idEvento invalido", http.StatusBadRequest)
+		return
+	}
+
+	filtro := models.ParseFiltroFromRequest(r)
+	if !parseProjecao(w, r, filtro) {
+		return
+	}
+
+	if !h.admitRequest(w, r, filtro) {
+		return
+	}
+
+	broadcaster := h.broadcaster
+	generation := broadcaster.Generation()
+	evento := broadcaster.FindEventoById(idEvento)
+	if evento == nil {
+		writeJSONError(w, http.StatusNotFound, "Evento nao encontrado no cache")
+		return
+	}
+	if filtro.CampeonatosPermitidos != nil && !filtro.CampeonatosPermitidos[evento.IdCampeonatoUnico] {
+		http.Error(w, "API key sem acesso a este jogo", http.StatusForbidden)
+		return
+	}
+
+	// Evento do snapshot e compartilhado: os filtros retornam copia
+	if !filtro.IsAssinante {
+		evento = evento.FiltrarParaFree()
+	}
+	if filtro.OcultarOdds {
+		evento = evento.RemoverOdds()
+	}
+	var data interface{} = evento
+	if filtro.Projecao != nil {
+		data = filtro.Projecao.Evento(evento)
+	}
+
+	dataBytes, err := codec.Marshal(filtro.Encoding, data)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	body, err := codec.Marshal(filtro.Encoding, map[string]interface{}{
+		"evento":    data,
+		"timestamp": time.Now().Unix(),
+	})
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	writeSnapshot(w, r, generation, dataBytes, body, filtro)
+}
+
+// writeSnapshot escreve a resposta com ETag/Cache-Control e responde 304 se o cliente ja tem a versao
+// O ETag combina a geracao do snapshot do Broadcaster com o hash do conteudo filtrado
+func writeSnapshot(w http.ResponseWriter, r *http.Request, generation uint64, etagSource, body []byte, filtro *models.Filtro) {
+	h := fnv.New64a()
+	h.Write(etagSource)
+	etag := fmt.Sprintf(`W/"%d-%x"`, generation, h.Sum64())
+
+	// Cadencia igual a do stream: 2s assinante, 10s free/anonimo
+	maxAge := int(tickerDuration(filtro.IsAssinante).Seconds())
+	if filtro.IdUsuario > 0 {
+		// Resposta contem favoritos do usuario: nao pode ir para cache compartilhado
+		w.Header().Set("Cache-Control", fmt.Sprintf("private, max-age=%d", maxAge))
+	} else {
+		w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d, stale-while-revalidate=%d", maxAge, maxAge))
+	}
+	w.Header().Set("Vary", "Authorization, X-API-Key")
+	w.Header().Set("ETag", etag)
+
+	if match := r.Header.Get("If-None-Match"); match != "" && etagMatches(match, etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", codec.ContentType(filtro.Encoding))
+	if r.Method == http.MethodHead {
+		return
+	}
+	w.Write(body)
+}
+
+// etagMatches compara If-None-Match (lista separada por virgula ou *) com o ETag atual
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+// writeJSONError responde erro no formato {"error": "..."}
+func writeJSONError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": msg})
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"radarfutebol-sse/internal/services"
+)
+
+func TestSnapshot_ETagENaoModificado(t *testing.T) {
+	a := novoAmbienteTeste(t)
+
+	resp, body := a.get(t, "/api/painel", nil)
+	etag := resp.Header.Get("ETag")
+	if resp.StatusCode != http.StatusOK || etag == "" || !strings.Contains(body, `"eventos"`) {
+		t.Fatalf("status=%d etag=%q body=%s", resp.StatusCode, etag, body)
+	}
+	if cc := resp.Header.Get("Cache-Control"); !strings.HasPrefix(cc, "public, max-age=10") {
+		t.Errorf("Anonimo deveria ter cache publico com a cadencia free: %q", cc)
+	}
+
+	// Cliente com a versao atual (inclusive em lista) recebe 304 sem corpo
+	for _, match := range []string{etag, `"outro", ` + etag} {
+		resp, body = a.get(t, "/api/painel", map[string]string{"If-None-Match": match})
+		if resp.StatusCode != http.StatusNotModified || body != "" {
+			t.Errorf("If-None-Match %q: status=%d body=%q", match, resp.StatusCode, body)
+		}
+	}
+
+	// Snapshot novo: o ETag antigo deixa de valer
+	geracao := a.b.Generation()
+	a.fonte.Set(`[{"idEvento": 3, "idWilliamhill": "333", "status": "inprogress", "idCampeonatoUnico": "10"}]`)
+	for deadline := time.Now().Add(5 * time.Second); a.b.Generation() == geracao; time.Sleep(10 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("Broadcaster nao carregou o snapshot novo")
+		}
+	}
+	resp, _ = a.get(t, "/api/painel", map[string]string{"If-None-Match": etag})
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("ETag") == etag {
+		t.Fatalf("Apos mudar a geracao: status=%d etag=%q (antigo %q)", resp.StatusCode, resp.Header.Get("ETag"), etag)
+	}
+}
+
+func TestSnapshot_PrivadoComToken(t *testing.T) {
+	a := novoAmbienteTeste(t)
+
+	// Resposta com favoritos do usuario nao pode ir para cache compartilhado
+	resp, _ := a.get(t, "/api/painel", map[string]string{"Authorization": "Bearer tok-assinante"})
+	if cc := resp.Header.Get("Cache-Control"); resp.StatusCode != http.StatusOK || !strings.HasPrefix(cc, "private, max-age=") {
+		t.Fatalf("status=%d Cache-Control=%q, esperava private", resp.StatusCode, cc)
+	}
+	if vary := resp.Header.Get("Vary"); !strings.Contains(vary, "Authorization") {
+		t.Errorf("Vary deveria incluir Authorization: %q", vary)
+	}
+}
+
+func TestSnapshot_CampeonatoForaDoEscopoDaAPIKey(t *testing.T) {
+	a := novoAmbienteTeste(t)
+	a.apiKeys.AddAPIKey("key-camp10", services.APIKey{
+		Nome: "parceiro", Ativo: true, Endpoints: []string{"painel", "oraculo"}, Campeonatos: []string{"10"},
+	})
+	chave := map[string]string{"X-API-Key": "key-camp10"}
+
+	// Evento 1 e do campeonato 10 (liberado), evento 2 do 20
+	if resp, body := a.get(t, "/api/eventos/1", chave); resp.StatusCode != http.StatusOK {
+		t.Fatalf("Evento liberado: status=%d body=%s", resp.StatusCode, body)
+	}
+	for _, path := range []string{"/api/eventos/2", "/api/oraculo/222"} {
+		resp, body := a.get(t, path, chave)
+		if resp.StatusCode != http.StatusForbidden || !strings.Contains(body, "sem acesso a este jogo") {
+			t.Errorf("%s: status=%d body=%s, esperava 403", path, resp.StatusCode, body)
+		}
+	}
+
+	// Lista filtrada pelo escopo da chave
+	if _, body := a.get(t, "/api/painel", chave); !strings.Contains(body, `"idEvento":1`) || strings.Contains(body, `"idEvento":2`) {
+		t.Errorf("Painel do parceiro trouxe jogo fora do escopo: %s", body)
+	}
+}
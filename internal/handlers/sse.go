@@ -1,14 +1,18 @@
 package handlers
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
+	"strconv"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	"radarfutebol-sse/internal/codec"
+	"radarfutebol-sse/internal/config"
 	"radarfutebol-sse/internal/models"
 	"radarfutebol-sse/internal/services"
 )
@@ -21,14 +25,117 @@ var reloadMu sync.Mutex
 // SSEHandler gerencia conexoes SSE
 type SSEHandler struct {
 	connections int64 // atomic counter para total de conexoes
-	maxConns    int64 // limite maximo de conexoes (0 = sem limite)
+	maxConns    int64 // atomic: limite maximo de conexoes (0 = sem limite; recarregavel)
+	readyMaxAge int64 // atomic: idade maxima do snapshot para o /readyz (time.Duration)
+	limiter     *ConnLimiter
+	sessions    *sessionRegistry
+	partners    *partnerGate
+	multi       *multiRegistry
+	compression *streamCompression // gzip/deflate por frame nos streams
+	drain       *drainer           // encerramento gradual dos streams no deploy
+
+	broadcaster *services.Broadcaster  // snapshot de eventos e hubs do oraculo
+	auth        *services.Autenticador // validacao dos tokens
+	apiKeys     services.APIKeyStore   // chaves dos parceiros B2B
+
+	usaRedis bool       // redis em event_source.order (exige restart)
+	deps     *depsCache // ultimo /health/deps (pings limitados por depsCacheTTL)
 }
 
-// NewSSEHandler cria um novo handler SSE
-func NewSSEHandler() *SSEHandler {
-	return &SSEHandler{
-		maxConns: 10000, // Limite de 10k conexoes simultaneas
+// NewSSEHandler cria um novo handler SSE (Broadcaster, autenticacao e API keys padrao: Redis/MySQL)
+func NewSSEHandler(cfg *config.Config) *SSEHandler {
+	return NewSSEHandlerWith(cfg, services.GetBroadcaster(), services.GetAutenticador(), services.GetAPIKeyStore())
+}
+
+// NewSSEHandlerWith cria o handler com Broadcaster, autenticador e API keys injetados (testes usam stores em memoria)
+func NewSSEHandlerWith(cfg *config.Config, broadcaster *services.Broadcaster, auth *services.Autenticador, apiKeys services.APIKeyStore) *SSEHandler {
+	h := &SSEHandler{
+		broadcaster: broadcaster,
+		auth:        auth,
+		apiKeys:     apiKeys,
+
+		limiter:  NewConnLimiter(cfg.RateLimit),
+		sessions: newSessionRegistry(),
+		partners: newPartnerGate(),
+		multi:    newMultiRegistry(),
+
+		compression: newStreamCompression(cfg.Server.Compression, cfg.Server.CompressionLevel, cfg.Server.CompressionMaxStreams),
+		drain:       newDrainer(cfg.Server.DrainRetryMin, cfg.Server.DrainRetryMax),
+
+		usaRedis: cfg.EventSource.Usa("redis"),
+		deps:     &depsCache{},
+	}
+	h.ApplyConfig(cfg)
+	return h
+}
+
+// ApplyConfig aplica os valores recarregaveis (SIGHUP) sem derrubar conexoes:
+// limite de conexoes, cadencias, frescor, breakers, fallback anonimo, rate limit, drain, limite de compressao, readiness e nivel de log
+func (h *SSEHandler) ApplyConfig(cfg *config.Config) {
+	atomic.StoreInt64(&h.maxConns, int64(cfg.Server.MaxConns))
+	atomic.StoreInt64(&h.readyMaxAge, int64(cfg.Server.ReadyMaxSnapshotAge))
+	setCadencia(cfg.Stream)
+	logLevel.Store(cfg.Server.LogLevel)
+	h.limiter.Update(cfg.RateLimit)
+	h.drain.setRetry(cfg.Server.DrainRetryMin, cfg.Server.DrainRetryMax)
+	h.compression.setMaxStreams(cfg.Server.CompressionMaxStreams)
+	h.broadcaster.SetLimitesFrescor(cfg.Stream.DegradedAfter, cfg.Stream.StaleAfter)
+	services.ConfigurarBreakers(cfg.Breaker)
+	services.SetAnonymousFallback(cfg.Auth.AnonymousFallback)
+}
+
+// Cadencia dos updates por tier (stream.ticker_assinante e stream.ticker_free)
+var tickerAssinante, tickerFree atomic.Int64
+
+// logLevel nivel dos logs de conexao (server.log_level)
+var logLevel atomic.Value
+
+func init() {
+	defaults := config.Defaults()
+	setCadencia(defaults.Stream)
+	logLevel.Store(defaults.Server.LogLevel)
+}
+
+func setCadencia(cfg config.StreamConfig) {
+	tickerAssinante.Store(int64(cfg.TickerAssinante))
+	tickerFree.Store(int64(cfg.TickerFree))
+}
+
+// tickerDuration cadencia de updates: 2s para assinantes, 10s para free/anonimo (padrao)
+func tickerDuration(isAssinante bool) time.Duration {
+	if isAssinante {
+		return time.Duration(tickerAssinante.Load())
+	}
+	return time.Duration(tickerFree.Load())
+}
+
+// logConexao indica se a abertura/fechamento da conexao n deve ir para o log
+// debug: todas; info: a cada 100 (e as 10 primeiras); warn: nenhuma
+func logConexao(n int64) bool {
+	switch logLevel.Load() {
+	case "debug":
+		return true
+	case "warn":
+		return false
 	}
+	return n%100 == 0 || n <= 10
+}
+
+// sendDadosStatus avisa o cliente sobre o frescor dos dados (banner "dados atrasados")
+// Volta ao normal e enviada como recovered
+func sendDadosStatus(w http.ResponseWriter, flusher http.Flusher, dados services.DadosStatus) {
+	if dados.Status == "ok" {
+		dados.Status = "recovered"
+	}
+	data, _ := json.Marshal(dados)
+	fmt.Fprintf(w, "event: status\ndata: %s\n\n", data)
+	flusher.Flush()
+}
+
+// sendSessionRevoked avisa o cliente que a sessao foi revogada (stream sera encerrado)
+func sendSessionRevoked(w http.ResponseWriter, flusher http.Flusher) {
+	fmt.Fprintf(w, "event: session_revoked\ndata: {\"reason\": \"auth_revoked\"}\n\n")
+	flusher.Flush()
 }
 
 // getReloadChan retorna o canal de reload atual
@@ -50,10 +157,20 @@ func triggerReload() int64 {
 // RegisterRoutes registra as rotas SSE
 func (h *SSEHandler) RegisterRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("/sse/health", h.handleHealth)
-	mux.HandleFunc("/sse/painel", h.handlePainel)
-	mux.HandleFunc("/sse/home", h.handleHome)
-	mux.HandleFunc("/sse/oraculo/", h.handleOraculo)
+	mux.HandleFunc("/livez", h.handleLivez)
+	mux.HandleFunc("/readyz", h.handleReadyz)
+	mux.HandleFunc("/health/deps", h.handleHealthDeps)
+	mux.HandleFunc("/sse/painel", h.withAPIKey("painel", h.handlePainel))
+	mux.HandleFunc("/sse/home", h.withAPIKey("home", h.handleHome))
+	mux.HandleFunc("/sse/oraculo/", h.withAPIKey("oraculo", h.handleOraculo))
+	mux.HandleFunc("/sse/multi", h.withAPIKey("", h.handleMulti))
+	mux.HandleFunc("/sse/multi/subscriptions", h.handleMultiSubscriptions)
+	mux.HandleFunc("/api/painel", h.withAPIKey("painel", h.handleSnapshotPainel))
+	mux.HandleFunc("/api/home", h.withAPIKey("home", h.handleSnapshotHome))
+	mux.HandleFunc("/api/oraculo/", h.withAPIKey("oraculo", h.handleSnapshotOraculo))
+	mux.HandleFunc("/api/eventos/", h.withAPIKey("painel", h.handleSnapshotEvento))
 	mux.HandleFunc("/sse/admin/force-reload", h.handleForceReload)
+	mux.HandleFunc("/sse/admin/api-keys", h.handleAdminAPIKeys)
 	mux.HandleFunc("/stats", h.handleStats)
 }
 
@@ -83,13 +200,20 @@ func (h *SSEHandler) handleForceReload(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-// handleHealth retorna status do servidor
+// handleHealth retorna status do servidor (503 durante o drain, para o balanceador tirar a instancia)
 func (h *SSEHandler) handleHealth(w http.ResponseWriter, r *http.Request) {
+	status := "ok"
+	dados, _ := h.broadcaster.DadosStatus()
 	w.Header().Set("Content-Type", "application/json")
+	if h.drain.draining.Load() {
+		status = "draining"
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
 	json.NewEncoder(w).Encode(map[string]interface{}{
-		"status":      "ok",
+		"status":      status,
 		"connections": atomic.LoadInt64(&h.connections),
-		"maxConns":    h.maxConns,
+		"maxConns":    atomic.LoadInt64(&h.maxConns),
+		"dados":       dados,
 		"timestamp":   time.Now().Unix(),
 	})
 }
@@ -98,12 +222,131 @@ func (h *SSEHandler) handleHealth(w http.ResponseWriter, r *http.Request) {
 func (h *SSEHandler) handleStats(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]interface{}{
-		"connections": atomic.LoadInt64(&h.connections),
-		"maxConns":    h.maxConns,
-		"uptime":      time.Now().Unix(),
+		"connections":                atomic.LoadInt64(&h.connections),
+		"maxConns":                   atomic.LoadInt64(&h.maxConns),
+		"rateLimitRejected":          h.limiter.Rejected(),
+		"compressedStreams":          h.compression.Ativos(),
+		"oraculoHubs":                h.broadcaster.OraculoHubs(),
+		"oraculoCamposDesconhecidos": services.OraculoCamposDesconhecidos(),
+		"oraculoTiposInvalidos":      services.OraculoTiposInvalidos(),
+		"breakers":                   services.BreakersStatus(),
+		"uptime":                     time.Now().Unix(),
 	})
 }
 
+// admitConnection valida o token (ou o escopo do parceiro B2B) e aplica os limites de conexao
+// Retorna a funcao que libera o stream; se ok=false a resposta de erro ja foi escrita
+func (h *SSEHandler) admitConnection(w http.ResponseWriter, r *http.Request, filtro *models.Filtro) (func(), bool) {
+	// Parceiro: limites ja aplicados por withAPIKey
+	if aplicarEscopoParceiro(r, filtro) {
+		return func() {}, true
+	}
+
+	// Limite de novas conexoes por IP (antes do token para nao sobrecarregar MySQL)
+	clientIP := h.limiter.ClientIP(r)
+	if !h.limiter.AllowIP(w, clientIP) {
+		return nil, false
+	}
+	if !h.autenticar(w, filtro) {
+		return nil, false
+	}
+
+	// Limite de novas conexoes por usuario (conforme tier) e de streams simultaneos por IP
+	if !h.limiter.AllowUser(w, filtro.IdUsuario, filtro.IsAssinante) {
+		return nil, false
+	}
+	return h.limiter.AcquireStream(w, clientIP)
+}
+
+// admitRequest equivalente do admitConnection para os snapshots REST
+// Usa o limite de requisicoes por IP e nao ocupa stream nem o limite de novas conexoes
+func (h *SSEHandler) admitRequest(w http.ResponseWriter, r *http.Request, filtro *models.Filtro) bool {
+	if aplicarEscopoParceiro(r, filtro) {
+		return true
+	}
+	if !h.limiter.AllowRequest(w, h.limiter.ClientIP(r)) {
+		return false
+	}
+	return h.autenticar(w, filtro)
+}
+
+// aplicarEscopoParceiro preenche o filtro com o escopo da API key (campos conforme os grupos da chave)
+// Retorna false se a requisicao nao e de parceiro
+func aplicarEscopoParceiro(r *http.Request, filtro *models.Filtro) bool {
+	partner := partnerFromContext(r.Context())
+	if partner == nil {
+		return false
+	}
+	filtro.IdUsuario = 0
+	filtro.IsAssinante = partner.TemGrupo(services.GrupoEstatisticas)
+	filtro.CampeonatosPermitidos = partner.CampeonatosPermitidos()
+	filtro.OcultarOdds = !partner.TemGrupo(services.GrupoOdds)
+	return true
+}
+
+// autenticar valida o token e preenche usuario e tier no filtro
+// Se recusar, ja escreve a resposta de erro
+func (h *SSEHandler) autenticar(w http.ResponseWriter, filtro *models.Filtro) bool {
+	authResult := h.auth.ValidateToken(filtro.Token)
+
+	// MySQL indisponivel sem fallback anonimo: cliente reconecta depois
+	if authResult.Unavailable {
+		w.Header().Set("Retry-After", strconv.Itoa(int(h.drain.retry().Seconds())+1))
+		http.Error(w, "Autenticacao indisponivel, reconecte em instantes", http.StatusServiceUnavailable)
+		return false
+	}
+
+	// Se token fornecido mas invalido, retorna 401
+	if filtro.Token != "" && !authResult.IsValid {
+		http.Error(w, "Token invalido", http.StatusUnauthorized)
+		return false
+	}
+
+	// Atualiza filtro com dados do usuario autenticado
+	filtro.IdUsuario = authResult.IdUsuario
+	filtro.IsAssinante = authResult.IsAssinante
+	return true
+}
+
+// parseProjecao le os parametros fields= (perfis compact/list/full ou chaves do Evento) e encoding=
+// Responde 400 se houver campo desconhecido ou formato nao suportado
+func parseProjecao(w http.ResponseWriter, r *http.Request, filtro *models.Filtro) bool {
+	projecao, err := models.ParseProjecao(r.URL.Query().Get("fields"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return false
+	}
+	filtro.Projecao = projecao
+	return parseEncoding(w, r, filtro)
+}
+
+// parseEncoding le o parametro encoding= (json, msgpack ou cbor)
+// Responde 400 se o formato nao for suportado
+func parseEncoding(w http.ResponseWriter, r *http.Request, filtro *models.Filtro) bool {
+	encoding, err := codec.ParseFormat(r.URL.Query().Get("encoding"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return false
+	}
+	filtro.Encoding = encoding
+	return true
+}
+
+// writeUpdate escreve o frame SSE de update
+func writeUpdate(w http.ResponseWriter, data []byte, encoding string) {
+	writeFrame(w, "update", data, encoding)
+}
+
+// writeFrame escreve um frame SSE com o payload serializado
+// Formatos binarios vao em base64 (data: do SSE e texto, uma linha)
+func writeFrame(w http.ResponseWriter, event string, data []byte, encoding string) {
+	if encoding != codec.JSON {
+		fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, base64.StdEncoding.EncodeToString(data))
+		return
+	}
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data)
+}
+
 // handlePainel endpoint SSE para o painel
 func (h *SSEHandler) handlePainel(w http.ResponseWriter, r *http.Request) {
 	h.handleSSE(w, r, "painel")
@@ -118,26 +361,28 @@ func (h *SSEHandler) handleHome(w http.ResponseWriter, r *http.Request) {
 func (h *SSEHandler) handleSSE(w http.ResponseWriter, r *http.Request, endpoint string) {
 	// Verifica limite de conexoes
 	currentConns := atomic.LoadInt64(&h.connections)
-	if h.maxConns > 0 && currentConns >= h.maxConns {
+	if maxConns := atomic.LoadInt64(&h.maxConns); maxConns > 0 && currentConns >= maxConns {
 		http.Error(w, "Servidor sobrecarregado, tente novamente", http.StatusServiceUnavailable)
 		return
 	}
 
+	// Em drain (deploy) nao aceita novos streams
+	if h.drain.rejectNew(w) {
+		return
+	}
+
 	// Extrai filtros da query string
 	filtro := models.ParseFiltroFromRequest(r)
-
-	// Valida token (busca usuario pelo token)
-	authResult := services.ValidateToken(filtro.Token)
-
-	// Se token fornecido mas invalido, retorna 401
-	if filtro.Token != "" && !authResult.IsValid {
-		http.Error(w, "Token invalido", http.StatusUnauthorized)
+	if !parseProjecao(w, r, filtro) {
 		return
 	}
 
-	// Atualiza filtro com dados do usuario autenticado
-	filtro.IdUsuario = authResult.IdUsuario
-	filtro.IsAssinante = authResult.IsAssinante
+	// Valida token (ou escopo do parceiro) e aplica limites de conexao
+	releaseStream, ok := h.admitConnection(w, r, filtro)
+	if !ok {
+		return
+	}
+	defer releaseStream()
 
 	// Headers SSE
 	w.Header().Set("Content-Type", "text/event-stream")
@@ -153,11 +398,15 @@ func (h *SSEHandler) handleSSE(w http.ResponseWriter, r *http.Request, endpoint
 		return
 	}
 
+	// Compressao por frame (mantem a janela do deflate entre os updates)
+	w, flusher, closeCompression := h.compression.wrap(w, r, flusher)
+	defer closeCompression()
+
 	// Incrementa contador de conexoes (atomic)
 	connCount := atomic.AddInt64(&h.connections, 1)
 
-	// Log apenas a cada 100 conexoes para reduzir I/O
-	if connCount%100 == 0 || connCount <= 10 {
+	// Log conforme server.log_level (info: a cada 100 conexoes para reduzir I/O)
+	if logConexao(connCount) {
 		tipoUsuario := "free"
 		if filtro.IsAssinante {
 			tipoUsuario = "assinante"
@@ -168,7 +417,7 @@ func (h *SSEHandler) handleSSE(w http.ResponseWriter, r *http.Request, endpoint
 	// Decrementa ao fechar
 	defer func() {
 		newCount := atomic.AddInt64(&h.connections, -1)
-		if newCount%100 == 0 || newCount <= 10 {
+		if logConexao(newCount) {
 			log.Printf("SSE %s: Conexao fechada (user=%d) - Total: %d", endpoint, filtro.IdUsuario, newCount)
 		}
 	}()
@@ -178,25 +427,35 @@ func (h *SSEHandler) handleSSE(w http.ResponseWriter, r *http.Request, endpoint
 	flusher.Flush()
 
 	// Ticker diferenciado: 2s para assinantes, 10s para free/anonimo
-	tickerDuration := 10 * time.Second
-	if filtro.IsAssinante {
-		tickerDuration = 2 * time.Second
-	}
-	ticker := time.NewTicker(tickerDuration)
+	ticker := time.NewTicker(tickerDuration(filtro.IsAssinante))
 	defer ticker.Stop()
 
+	// Registra o stream para receber revogacao/mudanca de plano do usuario
+	session, unregister := h.sessions.register(filtro.IdUsuario, filtro.Token)
+	defer unregister()
+
 	// Canal para detectar quando cliente desconecta
 	ctx := r.Context()
 
-	// Obtem broadcaster para usar cache em memoria
-	broadcaster := services.GetBroadcaster()
+	// Broadcaster com o cache em memoria
+	broadcaster := h.broadcaster
 
 	// Obtem canal de reload atual
 	currentReloadChan := getReloadChan()
 
+	// Inscreve no drain do deploy
+	drainStream, unregisterDrain := h.drain.register()
+	defer unregisterDrain()
+
 	// Envia primeiro update imediatamente
 	h.sendUpdateCached(w, flusher, endpoint, filtro, broadcaster)
 
+	// Frescor dos dados: avisa na conexao se ja estiver atrasado e a cada mudanca
+	dados, dadosChan := broadcaster.DadosStatus()
+	if dados.Status != "ok" {
+		sendDadosStatus(w, flusher, dados)
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -207,7 +466,26 @@ func (h *SSEHandler) handleSSE(w http.ResponseWriter, r *http.Request, endpoint
 			fmt.Fprintf(w, "event: reload\ndata: {\"reason\": \"server_update\"}\n\n")
 			flusher.Flush()
 			return
+		case retry := <-drainStream.C:
+			// Deploy: reconecta com retry sorteado para espalhar as reconexoes
+			sendDrainReload(w, flusher, retry)
+			return
+		case auth := <-session.authChan:
+			if !auth.IsValid {
+				sendSessionRevoked(w, flusher)
+				return
+			}
+			// Plano mudou - reavalia tier no lugar (cadencia e campos liberados)
+			if auth.IsAssinante != filtro.IsAssinante {
+				filtro.IsAssinante = auth.IsAssinante
+				ticker.Reset(tickerDuration(filtro.IsAssinante))
+				h.sendUpdateCached(w, flusher, endpoint, filtro, broadcaster)
+			}
+		case <-dadosChan:
+			dados, dadosChan = broadcaster.DadosStatus()
+			sendDadosStatus(w, flusher, dados)
 		case <-ticker.C:
+			ticker.Reset(tickerDuration(filtro.IsAssinante)) // cadencia pode ter mudado no SIGHUP
 			// Envia update periodico usando cache em memoria
 			h.sendUpdateCached(w, flusher, endpoint, filtro, broadcaster)
 		}
@@ -233,7 +511,7 @@ func (h *SSEHandler) sendUpdateCached(w http.ResponseWriter, flusher http.Flushe
 		return
 	}
 
-	fmt.Fprintf(w, "event: update\ndata: %s\n\n", jsonData)
+	writeUpdate(w, jsonData, filtro.Encoding)
 	flusher.Flush()
 }
 
@@ -256,7 +534,7 @@ func (h *SSEHandler) sendUpdateFiltrado(w http.ResponseWriter, flusher http.Flus
 		return
 	}
 
-	fmt.Fprintf(w, "event: update\ndata: %s\n\n", jsonData)
+	writeUpdate(w, jsonData, filtro.Encoding)
 	flusher.Flush()
 }
 
@@ -287,11 +565,16 @@ func (h *SSEHandler) sendUpdate(w http.ResponseWriter, flusher http.Flusher, end
 func (h *SSEHandler) handleOraculo(w http.ResponseWriter, r *http.Request) {
 	// Verifica limite de conexoes
 	currentConns := atomic.LoadInt64(&h.connections)
-	if h.maxConns > 0 && currentConns >= h.maxConns {
+	if maxConns := atomic.LoadInt64(&h.maxConns); maxConns > 0 && currentConns >= maxConns {
 		http.Error(w, "Servidor sobrecarregado, tente novamente", http.StatusServiceUnavailable)
 		return
 	}
 
+	// Em drain (deploy) nao aceita novos streams
+	if h.drain.rejectNew(w) {
+		return
+	}
+
 	// Extrai idWilliamhill do path: /sse/oraculo/{idWilliamhill}
 	path := r.URL.Path
 	idWilliamhill := path[len("/sse/oraculo/"):]
@@ -303,19 +586,25 @@ func (h *SSEHandler) handleOraculo(w http.ResponseWriter, r *http.Request) {
 
 	// Extrai filtros da query string (para validacao de token)
 	filtro := models.ParseFiltroFromRequest(r)
+	if !parseEncoding(w, r, filtro) {
+		return
+	}
 
-	// Valida token (busca usuario pelo token)
-	authResult := services.ValidateToken(filtro.Token)
-
-	// Se token fornecido mas invalido, retorna 401
-	if filtro.Token != "" && !authResult.IsValid {
-		http.Error(w, "Token invalido", http.StatusUnauthorized)
+	// Valida token (ou escopo do parceiro) e aplica limites de conexao
+	releaseStream, ok := h.admitConnection(w, r, filtro)
+	if !ok {
 		return
 	}
+	defer releaseStream()
 
-	// Atualiza filtro com dados do usuario autenticado
-	filtro.IdUsuario = authResult.IdUsuario
-	filtro.IsAssinante = authResult.IsAssinante
+	// Parceiro com escopo de campeonatos: jogo precisa ser de campeonato liberado
+	if filtro.CampeonatosPermitidos != nil {
+		evento := h.broadcaster.FindEventoByIdWilliamhill(idWilliamhill)
+		if evento == nil || !filtro.CampeonatosPermitidos[evento.IdCampeonatoUnico] {
+			http.Error(w, "API key sem acesso a este jogo", http.StatusForbidden)
+			return
+		}
+	}
 
 	// Headers SSE
 	w.Header().Set("Content-Type", "text/event-stream")
@@ -330,9 +619,13 @@ func (h *SSEHandler) handleOraculo(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Compressao por frame (mantem a janela do deflate entre os updates)
+	w, flusher, closeCompression := h.compression.wrap(w, r, flusher)
+	defer closeCompression()
+
 	// Incrementa contador
 	connCount := atomic.AddInt64(&h.connections, 1)
-	if connCount%100 == 0 || connCount <= 10 {
+	if logConexao(connCount) {
 		tipoUsuario := "free"
 		if filtro.IsAssinante {
 			tipoUsuario = "assinante"
@@ -342,7 +635,7 @@ func (h *SSEHandler) handleOraculo(w http.ResponseWriter, r *http.Request) {
 
 	defer func() {
 		newCount := atomic.AddInt64(&h.connections, -1)
-		if newCount%100 == 0 || newCount <= 10 {
+		if logConexao(newCount) {
 			log.Printf("SSE oraculo: Conexao fechada (jogo=%s) - Total: %d", idWilliamhill, newCount)
 		}
 	}()
@@ -352,25 +645,39 @@ func (h *SSEHandler) handleOraculo(w http.ResponseWriter, r *http.Request) {
 	flusher.Flush()
 
 	// Ticker diferenciado: 2s para assinantes, 10s para free/anonimo
-	tickerDuration := 10 * time.Second
-	if filtro.IsAssinante {
-		tickerDuration = 2 * time.Second
-	}
-	ticker := time.NewTicker(tickerDuration)
+	ticker := time.NewTicker(tickerDuration(filtro.IsAssinante))
 	defer ticker.Stop()
 
+	// Registra o stream para receber revogacao/mudanca de plano do usuario
+	session, unregister := h.sessions.register(filtro.IdUsuario, filtro.Token)
+	defer unregister()
+
 	ctx := r.Context()
-	broadcaster := services.GetBroadcaster()
+	broadcaster := h.broadcaster
+
+	// Hub do jogo: uma unica busca no Redis por ciclo para todas as conexoes
+	watcher, unwatch := broadcaster.WatchOraculo(idWilliamhill)
+	defer unwatch()
 
 	// Obtem canal de reload atual
 	currentReloadChan := getReloadChan()
 
+	// Inscreve no drain do deploy
+	drainStream, unregisterDrain := h.drain.register()
+	defer unregisterDrain()
+
 	// Envia primeiro update imediatamente
-	finished := h.sendOraculoUpdateCached(w, flusher, idWilliamhill, broadcaster, filtro.IsAssinante)
+	finished := h.sendOraculoUpdateCached(w, flusher, idWilliamhill, broadcaster, filtro)
 	if finished {
 		return
 	}
 
+	// Frescor dos dados: avisa na conexao se ja estiver atrasado e a cada mudanca
+	dados, dadosChan := broadcaster.DadosStatus()
+	if dados.Status != "ok" {
+		sendDadosStatus(w, flusher, dados)
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -380,8 +687,36 @@ func (h *SSEHandler) handleOraculo(w http.ResponseWriter, r *http.Request) {
 			fmt.Fprintf(w, "event: reload\ndata: {\"reason\": \"server_update\"}\n\n")
 			flusher.Flush()
 			return
+		case retry := <-drainStream.C:
+			// Deploy: reconecta com retry sorteado para espalhar as reconexoes
+			sendDrainReload(w, flusher, retry)
+			return
+		case auth := <-session.authChan:
+			if !auth.IsValid {
+				sendSessionRevoked(w, flusher)
+				return
+			}
+			if auth.IsAssinante != filtro.IsAssinante {
+				filtro.IsAssinante = auth.IsAssinante
+				ticker.Reset(tickerDuration(filtro.IsAssinante))
+				if h.sendOraculoUpdateCached(w, flusher, idWilliamhill, broadcaster, filtro) {
+					return
+				}
+			}
+		case <-watcher.C:
+			// Dados novos: assinante recebe na hora, free segue na cadencia de 10s
+			if filtro.IsAssinante {
+				ticker.Reset(tickerDuration(filtro.IsAssinante))
+				if h.sendOraculoUpdateCached(w, flusher, idWilliamhill, broadcaster, filtro) {
+					return
+				}
+			}
+		case <-dadosChan:
+			dados, dadosChan = broadcaster.DadosStatus()
+			sendDadosStatus(w, flusher, dados)
 		case <-ticker.C:
-			finished := h.sendOraculoUpdateCached(w, flusher, idWilliamhill, broadcaster, filtro.IsAssinante)
+			ticker.Reset(tickerDuration(filtro.IsAssinante)) // cadencia pode ter mudado no SIGHUP
+			finished := h.sendOraculoUpdateCached(w, flusher, idWilliamhill, broadcaster, filtro)
 			if finished {
 				return
 			}
@@ -390,8 +725,8 @@ func (h *SSEHandler) handleOraculo(w http.ResponseWriter, r *http.Request) {
 }
 
 // sendOraculoUpdateCached envia update do oraculo usando cache e retorna true se jogo finalizou
-func (h *SSEHandler) sendOraculoUpdateCached(w http.ResponseWriter, flusher http.Flusher, idWilliamhill string, broadcaster *services.Broadcaster, isAssinante bool) bool {
-	data, err := broadcaster.GetOraculoCached(idWilliamhill)
+func (h *SSEHandler) sendOraculoUpdateCached(w http.ResponseWriter, flusher http.Flusher, idWilliamhill string, broadcaster *services.Broadcaster, filtro *models.Filtro) bool {
+	response, finished, err := montarOraculoResponse(idWilliamhill, broadcaster, filtro)
 	if err != nil {
 		log.Printf("SSE oraculo: Erro ao buscar dados (jogo=%s): %v", idWilliamhill, err)
 		fmt.Fprintf(w, "event: error\ndata: {\"error\": \"%s\"}\n\n", err.Error())
@@ -399,35 +734,23 @@ func (h *SSEHandler) sendOraculoUpdateCached(w http.ResponseWriter, flusher http
 		return false
 	}
 
-	if data == nil {
+	if response == nil {
 		// Jogo nao encontrado no cache
 		fmt.Fprintf(w, "event: error\ndata: {\"error\": \"Jogo nao encontrado no cache\"}\n\n")
 		flusher.Flush()
 		return false
 	}
 
-	// Se usuario free, filtra dados sensiveis
-	if !isAssinante {
-		data = services.FiltrarOraculoParaFree(data)
-	}
-
-	// Monta resposta no formato esperado pelo Oraculo.vue
-	response := map[string]interface{}{
-		"oraculo":   data,
-		"timestamp": time.Now().Unix(),
-	}
-
-	jsonData, err := json.Marshal(response)
+	jsonData, err := codec.Marshal(filtro.Encoding, response)
 	if err != nil {
 		log.Printf("SSE oraculo: Erro ao serializar (jogo=%s): %v", idWilliamhill, err)
 		return false
 	}
 
-	fmt.Fprintf(w, "event: update\ndata: %s\n\n", jsonData)
+	writeUpdate(w, jsonData, filtro.Encoding)
 	flusher.Flush()
 
-	// Verifica se jogo finalizou
-	if status, ok := data["status"].(string); ok && status == "finished" {
+	if finished {
 		fmt.Fprintf(w, "event: finished\ndata: {}\n\n")
 		flusher.Flush()
 		return true
@@ -436,6 +759,38 @@ func (h *SSEHandler) sendOraculoUpdateCached(w http.ResponseWriter, flusher http
 	return false
 }
 
+// montarOraculoResponse busca o oraculo no cache, aplica o tier e monta a resposta do Oraculo.vue
+// Retorna response nil se o jogo nao esta no cache; finished=true se o jogo terminou
+func montarOraculoResponse(idWilliamhill string, broadcaster *services.Broadcaster, filtro *models.Filtro) (map[string]interface{}, bool, error) {
+	data, err := broadcaster.GetOraculoCached(idWilliamhill)
+	if err != nil || data == nil {
+		return nil, false, err
+	}
+
+	// Mesmas regras de tier e escopo do parceiro do Evento
+	finished := data.Status == "finished"
+	data = filtrarOraculo(data, filtro)
+
+	// Monta resposta no formato esperado pelo Oraculo.vue
+	response := map[string]interface{}{
+		"oraculo":   data,
+		"timestamp": time.Now().Unix(),
+	}
+
+	return response, finished, nil
+}
+
+// filtrarOraculo aplica o tier (free/anonimo) e o escopo de odds do parceiro
+func filtrarOraculo(data *models.Oraculo, filtro *models.Filtro) *models.Oraculo {
+	if !filtro.IsAssinante {
+		data = data.FiltrarParaFree()
+	}
+	if filtro.OcultarOdds {
+		data = data.RemoverOdds()
+	}
+	return data
+}
+
 // sendOraculoUpdate envia update do oraculo e retorna true se jogo finalizou (fallback)
 func (h *SSEHandler) sendOraculoUpdate(w http.ResponseWriter, flusher http.Flusher, idWilliamhill string) bool {
 	data, err := services.GetOraculoCache(idWilliamhill)
@@ -469,7 +824,7 @@ func (h *SSEHandler) sendOraculoUpdate(w http.ResponseWriter, flusher http.Flush
 	flusher.Flush()
 
 	// Verifica se jogo finalizou
-	if status, ok := data["status"].(string); ok && status == "finished" {
+	if data.Status == "finished" {
 		fmt.Fprintf(w, "event: finished\ndata: {}\n\n")
 		flusher.Flush()
 		return true
@@ -0,0 +1,280 @@
+package handlers
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"radarfutebol-sse/internal/config"
+	"radarfutebol-sse/internal/models"
+	"radarfutebol-sse/internal/services"
+)
+
+const eventosTeste = `[
+	{"idEvento": 1, "idWilliamhill": "111", "status": "inprogress", "idCampeonatoUnico": "10", "descontoHt": 2},
+	{"idEvento": 2, "idWilliamhill": "222", "status": "notstarted", "idCampeonatoUnico": "20"}
+]`
+
+// ambienteTeste servidor HTTP com Broadcaster e autenticacao sobre stores em memoria
+type ambienteTeste struct {
+	srv      *httptest.Server
+	fonte    *services.FonteMemoria
+	auth     *services.AuthMemoria
+	prefs    *services.PrefsMemoria
+	oraculos *services.OraculoMemoria
+	apiKeys  *services.APIKeysMemoria
+	b        *services.Broadcaster
+}
+
+func novoAmbienteTeste(t *testing.T) *ambienteTeste {
+	t.Helper()
+	a := &ambienteTeste{
+		fonte:    services.NewFonteMemoria(eventosTeste),
+		auth:     services.NewAuthMemoria(),
+		prefs:    services.NewPrefsMemoria(),
+		oraculos: services.NewOraculoMemoria(),
+		apiKeys:  services.NewAPIKeysMemoria(),
+	}
+	a.auth.AddToken("tok-assinante", 10, 4)
+	a.auth.AddToken("tok-free", 20, 5)
+
+	b := services.NewBroadcaster(services.Stores{
+		Eventos:  []services.FonteEventos{a.fonte},
+		Prefs:    a.prefs,
+		Oraculos: a.oraculos,
+	})
+	a.b = b
+	b.Start()
+	t.Cleanup(b.Stop)
+	for deadline := time.Now().Add(2 * time.Second); b.Generation() == 0; time.Sleep(10 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("Broadcaster nao carregou o primeiro snapshot")
+		}
+	}
+
+	cfg := config.Defaults()
+	cfg.RateLimit.Enabled = false
+	cfg.Stream.TickerAssinante = 200 * time.Millisecond
+	h := NewSSEHandlerWith(cfg, b, services.NewAutenticador(a.auth), a.apiKeys)
+	t.Cleanup(func() { setCadencia(config.Defaults().Stream) })
+
+	mux := http.NewServeMux()
+	h.RegisterRoutes(mux)
+	a.srv = httptest.NewServer(mux)
+	t.Cleanup(a.srv.Close)
+	return a
+}
+
+// frameTeste evento SSE lido do stream
+type frameTeste struct {
+	event string
+	data  string
+}
+
+// abrirStream faz o GET e entrega os frames com data num canal (fechado no fim do stream)
+func (a *ambienteTeste) abrirStream(t *testing.T, path, token string) (*http.Response, <-chan frameTeste) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, a.srv.URL+path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Accept-Encoding", "identity")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+
+	frames := make(chan frameTeste, 16)
+	if resp.StatusCode != http.StatusOK {
+		close(frames)
+		return resp, frames
+	}
+	go func() {
+		defer close(frames)
+		scanner := bufio.NewScanner(resp.Body)
+		scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+		var atual frameTeste
+		for scanner.Scan() {
+			linha := scanner.Text()
+			switch {
+			case linha == "":
+				if atual.data != "" {
+					frames <- atual
+				}
+				atual = frameTeste{}
+			case strings.HasPrefix(linha, "event: "):
+				atual.event = strings.TrimPrefix(linha, "event: ")
+			case strings.HasPrefix(linha, "data: "):
+				atual.data += strings.TrimPrefix(linha, "data: ")
+			}
+		}
+	}()
+	return resp, frames
+}
+
+// get faz uma requisicao simples (snapshots REST) e retorna a resposta com o corpo lido
+func (a *ambienteTeste) get(t *testing.T, path string, headers map[string]string) (*http.Response, string) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, a.srv.URL+path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	return resp, string(body)
+}
+
+// proximoFrame aguarda o proximo frame do tipo informado (outros sao ignorados)
+func proximoFrame(t *testing.T, frames <-chan frameTeste, event string) frameTeste {
+	t.Helper()
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case f, ok := <-frames:
+			if !ok {
+				t.Fatalf("Stream encerrado antes de event: %s", event)
+			}
+			if f.event == event {
+				return f
+			}
+		case <-timeout:
+			t.Fatalf("Timeout aguardando event: %s", event)
+		}
+	}
+}
+
+func decodePainel(t *testing.T, f frameTeste) models.PainelResponse {
+	t.Helper()
+	var painel models.PainelResponse
+	if err := json.Unmarshal([]byte(f.data), &painel); err != nil {
+		t.Fatalf("Frame do painel invalido: %v (%s)", err, f.data)
+	}
+	return painel
+}
+
+func TestSSE_PainelPrimeiroFrameEAtualizacao(t *testing.T) {
+	a := novoAmbienteTeste(t)
+
+	resp, frames := a.abrirStream(t, "/sse/painel", "tok-assinante")
+	if resp.StatusCode != http.StatusOK || !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+		t.Fatalf("status=%d content-type=%q", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+
+	painel := decodePainel(t, proximoFrame(t, frames, "update"))
+	if len(painel.Eventos) != 2 || painel.Counts.Live != 1 || painel.Counts.Total != 2 {
+		t.Fatalf("Primeiro update: %d eventos, counts %+v", len(painel.Eventos), painel.Counts)
+	}
+
+	// Fonte muda: o proximo refresh do Broadcaster chega no stream
+	a.fonte.Set(`[{"idEvento": 3, "idWilliamhill": "333", "status": "inprogress"}]`)
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		painel = decodePainel(t, proximoFrame(t, frames, "update"))
+		if len(painel.Eventos) == 1 && painel.Eventos[0].IdEvento == 3 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Stream nao recebeu os eventos atualizados")
+		}
+	}
+}
+
+func TestSSE_FavoritosDoUsuario(t *testing.T) {
+	a := novoAmbienteTeste(t)
+	a.prefs.SetFavoritos(10, nil, []string{"2"})
+
+	_, frames := a.abrirStream(t, "/sse/painel?mostrarApenasJogosFavoritos=true", "tok-assinante")
+	painel := decodePainel(t, proximoFrame(t, frames, "update"))
+	if len(painel.Eventos) != 1 || painel.Eventos[0].IdEvento != 2 {
+		t.Fatalf("Esperava apenas o jogo favorito 2, obteve %d eventos", len(painel.Eventos))
+	}
+
+	// Favoritos sao por usuario: o free nao tem nenhum
+	_, frames = a.abrirStream(t, "/sse/painel?mostrarApenasJogosFavoritos=true", "tok-free")
+	if painel := decodePainel(t, proximoFrame(t, frames, "update")); len(painel.Eventos) != 0 {
+		t.Fatalf("Usuario sem favoritos recebeu %d eventos", len(painel.Eventos))
+	}
+}
+
+func TestSSE_Autenticacao(t *testing.T) {
+	a := novoAmbienteTeste(t)
+
+	if resp, _ := a.abrirStream(t, "/sse/home", "tok-inexistente"); resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("Token invalido: status %d, esperava 401", resp.StatusCode)
+	}
+
+	// MySQL fora sem fallback anonimo: 503 com Retry-After
+	a.auth.SetIndisponivel(true)
+	resp, _ := a.abrirStream(t, "/sse/home", "tok-novo")
+	if resp.StatusCode != http.StatusServiceUnavailable || resp.Header.Get("Retry-After") == "" {
+		t.Fatalf("Auth indisponivel: status %d, Retry-After %q", resp.StatusCode, resp.Header.Get("Retry-After"))
+	}
+
+	// Token ja validado segue pelo cache
+	a.auth.SetIndisponivel(false)
+	a.abrirStream(t, "/sse/home", "tok-free")
+	a.auth.SetIndisponivel(true)
+	if resp, _ := a.abrirStream(t, "/sse/home", "tok-free"); resp.StatusCode != http.StatusOK {
+		t.Fatalf("Token em cache com MySQL fora: status %d, esperava 200", resp.StatusCode)
+	}
+}
+
+func TestSSE_OraculoComMergeDoEvento(t *testing.T) {
+	a := novoAmbienteTeste(t)
+	a.oraculos.SetOraculo("111", &models.Oraculo{Evento: models.Evento{IdWilliamhill: "111", Status: "notstarted"}})
+
+	_, frames := a.abrirStream(t, "/sse/oraculo/111", "tok-assinante")
+	var resposta struct {
+		Oraculo models.Evento `json:"oraculo"`
+	}
+	f := proximoFrame(t, frames, "update")
+	if err := json.Unmarshal([]byte(f.data), &resposta); err != nil {
+		t.Fatalf("Frame do oraculo invalido: %v (%s)", err, f.data)
+	}
+	if resposta.Oraculo.Status != "inprogress" || resposta.Oraculo.Acrescimo1Tempo != "2" {
+		t.Fatalf("Oraculo sem o merge do evento: status=%q acrescimo=%q", resposta.Oraculo.Status, resposta.Oraculo.Acrescimo1Tempo)
+	}
+
+	// Jogo sem oraculo no cache
+	_, frames = a.abrirStream(t, "/sse/oraculo/999", "tok-assinante")
+	if f := proximoFrame(t, frames, "error"); !strings.Contains(f.data, "nao encontrado") {
+		t.Fatalf("Esperava erro de jogo nao encontrado: %s", f.data)
+	}
+}
+
+func TestSSE_ReadyzPeloSnapshot(t *testing.T) {
+	a := novoAmbienteTeste(t)
+
+	// Redis fora (nao inicializado), mas o snapshot veio de outra fonte e esta atualizado
+	resp, err := http.Get(a.srv.URL + "/readyz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var corpo struct {
+		Checks map[string]string `json:"checks"`
+	}
+	json.NewDecoder(resp.Body).Decode(&corpo)
+	if resp.StatusCode != http.StatusOK || corpo.Checks["snapshot"] != "ok" {
+		t.Fatalf("Esperava pronto pelo snapshot: status %d, checks %v", resp.StatusCode, corpo.Checks)
+	}
+	if corpo.Checks["redis"] == "ok" {
+		t.Errorf("Redis nao inicializado deveria aparecer no check: %v", corpo.Checks)
+	}
+}
@@ -0,0 +1,202 @@
+// Package handoff permite reiniciar o servidor sem fechar a porta
+// O socket de escuta pode vir do systemd (socket activation) ou do processo
+// anterior (upgrade por SIGUSR2: o processo novo herda o fd, avisa quando esta
+// pronto e so entao o antigo para de aceitar conexoes e faz o drain)
+package handoff
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"os/exec"
+	"strconv"
+	"time"
+)
+
+// Variaveis de ambiente passadas ao processo novo no upgrade
+const (
+	envListenFD = "SSE_LISTEN_FD" // fd do socket herdado
+	envReadyFD  = "SSE_READY_FD"  // fd do pipe de prontidao (escreve ao ficar pronto)
+)
+
+// Primeiro fd passado pelo systemd (SD_LISTEN_FDS_START)
+const systemdListenFDStart = 3
+
+// Listen retorna o socket de escuta: herdado do processo anterior, do systemd
+// (sse-go.socket) ou aberto agora em addr
+func Listen(addr string) (net.Listener, error) {
+	if fd, ok := inheritedFD(); ok {
+		l, err := fileListener(fd, "handoff")
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("Handoff: socket herdado do processo anterior (%s)", l.Addr())
+		return l, nil
+	}
+
+	if systemdActivated() {
+		l, err := fileListener(systemdListenFDStart, "systemd")
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("Handoff: socket recebido do systemd (%s)", l.Addr())
+		return l, nil
+	}
+
+	return net.Listen("tcp", addr)
+}
+
+// inheritedFD fd do socket herdado no upgrade (env SSE_LISTEN_FD)
+func inheritedFD() (int, bool) {
+	value := os.Getenv(envListenFD)
+	if value == "" {
+		return 0, false
+	}
+	os.Unsetenv(envListenFD)
+	fd, err := strconv.Atoi(value)
+	if err != nil || fd < systemdListenFDStart {
+		log.Printf("Handoff: %s invalido (%q), ignorando", envListenFD, value)
+		return 0, false
+	}
+	return fd, true
+}
+
+// systemdActivated indica se o systemd passou o socket (LISTEN_PID/LISTEN_FDS)
+// As variaveis sao removidas para nao vazarem para processos filhos
+func systemdActivated() bool {
+	pid, _ := strconv.Atoi(os.Getenv("LISTEN_PID"))
+	fds, _ := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+	os.Unsetenv("LISTEN_PID")
+	os.Unsetenv("LISTEN_FDS")
+	os.Unsetenv("LISTEN_FDNAMES")
+
+	if pid != os.Getpid() || fds < 1 {
+		return false
+	}
+	if fds > 1 {
+		log.Printf("Handoff: systemd passou %d sockets, usando apenas o primeiro", fds)
+	}
+	return true
+}
+
+// fileListener cria o listener a partir do fd (o fd original e fechado; o listener tem sua copia)
+func fileListener(fd int, origem string) (net.Listener, error) {
+	file := os.NewFile(uintptr(fd), origem)
+	if file == nil {
+		return nil, fmt.Errorf("handoff: fd %d (%s) invalido", fd, origem)
+	}
+	defer file.Close()
+
+	l, err := net.FileListener(file)
+	if err != nil {
+		return nil, fmt.Errorf("handoff: fd %d (%s) nao e um socket de escuta: %w", fd, origem, err)
+	}
+	return l, nil
+}
+
+// Upgrade inicia o binario atual (ja substituido no deploy) herdando o socket
+// Retorna apos o processo novo avisar que esta pronto; se nao ficar pronto dentro
+// do timeout ele e encerrado e o processo atual continua atendendo normalmente
+func Upgrade(l net.Listener, timeout time.Duration) (*os.Process, error) {
+	tcp, ok := l.(*net.TCPListener)
+	if !ok {
+		return nil, fmt.Errorf("handoff: listener %T nao suporta upgrade", l)
+	}
+	listenFile, err := tcp.File()
+	if err != nil {
+		return nil, fmt.Errorf("handoff: erro ao duplicar socket: %w", err)
+	}
+	defer listenFile.Close()
+
+	readyR, readyW, err := os.Pipe()
+	if err != nil {
+		return nil, fmt.Errorf("handoff: erro ao criar pipe de prontidao: %w", err)
+	}
+	defer readyR.Close()
+
+	executable, err := os.Executable()
+	if err != nil {
+		readyW.Close()
+		return nil, fmt.Errorf("handoff: binario atual nao encontrado: %w", err)
+	}
+
+	// ExtraFiles[i] vira o fd 3+i no processo novo
+	cmd := exec.Command(executable, os.Args[1:]...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.ExtraFiles = []*os.File{listenFile, readyW}
+	cmd.Env = append(os.Environ(),
+		fmt.Sprintf("%s=%d", envListenFD, systemdListenFDStart),
+		fmt.Sprintf("%s=%d", envReadyFD, systemdListenFDStart+1),
+	)
+
+	err = cmd.Start()
+	readyW.Close() // so o processo novo fica com a ponta de escrita: EOF se ele morrer
+	if err != nil {
+		return nil, fmt.Errorf("handoff: erro ao iniciar processo novo: %w", err)
+	}
+	log.Printf("Handoff: processo novo iniciado (pid %d), aguardando prontidao...", cmd.Process.Pid)
+
+	// Coleta o status de saida para nao deixar zumbi (o processo novo sobrevive ao atual)
+	go cmd.Wait()
+
+	readyR.SetReadDeadline(time.Now().Add(timeout))
+	buf := make([]byte, 1)
+	if _, err := readyR.Read(buf); err != nil {
+		cmd.Process.Kill()
+		if errors.Is(err, os.ErrDeadlineExceeded) {
+			return nil, fmt.Errorf("handoff: processo novo nao ficou pronto em %v", timeout)
+		}
+		return nil, fmt.Errorf("handoff: processo novo encerrou antes de ficar pronto: %w", err)
+	}
+
+	return cmd.Process, nil
+}
+
+// Upgrading indica se o processo foi iniciado por um upgrade (o anterior aguarda o Ready)
+func Upgrading() bool {
+	return os.Getenv(envReadyFD) != ""
+}
+
+// Ready avisa que o processo esta pronto para atender: ao processo anterior
+// (se veio de um upgrade) e ao systemd (Type=notify; MAINPID passa a ser este processo)
+func Ready() {
+	if value := os.Getenv(envReadyFD); value != "" {
+		os.Unsetenv(envReadyFD)
+		if fd, err := strconv.Atoi(value); err == nil {
+			pipe := os.NewFile(uintptr(fd), "handoff-ready")
+			if _, err := pipe.Write([]byte{1}); err != nil {
+				log.Printf("Handoff: erro ao avisar processo anterior: %v", err)
+			}
+			pipe.Close()
+		}
+	}
+
+	if err := notifySystemd(fmt.Sprintf("READY=1\nMAINPID=%d", os.Getpid())); err != nil {
+		log.Printf("Handoff: erro ao notificar systemd: %v", err)
+	}
+}
+
+// Stopping avisa o systemd que o processo esta encerrando
+func Stopping() {
+	notifySystemd("STOPPING=1")
+}
+
+// notifySystemd envia o estado para o NOTIFY_SOCKET (sd_notify); sem systemd nao faz nada
+func notifySystemd(state string) error {
+	socket := os.Getenv("NOTIFY_SOCKET")
+	if socket == "" {
+		return nil
+	}
+
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: socket, Net: "unixgram"})
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = conn.Write([]byte(state))
+	return err
+}
@@ -0,0 +1,78 @@
+package handoff
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestMain no processo novo do upgrade (mesmo binario de teste): herda o socket e avisa que esta pronto
+func TestMain(m *testing.M) {
+	if addr := os.Getenv("HANDOFF_TEST_CHILD"); addr != "" {
+		l, err := Listen("127.0.0.1:0")
+		if err != nil || l.Addr().String() != addr {
+			fmt.Fprintf(os.Stderr, "socket nao herdado: %v %v\n", l, err)
+			os.Exit(1)
+		}
+		if os.Getenv("HANDOFF_TEST_NAO_PRONTO") == "" {
+			Ready()
+		}
+		time.Sleep(5 * time.Second)
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+func TestUpgrade_ProcessoNovoHerdaSocket(t *testing.T) {
+	l, err := Listen("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	t.Setenv("HANDOFF_TEST_CHILD", l.Addr().String())
+	process, err := Upgrade(l, 10*time.Second)
+	if err != nil {
+		t.Fatalf("Upgrade falhou: %v", err)
+	}
+	process.Kill()
+}
+
+func TestUpgrade_AbortaSemProntidao(t *testing.T) {
+	l, err := Listen("127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	t.Setenv("HANDOFF_TEST_CHILD", l.Addr().String())
+	t.Setenv("HANDOFF_TEST_NAO_PRONTO", "1")
+	if _, err := Upgrade(l, 200*time.Millisecond); err == nil || !strings.Contains(err.Error(), "nao ficou pronto") {
+		t.Fatalf("Upgrade deveria abortar por timeout, obteve %v", err)
+	}
+}
+
+func TestNotifySystemd(t *testing.T) {
+	socket := t.TempDir() + "/notify.sock"
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socket, Net: "unixgram"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	t.Setenv("NOTIFY_SOCKET", socket)
+	Ready()
+
+	buf := make([]byte, 128)
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	n, err := conn.Read(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := fmt.Sprintf("READY=1\nMAINPID=%d", os.Getpid()); string(buf[:n]) != want {
+		t.Errorf("Estado inesperado: %q", buf[:n])
+	}
+}
@@ -1,5 +1,7 @@
 package models
 
+import "reflect"
+
 // Evento representa um jogo/evento de futebol
 // Nota: Campos de estatísticas usam FlexValue pois PHP envia como string ou number
 type Evento struct {
@@ -84,206 +86,206 @@ type Evento struct {
 	LinkOrbit          string `json:"linkOrbit"`
 
 	// Estatisticas Time Casa (do Redis/oraculo)
-	PosseBolaTimeCasa           FlexValue `json:"posseBolaTimeCasa"`
-	ChutesGolTimeCasa           FlexValue `json:"chutesGolTimeCasa"`
-	ChutesForaTimeCasa          FlexValue `json:"chutesForaTimeCasa"`
-	ChutesTraveTimeCasa         FlexValue `json:"chutesTraveTimeCasa"`
-	ChutesBloqueadoTimeCasa     FlexValue `json:"chutesBloqueadoTimeCasa"`
-	EscanteiosTimeCasa          FlexValue `json:"escanteiosTimeCasa"`
-	AtaquesPerigososTimeCasa    FlexValue `json:"ataquesPerigososTimeCasa"`
-	PenalidadesTimeCasa         FlexValue `json:"penalidadesTimeCasa"`
-	ProbabilidadesTimeCasa      FlexValue `json:"probabilidadesTimeCasa"`
-	Pontos10MinTimeCasa         FlexValue `json:"pontos10MinTimeCasa"`
+	PosseBolaTimeCasa           FlexValue `json:"posseBolaTimeCasa" tier:"assinante"`
+	ChutesGolTimeCasa           FlexValue `json:"chutesGolTimeCasa" tier:"assinante"`
+	ChutesForaTimeCasa          FlexValue `json:"chutesForaTimeCasa" tier:"assinante"`
+	ChutesTraveTimeCasa         FlexValue `json:"chutesTraveTimeCasa" tier:"assinante"`
+	ChutesBloqueadoTimeCasa     FlexValue `json:"chutesBloqueadoTimeCasa" tier:"assinante"`
+	EscanteiosTimeCasa          FlexValue `json:"escanteiosTimeCasa" tier:"assinante"`
+	AtaquesPerigososTimeCasa    FlexValue `json:"ataquesPerigososTimeCasa" tier:"assinante"`
+	PenalidadesTimeCasa         FlexValue `json:"penalidadesTimeCasa" tier:"assinante"`
+	ProbabilidadesTimeCasa      FlexValue `json:"probabilidadesTimeCasa" tier:"assinante"`
+	Pontos10MinTimeCasa         FlexValue `json:"pontos10MinTimeCasa" tier:"assinante"`
 
 	// Estatisticas 1 Tempo Casa
-	ChutesGolTimeCasa1Tempo        FlexValue `json:"chutesGolTimeCasa1Tempo"`
-	ChutesForaTimeCasa1Tempo       FlexValue `json:"chutesForaTimeCasa1Tempo"`
-	ChutesTraveTimeCasa1Tempo      FlexValue `json:"chutesTraveTimeCasa1Tempo"`
-	ChutesBloqueadoTimeCasa1Tempo  FlexValue `json:"chutesBloqueadoTimeCasa1Tempo"`
-	EscanteiosTimeCasa1Tempo       FlexValue `json:"escanteiosTimeCasa1Tempo"`
-	AtaquesPerigososTimeCasa1Tempo FlexValue `json:"ataquesPerigososTimeCasa1Tempo"`
-	PenalidadesTimeCasa1Tempo      FlexValue `json:"penalidadesTimeCasa1Tempo"`
+	ChutesGolTimeCasa1Tempo        FlexValue `json:"chutesGolTimeCasa1Tempo" tier:"assinante"`
+	ChutesForaTimeCasa1Tempo       FlexValue `json:"chutesForaTimeCasa1Tempo" tier:"assinante"`
+	ChutesTraveTimeCasa1Tempo      FlexValue `json:"chutesTraveTimeCasa1Tempo" tier:"assinante"`
+	ChutesBloqueadoTimeCasa1Tempo  FlexValue `json:"chutesBloqueadoTimeCasa1Tempo" tier:"assinante"`
+	EscanteiosTimeCasa1Tempo       FlexValue `json:"escanteiosTimeCasa1Tempo" tier:"assinante"`
+	AtaquesPerigososTimeCasa1Tempo FlexValue `json:"ataquesPerigososTimeCasa1Tempo" tier:"assinante"`
+	PenalidadesTimeCasa1Tempo      FlexValue `json:"penalidadesTimeCasa1Tempo" tier:"assinante"`
 
 	// Estatisticas 2 Tempo Casa
-	ChutesGolTimeCasa2Tempo        FlexValue `json:"chutesGolTimeCasa2Tempo"`
-	ChutesForaTimeCasa2Tempo       FlexValue `json:"chutesForaTimeCasa2Tempo"`
-	ChutesTraveTimeCasa2Tempo      FlexValue `json:"chutesTraveTimeCasa2Tempo"`
-	ChutesBloqueadoTimeCasa2Tempo  FlexValue `json:"chutesBloqueadoTimeCasa2Tempo"`
-	EscanteiosTimeCasa2Tempo       FlexValue `json:"escanteiosTimeCasa2Tempo"`
-	AtaquesPerigososTimeCasa2Tempo FlexValue `json:"ataquesPerigososTimeCasa2Tempo"`
-	PenalidadesTimeCasa2Tempo      FlexValue `json:"penalidadesTimeCasa2Tempo"`
+	ChutesGolTimeCasa2Tempo        FlexValue `json:"chutesGolTimeCasa2Tempo" tier:"assinante"`
+	ChutesForaTimeCasa2Tempo       FlexValue `json:"chutesForaTimeCasa2Tempo" tier:"assinante"`
+	ChutesTraveTimeCasa2Tempo      FlexValue `json:"chutesTraveTimeCasa2Tempo" tier:"assinante"`
+	ChutesBloqueadoTimeCasa2Tempo  FlexValue `json:"chutesBloqueadoTimeCasa2Tempo" tier:"assinante"`
+	EscanteiosTimeCasa2Tempo       FlexValue `json:"escanteiosTimeCasa2Tempo" tier:"assinante"`
+	AtaquesPerigososTimeCasa2Tempo FlexValue `json:"ataquesPerigososTimeCasa2Tempo" tier:"assinante"`
+	PenalidadesTimeCasa2Tempo      FlexValue `json:"penalidadesTimeCasa2Tempo" tier:"assinante"`
 
 	// Estatisticas 5 Min Casa
-	ChutesGolTimeCasa5Min        FlexValue `json:"chutesGolTimeCasa5Min"`
-	ChutesForaTimeCasa5Min       FlexValue `json:"chutesForaTimeCasa5Min"`
-	ChutesTraveTimeCasa5Min      FlexValue `json:"chutesTraveTimeCasa5Min"`
-	ChutesBloqueadoTimeCasa5Min  FlexValue `json:"chutesBloqueadoTimeCasa5Min"`
-	EscanteiosTimeCasa5Min       FlexValue `json:"escanteiosTimeCasa5Min"`
-	AtaquesPerigososTimeCasa5Min FlexValue `json:"ataquesPerigososTimeCasa5Min"`
-	PenalidadesTimeCasa5Min      FlexValue `json:"penalidadesTimeCasa5Min"`
+	ChutesGolTimeCasa5Min        FlexValue `json:"chutesGolTimeCasa5Min" tier:"assinante"`
+	ChutesForaTimeCasa5Min       FlexValue `json:"chutesForaTimeCasa5Min" tier:"assinante"`
+	ChutesTraveTimeCasa5Min      FlexValue `json:"chutesTraveTimeCasa5Min" tier:"assinante"`
+	ChutesBloqueadoTimeCasa5Min  FlexValue `json:"chutesBloqueadoTimeCasa5Min" tier:"assinante"`
+	EscanteiosTimeCasa5Min       FlexValue `json:"escanteiosTimeCasa5Min" tier:"assinante"`
+	AtaquesPerigososTimeCasa5Min FlexValue `json:"ataquesPerigososTimeCasa5Min" tier:"assinante"`
+	PenalidadesTimeCasa5Min      FlexValue `json:"penalidadesTimeCasa5Min" tier:"assinante"`
 
 	// Estatisticas 10 Min Casa
-	ChutesGolTimeCasa10Min        FlexValue `json:"chutesGolTimeCasa10Min"`
-	ChutesForaTimeCasa10Min       FlexValue `json:"chutesForaTimeCasa10Min"`
-	ChutesTraveTimeCasa10Min      FlexValue `json:"chutesTraveTimeCasa10Min"`
-	ChutesBloqueadoTimeCasa10Min  FlexValue `json:"chutesBloqueadoTimeCasa10Min"`
-	EscanteiosTimeCasa10Min       FlexValue `json:"escanteiosTimeCasa10Min"`
-	AtaquesPerigososTimeCasa10Min FlexValue `json:"ataquesPerigososTimeCasa10Min"`
-	PenalidadesTimeCasa10Min      FlexValue `json:"penalidadesTimeCasa10Min"`
+	ChutesGolTimeCasa10Min        FlexValue `json:"chutesGolTimeCasa10Min" tier:"assinante"`
+	ChutesForaTimeCasa10Min       FlexValue `json:"chutesForaTimeCasa10Min" tier:"assinante"`
+	ChutesTraveTimeCasa10Min      FlexValue `json:"chutesTraveTimeCasa10Min" tier:"assinante"`
+	ChutesBloqueadoTimeCasa10Min  FlexValue `json:"chutesBloqueadoTimeCasa10Min" tier:"assinante"`
+	EscanteiosTimeCasa10Min       FlexValue `json:"escanteiosTimeCasa10Min" tier:"assinante"`
+	AtaquesPerigososTimeCasa10Min FlexValue `json:"ataquesPerigososTimeCasa10Min" tier:"assinante"`
+	PenalidadesTimeCasa10Min      FlexValue `json:"penalidadesTimeCasa10Min" tier:"assinante"`
 
 	// Classes CSS Time Casa
-	ClassPosseBolaTimeCasa           string `json:"classPosseBolaTimeCasa"`
-	ClassChutesGolTimeCasa           string `json:"classChutesGolTimeCasa"`
-	ClassChutesForaTimeCasa          string `json:"classChutesForaTimeCasa"`
-	ClassChutesTraveTimeCasa         string `json:"classChutesTraveTimeCasa"`
-	ClassChutesBloqueadoTimeCasa     string `json:"classChutesBloqueadoTimeCasa"`
-	ClassEscanteiosTimeCasa          string `json:"classEscanteiosTimeCasa"`
-	ClassAtaquesPerigososTimeCasa    string `json:"classAtaquesPerigososTimeCasa"`
-	ClassPenalidadesTimeCasa         string `json:"classPenalidadesTimeCasa"`
-	ClassProbabilidadesTimeCasa      string `json:"classProbabilidadesTimeCasa"`
-	ClassPontos10MinTimeCasa         string `json:"classPontos10MinTimeCasa"`
+	ClassPosseBolaTimeCasa           string `json:"classPosseBolaTimeCasa" tier:"assinante"`
+	ClassChutesGolTimeCasa           string `json:"classChutesGolTimeCasa" tier:"assinante"`
+	ClassChutesForaTimeCasa          string `json:"classChutesForaTimeCasa" tier:"assinante"`
+	ClassChutesTraveTimeCasa         string `json:"classChutesTraveTimeCasa" tier:"assinante"`
+	ClassChutesBloqueadoTimeCasa     string `json:"classChutesBloqueadoTimeCasa" tier:"assinante"`
+	ClassEscanteiosTimeCasa          string `json:"classEscanteiosTimeCasa" tier:"assinante"`
+	ClassAtaquesPerigososTimeCasa    string `json:"classAtaquesPerigososTimeCasa" tier:"assinante"`
+	ClassPenalidadesTimeCasa         string `json:"classPenalidadesTimeCasa" tier:"assinante"`
+	ClassProbabilidadesTimeCasa      string `json:"classProbabilidadesTimeCasa" tier:"assinante"`
+	ClassPontos10MinTimeCasa         string `json:"classPontos10MinTimeCasa" tier:"assinante"`
 
 	// Classes CSS 1 Tempo Casa
-	ClassChutesGolTimeCasa1Tempo        string `json:"classChutesGolTimeCasa1Tempo"`
-	ClassChutesForaTimeCasa1Tempo       string `json:"classChutesForaTimeCasa1Tempo"`
-	ClassChutesTraveTimeCasa1Tempo      string `json:"classChutesTraveTimeCasa1Tempo"`
-	ClassChutesBloqueadoTimeCasa1Tempo  string `json:"classChutesBloqueadoTimeCasa1Tempo"`
-	ClassEscanteiosTimeCasa1Tempo       string `json:"classEscanteiosTimeCasa1Tempo"`
-	ClassAtaquesPerigososTimeCasa1Tempo string `json:"classAtaquesPerigososTimeCasa1Tempo"`
-	ClassPenalidadesTimeCasa1Tempo      string `json:"classPenalidadesTimeCasa1Tempo"`
+	ClassChutesGolTimeCasa1Tempo        string `json:"classChutesGolTimeCasa1Tempo" tier:"assinante"`
+	ClassChutesForaTimeCasa1Tempo       string `json:"classChutesForaTimeCasa1Tempo" tier:"assinante"`
+	ClassChutesTraveTimeCasa1Tempo      string `json:"classChutesTraveTimeCasa1Tempo" tier:"assinante"`
+	ClassChutesBloqueadoTimeCasa1Tempo  string `json:"classChutesBloqueadoTimeCasa1Tempo" tier:"assinante"`
+	ClassEscanteiosTimeCasa1Tempo       string `json:"classEscanteiosTimeCasa1Tempo" tier:"assinante"`
+	ClassAtaquesPerigososTimeCasa1Tempo string `json:"classAtaquesPerigososTimeCasa1Tempo" tier:"assinante"`
+	ClassPenalidadesTimeCasa1Tempo      string `json:"classPenalidadesTimeCasa1Tempo" tier:"assinante"`
 
 	// Classes CSS 2 Tempo Casa
-	ClassChutesGolTimeCasa2Tempo        string `json:"classChutesGolTimeCasa2Tempo"`
-	ClassChutesForaTimeCasa2Tempo       string `json:"classChutesForaTimeCasa2Tempo"`
-	ClassChutesTraveTimeCasa2Tempo      string `json:"classChutesTraveTimeCasa2Tempo"`
-	ClassChutesBloqueadoTimeCasa2Tempo  string `json:"classChutesBloqueadoTimeCasa2Tempo"`
-	ClassEscanteiosTimeCasa2Tempo       string `json:"classEscanteiosTimeCasa2Tempo"`
-	ClassAtaquesPerigososTimeCasa2Tempo string `json:"classAtaquesPerigososTimeCasa2Tempo"`
-	ClassPenalidadesTimeCasa2Tempo      string `json:"classPenalidadesTimeCasa2Tempo"`
+	ClassChutesGolTimeCasa2Tempo        string `json:"classChutesGolTimeCasa2Tempo" tier:"assinante"`
+	ClassChutesForaTimeCasa2Tempo       string `json:"classChutesForaTimeCasa2Tempo" tier:"assinante"`
+	ClassChutesTraveTimeCasa2Tempo      string `json:"classChutesTraveTimeCasa2Tempo" tier:"assinante"`
+	ClassChutesBloqueadoTimeCasa2Tempo  string `json:"classChutesBloqueadoTimeCasa2Tempo" tier:"assinante"`
+	ClassEscanteiosTimeCasa2Tempo       string `json:"classEscanteiosTimeCasa2Tempo" tier:"assinante"`
+	ClassAtaquesPerigososTimeCasa2Tempo string `json:"classAtaquesPerigososTimeCasa2Tempo" tier:"assinante"`
+	ClassPenalidadesTimeCasa2Tempo      string `json:"classPenalidadesTimeCasa2Tempo" tier:"assinante"`
 
 	// Classes CSS 5 Min Casa
-	ClassChutesGolTimeCasa5Min        string `json:"classChutesGolTimeCasa5Min"`
-	ClassChutesForaTimeCasa5Min       string `json:"classChutesForaTimeCasa5Min"`
-	ClassChutesTraveTimeCasa5Min      string `json:"classChutesTraveTimeCasa5Min"`
-	ClassChutesBloqueadoTimeCasa5Min  string `json:"classChutesBloqueadoTimeCasa5Min"`
-	ClassEscanteiosTimeCasa5Min       string `json:"classEscanteiosTimeCasa5Min"`
-	ClassAtaquesPerigososTimeCasa5Min string `json:"classAtaquesPerigososTimeCasa5Min"`
-	ClassPenalidadesTimeCasa5Min      string `json:"classPenalidadesTimeCasa5Min"`
+	ClassChutesGolTimeCasa5Min        string `json:"classChutesGolTimeCasa5Min" tier:"assinante"`
+	ClassChutesForaTimeCasa5Min       string `json:"classChutesForaTimeCasa5Min" tier:"assinante"`
+	ClassChutesTraveTimeCasa5Min      string `json:"classChutesTraveTimeCasa5Min" tier:"assinante"`
+	ClassChutesBloqueadoTimeCasa5Min  string `json:"classChutesBloqueadoTimeCasa5Min" tier:"assinante"`
+	ClassEscanteiosTimeCasa5Min       string `json:"classEscanteiosTimeCasa5Min" tier:"assinante"`
+	ClassAtaquesPerigososTimeCasa5Min string `json:"classAtaquesPerigososTimeCasa5Min" tier:"assinante"`
+	ClassPenalidadesTimeCasa5Min      string `json:"classPenalidadesTimeCasa5Min" tier:"assinante"`
 
 	// Classes CSS 10 Min Casa
-	ClassChutesGolTimeCasa10Min        string `json:"classChutesGolTimeCasa10Min"`
-	ClassChutesForaTimeCasa10Min       string `json:"classChutesForaTimeCasa10Min"`
-	ClassChutesTraveTimeCasa10Min      string `json:"classChutesTraveTimeCasa10Min"`
-	ClassChutesBloqueadoTimeCasa10Min  string `json:"classChutesBloqueadoTimeCasa10Min"`
-	ClassEscanteiosTimeCasa10Min       string `json:"classEscanteiosTimeCasa10Min"`
-	ClassAtaquesPerigososTimeCasa10Min string `json:"classAtaquesPerigososTimeCasa10Min"`
-	ClassPenalidadesTimeCasa10Min      string `json:"classPenalidadesTimeCasa10Min"`
+	ClassChutesGolTimeCasa10Min        string `json:"classChutesGolTimeCasa10Min" tier:"assinante"`
+	ClassChutesForaTimeCasa10Min       string `json:"classChutesForaTimeCasa10Min" tier:"assinante"`
+	ClassChutesTraveTimeCasa10Min      string `json:"classChutesTraveTimeCasa10Min" tier:"assinante"`
+	ClassChutesBloqueadoTimeCasa10Min  string `json:"classChutesBloqueadoTimeCasa10Min" tier:"assinante"`
+	ClassEscanteiosTimeCasa10Min       string `json:"classEscanteiosTimeCasa10Min" tier:"assinante"`
+	ClassAtaquesPerigososTimeCasa10Min string `json:"classAtaquesPerigososTimeCasa10Min" tier:"assinante"`
+	ClassPenalidadesTimeCasa10Min      string `json:"classPenalidadesTimeCasa10Min" tier:"assinante"`
 
 	// Estatisticas Time Fora (do Redis/oraculo)
-	PosseBolaTimeFora           FlexValue `json:"posseBolaTimeFora"`
-	ChutesGolTimeFora           FlexValue `json:"chutesGolTimeFora"`
-	ChutesForaTimeFora          FlexValue `json:"chutesForaTimeFora"`
-	ChutesTraveTimeFora         FlexValue `json:"chutesTraveTimeFora"`
-	ChutesBloqueadoTimeFora     FlexValue `json:"chutesBloqueadoTimeFora"`
-	EscanteiosTimeFora          FlexValue `json:"escanteiosTimeFora"`
-	AtaquesPerigososTimeFora    FlexValue `json:"ataquesPerigososTimeFora"`
-	PenalidadesTimeFora         FlexValue `json:"penalidadesTimeFora"`
-	ProbabilidadesTimeFora      FlexValue `json:"probabilidadesTimeFora"`
-	Pontos10MinTimeFora         FlexValue `json:"pontos10MinTimeFora"`
+	PosseBolaTimeFora           FlexValue `json:"posseBolaTimeFora" tier:"assinante"`
+	ChutesGolTimeFora           FlexValue `json:"chutesGolTimeFora" tier:"assinante"`
+	ChutesForaTimeFora          FlexValue `json:"chutesForaTimeFora" tier:"assinante"`
+	ChutesTraveTimeFora         FlexValue `json:"chutesTraveTimeFora" tier:"assinante"`
+	ChutesBloqueadoTimeFora     FlexValue `json:"chutesBloqueadoTimeFora" tier:"assinante"`
+	EscanteiosTimeFora          FlexValue `json:"escanteiosTimeFora" tier:"assinante"`
+	AtaquesPerigososTimeFora    FlexValue `json:"ataquesPerigososTimeFora" tier:"assinante"`
+	PenalidadesTimeFora         FlexValue `json:"penalidadesTimeFora" tier:"assinante"`
+	ProbabilidadesTimeFora      FlexValue `json:"probabilidadesTimeFora" tier:"assinante"`
+	Pontos10MinTimeFora         FlexValue `json:"pontos10MinTimeFora" tier:"assinante"`
 
 	// Estatisticas 1 Tempo Fora
-	ChutesGolTimeFora1Tempo        FlexValue `json:"chutesGolTimeFora1Tempo"`
-	ChutesForaTimeFora1Tempo       FlexValue `json:"chutesForaTimeFora1Tempo"`
-	ChutesTraveTimeFora1Tempo      FlexValue `json:"chutesTraveTimeFora1Tempo"`
-	ChutesBloqueadoTimeFora1Tempo  FlexValue `json:"chutesBloqueadoTimeFora1Tempo"`
-	EscanteiosTimeFora1Tempo       FlexValue `json:"escanteiosTimeFora1Tempo"`
-	AtaquesPerigososTimeFora1Tempo FlexValue `json:"ataquesPerigososTimeFora1Tempo"`
-	PenalidadesTimeFora1Tempo      FlexValue `json:"penalidadesTimeFora1Tempo"`
+	ChutesGolTimeFora1Tempo        FlexValue `json:"chutesGolTimeFora1Tempo" tier:"assinante"`
+	ChutesForaTimeFora1Tempo       FlexValue `json:"chutesForaTimeFora1Tempo" tier:"assinante"`
+	ChutesTraveTimeFora1Tempo      FlexValue `json:"chutesTraveTimeFora1Tempo" tier:"assinante"`
+	ChutesBloqueadoTimeFora1Tempo  FlexValue `json:"chutesBloqueadoTimeFora1Tempo" tier:"assinante"`
+	EscanteiosTimeFora1Tempo       FlexValue `json:"escanteiosTimeFora1Tempo" tier:"assinante"`
+	AtaquesPerigososTimeFora1Tempo FlexValue `json:"ataquesPerigososTimeFora1Tempo" tier:"assinante"`
+	PenalidadesTimeFora1Tempo      FlexValue `json:"penalidadesTimeFora1Tempo" tier:"assinante"`
 
 	// Estatisticas 2 Tempo Fora
-	ChutesGolTimeFora2Tempo        FlexValue `json:"chutesGolTimeFora2Tempo"`
-	ChutesForaTimeFora2Tempo       FlexValue `json:"chutesForaTimeFora2Tempo"`
-	ChutesTraveTimeFora2Tempo      FlexValue `json:"chutesTraveTimeFora2Tempo"`
-	ChutesBloqueadoTimeFora2Tempo  FlexValue `json:"chutesBloqueadoTimeFora2Tempo"`
-	EscanteiosTimeFora2Tempo       FlexValue `json:"escanteiosTimeFora2Tempo"`
-	AtaquesPerigososTimeFora2Tempo FlexValue `json:"ataquesPerigososTimeFora2Tempo"`
-	PenalidadesTimeFora2Tempo      FlexValue `json:"penalidadesTimeFora2Tempo"`
+	ChutesGolTimeFora2Tempo        FlexValue `json:"chutesGolTimeFora2Tempo" tier:"assinante"`
+	ChutesForaTimeFora2Tempo       FlexValue `json:"chutesForaTimeFora2Tempo" tier:"assinante"`
+	ChutesTraveTimeFora2Tempo      FlexValue `json:"chutesTraveTimeFora2Tempo" tier:"assinante"`
+	ChutesBloqueadoTimeFora2Tempo  FlexValue `json:"chutesBloqueadoTimeFora2Tempo" tier:"assinante"`
+	EscanteiosTimeFora2Tempo       FlexValue `json:"escanteiosTimeFora2Tempo" tier:"assinante"`
+	AtaquesPerigososTimeFora2Tempo FlexValue `json:"ataquesPerigososTimeFora2Tempo" tier:"assinante"`
+	PenalidadesTimeFora2Tempo      FlexValue `json:"penalidadesTimeFora2Tempo" tier:"assinante"`
 
 	// Estatisticas 5 Min Fora
-	ChutesGolTimeFora5Min        FlexValue `json:"chutesGolTimeFora5Min"`
-	ChutesForaTimeFora5Min       FlexValue `json:"chutesForaTimeFora5Min"`
-	ChutesTraveTimeFora5Min      FlexValue `json:"chutesTraveTimeFora5Min"`
-	ChutesBloqueadoTimeFora5Min  FlexValue `json:"chutesBloqueadoTimeFora5Min"`
-	EscanteiosTimeFora5Min       FlexValue `json:"escanteiosTimeFora5Min"`
-	AtaquesPerigososTimeFora5Min FlexValue `json:"ataquesPerigososTimeFora5Min"`
-	PenalidadesTimeFora5Min      FlexValue `json:"penalidadesTimeFora5Min"`
+	ChutesGolTimeFora5Min        FlexValue `json:"chutesGolTimeFora5Min" tier:"assinante"`
+	ChutesForaTimeFora5Min       FlexValue `json:"chutesForaTimeFora5Min" tier:"assinante"`
+	ChutesTraveTimeFora5Min      FlexValue `json:"chutesTraveTimeFora5Min" tier:"assinante"`
+	ChutesBloqueadoTimeFora5Min  FlexValue `json:"chutesBloqueadoTimeFora5Min" tier:"assinante"`
+	EscanteiosTimeFora5Min       FlexValue `json:"escanteiosTimeFora5Min" tier:"assinante"`
+	AtaquesPerigososTimeFora5Min FlexValue `json:"ataquesPerigososTimeFora5Min" tier:"assinante"`
+	PenalidadesTimeFora5Min      FlexValue `json:"penalidadesTimeFora5Min" tier:"assinante"`
 
 	// Estatisticas 10 Min Fora
-	ChutesGolTimeFora10Min        FlexValue `json:"chutesGolTimeFora10Min"`
-	ChutesForaTimeFora10Min       FlexValue `json:"chutesForaTimeFora10Min"`
-	ChutesTraveTimeFora10Min      FlexValue `json:"chutesTraveTimeFora10Min"`
-	ChutesBloqueadoTimeFora10Min  FlexValue `json:"chutesBloqueadoTimeFora10Min"`
-	EscanteiosTimeFora10Min       FlexValue `json:"escanteiosTimeFora10Min"`
-	AtaquesPerigososTimeFora10Min FlexValue `json:"ataquesPerigososTimeFora10Min"`
-	PenalidadesTimeFora10Min      FlexValue `json:"penalidadesTimeFora10Min"`
+	ChutesGolTimeFora10Min        FlexValue `json:"chutesGolTimeFora10Min" tier:"assinante"`
+	ChutesForaTimeFora10Min       FlexValue `json:"chutesForaTimeFora10Min" tier:"assinante"`
+	ChutesTraveTimeFora10Min      FlexValue `json:"chutesTraveTimeFora10Min" tier:"assinante"`
+	ChutesBloqueadoTimeFora10Min  FlexValue `json:"chutesBloqueadoTimeFora10Min" tier:"assinante"`
+	EscanteiosTimeFora10Min       FlexValue `json:"escanteiosTimeFora10Min" tier:"assinante"`
+	AtaquesPerigososTimeFora10Min FlexValue `json:"ataquesPerigososTimeFora10Min" tier:"assinante"`
+	PenalidadesTimeFora10Min      FlexValue `json:"penalidadesTimeFora10Min" tier:"assinante"`
 
 	// Classes CSS Time Fora
-	ClassPosseBolaTimeFora           string `json:"classPosseBolaTimeFora"`
-	ClassChutesGolTimeFora           string `json:"classChutesGolTimeFora"`
-	ClassChutesForaTimeFora          string `json:"classChutesForaTimeFora"`
-	ClassChutesTraveTimeFora         string `json:"classChutesTraveTimeFora"`
-	ClassChutesBloqueadoTimeFora     string `json:"classChutesBloqueadoTimeFora"`
-	ClassEscanteiosTimeFora          string `json:"classEscanteiosTimeFora"`
-	ClassAtaquesPerigososTimeFora    string `json:"classAtaquesPerigososTimeFora"`
-	ClassPenalidadesTimeFora         string `json:"classPenalidadesTimeFora"`
-	ClassProbabilidadesTimeFora      string `json:"classProbabilidadesTimeFora"`
-	ClassPontos10MinTimeFora         string `json:"classPontos10MinTimeFora"`
+	ClassPosseBolaTimeFora           string `json:"classPosseBolaTimeFora" tier:"assinante"`
+	ClassChutesGolTimeFora           string `json:"classChutesGolTimeFora" tier:"assinante"`
+	ClassChutesForaTimeFora          string `json:"classChutesForaTimeFora" tier:"assinante"`
+	ClassChutesTraveTimeFora         string `json:"classChutesTraveTimeFora" tier:"assinante"`
+	ClassChutesBloqueadoTimeFora     string `json:"classChutesBloqueadoTimeFora" tier:"assinante"`
+	ClassEscanteiosTimeFora          string `json:"classEscanteiosTimeFora" tier:"assinante"`
+	ClassAtaquesPerigososTimeFora    string `json:"classAtaquesPerigososTimeFora" tier:"assinante"`
+	ClassPenalidadesTimeFora         string `json:"classPenalidadesTimeFora" tier:"assinante"`
+	ClassProbabilidadesTimeFora      string `json:"classProbabilidadesTimeFora" tier:"assinante"`
+	ClassPontos10MinTimeFora         string `json:"classPontos10MinTimeFora" tier:"assinante"`
 
 	// Classes CSS 1 Tempo Fora
-	ClassChutesGolTimeFora1Tempo        string `json:"classChutesGolTimeFora1Tempo"`
-	ClassChutesForaTimeFora1Tempo       string `json:"classChutesForaTimeFora1Tempo"`
-	ClassChutesTraveTimeFora1Tempo      string `json:"classChutesTraveTimeFora1Tempo"`
-	ClassChutesBloqueadoTimeFora1Tempo  string `json:"classChutesBloqueadoTimeFora1Tempo"`
-	ClassEscanteiosTimeFora1Tempo       string `json:"classEscanteiosTimeFora1Tempo"`
-	ClassAtaquesPerigososTimeFora1Tempo string `json:"classAtaquesPerigososTimeFora1Tempo"`
-	ClassPenalidadesTimeFora1Tempo      string `json:"classPenalidadesTimeFora1Tempo"`
+	ClassChutesGolTimeFora1Tempo        string `json:"classChutesGolTimeFora1Tempo" tier:"assinante"`
+	ClassChutesForaTimeFora1Tempo       string `json:"classChutesForaTimeFora1Tempo" tier:"assinante"`
+	ClassChutesTraveTimeFora1Tempo      string `json:"classChutesTraveTimeFora1Tempo" tier:"assinante"`
+	ClassChutesBloqueadoTimeFora1Tempo  string `json:"classChutesBloqueadoTimeFora1Tempo" tier:"assinante"`
+	ClassEscanteiosTimeFora1Tempo       string `json:"classEscanteiosTimeFora1Tempo" tier:"assinante"`
+	ClassAtaquesPerigososTimeFora1Tempo string `json:"classAtaquesPerigososTimeFora1Tempo" tier:"assinante"`
+	ClassPenalidadesTimeFora1Tempo      string `json:"classPenalidadesTimeFora1Tempo" tier:"assinante"`
 
 	// Classes CSS 2 Tempo Fora
-	ClassChutesGolTimeFora2Tempo        string `json:"classChutesGolTimeFora2Tempo"`
-	ClassChutesForaTimeFora2Tempo       string `json:"classChutesForaTimeFora2Tempo"`
-	ClassChutesTraveTimeFora2Tempo      string `json:"classChutesTraveTimeFora2Tempo"`
-	ClassChutesBloqueadoTimeFora2Tempo  string `json:"classChutesBloqueadoTimeFora2Tempo"`
-	ClassEscanteiosTimeFora2Tempo       string `json:"classEscanteiosTimeFora2Tempo"`
-	ClassAtaquesPerigososTimeFora2Tempo string `json:"classAtaquesPerigososTimeFora2Tempo"`
-	ClassPenalidadesTimeFora2Tempo      string `json:"classPenalidadesTimeFora2Tempo"`
+	ClassChutesGolTimeFora2Tempo        string `json:"classChutesGolTimeFora2Tempo" tier:"assinante"`
+	ClassChutesForaTimeFora2Tempo       string `json:"classChutesForaTimeFora2Tempo" tier:"assinante"`
+	ClassChutesTraveTimeFora2Tempo      string `json:"classChutesTraveTimeFora2Tempo" tier:"assinante"`
+	ClassChutesBloqueadoTimeFora2Tempo  string `json:"classChutesBloqueadoTimeFora2Tempo" tier:"assinante"`
+	ClassEscanteiosTimeFora2Tempo       string `json:"classEscanteiosTimeFora2Tempo" tier:"assinante"`
+	ClassAtaquesPerigososTimeFora2Tempo string `json:"classAtaquesPerigososTimeFora2Tempo" tier:"assinante"`
+	ClassPenalidadesTimeFora2Tempo      string `json:"classPenalidadesTimeFora2Tempo" tier:"assinante"`
 
 	// Classes CSS 5 Min Fora
-	ClassChutesGolTimeFora5Min        string `json:"classChutesGolTimeFora5Min"`
-	ClassChutesForaTimeFora5Min       string `json:"classChutesForaTimeFora5Min"`
-	ClassChutesTraveTimeFora5Min      string `json:"classChutesTraveTimeFora5Min"`
-	ClassChutesBloqueadoTimeFora5Min  string `json:"classChutesBloqueadoTimeFora5Min"`
-	ClassEscanteiosTimeFora5Min       string `json:"classEscanteiosTimeFora5Min"`
-	ClassAtaquesPerigososTimeFora5Min string `json:"classAtaquesPerigososTimeFora5Min"`
-	ClassPenalidadesTimeFora5Min      string `json:"classPenalidadesTimeFora5Min"`
+	ClassChutesGolTimeFora5Min        string `json:"classChutesGolTimeFora5Min" tier:"assinante"`
+	ClassChutesForaTimeFora5Min       string `json:"classChutesForaTimeFora5Min" tier:"assinante"`
+	ClassChutesTraveTimeFora5Min      string `json:"classChutesTraveTimeFora5Min" tier:"assinante"`
+	ClassChutesBloqueadoTimeFora5Min  string `json:"classChutesBloqueadoTimeFora5Min" tier:"assinante"`
+	ClassEscanteiosTimeFora5Min       string `json:"classEscanteiosTimeFora5Min" tier:"assinante"`
+	ClassAtaquesPerigososTimeFora5Min string `json:"classAtaquesPerigososTimeFora5Min" tier:"assinante"`
+	ClassPenalidadesTimeFora5Min      string `json:"classPenalidadesTimeFora5Min" tier:"assinante"`
 
 	// Classes CSS 10 Min Fora
-	ClassChutesGolTimeFora10Min        string `json:"classChutesGolTimeFora10Min"`
-	ClassChutesForaTimeFora10Min       string `json:"classChutesForaTimeFora10Min"`
-	ClassChutesTraveTimeFora10Min      string `json:"classChutesTraveTimeFora10Min"`
-	ClassChutesBloqueadoTimeFora10Min  string `json:"classChutesBloqueadoTimeFora10Min"`
-	ClassEscanteiosTimeFora10Min       string `json:"classEscanteiosTimeFora10Min"`
-	ClassAtaquesPerigososTimeFora10Min string `json:"classAtaquesPerigososTimeFora10Min"`
-	ClassPenalidadesTimeFora10Min      string `json:"classPenalidadesTimeFora10Min"`
+	ClassChutesGolTimeFora10Min        string `json:"classChutesGolTimeFora10Min" tier:"assinante"`
+	ClassChutesForaTimeFora10Min       string `json:"classChutesForaTimeFora10Min" tier:"assinante"`
+	ClassChutesTraveTimeFora10Min      string `json:"classChutesTraveTimeFora10Min" tier:"assinante"`
+	ClassChutesBloqueadoTimeFora10Min  string `json:"classChutesBloqueadoTimeFora10Min" tier:"assinante"`
+	ClassEscanteiosTimeFora10Min       string `json:"classEscanteiosTimeFora10Min" tier:"assinante"`
+	ClassAtaquesPerigososTimeFora10Min string `json:"classAtaquesPerigososTimeFora10Min" tier:"assinante"`
+	ClassPenalidadesTimeFora10Min      string `json:"classPenalidadesTimeFora10Min" tier:"assinante"`
 
 	// Score de Lances (SL) - usado para alertas de pressao
-	ScoreLances10MinTimeCasa      FlexValue `json:"scoreLances10MinTimeCasa"`
-	ScoreLances10MinTimeFora      FlexValue `json:"scoreLances10MinTimeFora"`
-	ClassScoreLances10MinTimeCasa string    `json:"classScoreLances10MinTimeCasa"`
-	ClassScoreLances10MinTimeFora string    `json:"classScoreLances10MinTimeFora"`
-	ScoreLances5MinTimeCasa       FlexValue `json:"scoreLances5MinTimeCasa"`
-	ScoreLances5MinTimeFora       FlexValue `json:"scoreLances5MinTimeFora"`
-	ClassScoreLances5MinTimeCasa  string    `json:"classScoreLances5MinTimeCasa"`
-	ClassScoreLances5MinTimeFora  string    `json:"classScoreLances5MinTimeFora"`
+	ScoreLances10MinTimeCasa      FlexValue `json:"scoreLances10MinTimeCasa" tier:"assinante"`
+	ScoreLances10MinTimeFora      FlexValue `json:"scoreLances10MinTimeFora" tier:"assinante"`
+	ClassScoreLances10MinTimeCasa string    `json:"classScoreLances10MinTimeCasa" tier:"assinante"`
+	ClassScoreLances10MinTimeFora string    `json:"classScoreLances10MinTimeFora" tier:"assinante"`
+	ScoreLances5MinTimeCasa       FlexValue `json:"scoreLances5MinTimeCasa" tier:"assinante"`
+	ScoreLances5MinTimeFora       FlexValue `json:"scoreLances5MinTimeFora" tier:"assinante"`
+	ClassScoreLances5MinTimeCasa  string    `json:"classScoreLances5MinTimeCasa" tier:"assinante"`
+	ClassScoreLances5MinTimeFora  string    `json:"classScoreLances5MinTimeFora" tier:"assinante"`
 
 	// Alertas (FlexBool pois PHP envia 0/1)
 	AlertarGolTimeCasa           FlexBool `json:"alertarGolTimeCasa"`
@@ -292,39 +294,39 @@ type Evento struct {
 	AlertarPenalTimeFora         FlexBool `json:"alertarPenalTimeFora"`
 	AlertarSomGol                FlexBool `json:"alertarSomGol"`
 	Cuidado                      FlexBool `json:"cuidado"`
-	AlertaMomentoGolAtivo        FlexBool  `json:"alertaMomentoGolAtivo"`
-	AlertaMomentoGolValor        FlexValue `json:"alertaMomentoGolValor"`
-	AlertaPressaoIndividualAtivo FlexBool  `json:"alertaPressaoIndividualAtivo"`
-	AlertaPressaoIndividualTime  string    `json:"alertaPressaoIndividualTime"`
-	AlertaPressaoIndividualNome  string    `json:"alertaPressaoIndividualNome"`
-	AlertaPressaoIndividualValor FlexValue `json:"alertaPressaoIndividualValor"`
-	PressaoTimeCasa              FlexValue `json:"pressaoTimeCasa"`
-	PressaoTimeFora              FlexValue `json:"pressaoTimeFora"`
-	ClassPressaoTimeCasa         string    `json:"classPressaoTimeCasa"`
-	ClassPressaoTimeFora         string    `json:"classPressaoTimeFora"`
-	SomaPressao                  FlexValue `json:"somaPressao"`
+	AlertaMomentoGolAtivo        FlexBool  `json:"alertaMomentoGolAtivo" tier:"assinante"`
+	AlertaMomentoGolValor        FlexValue `json:"alertaMomentoGolValor" tier:"assinante"`
+	AlertaPressaoIndividualAtivo FlexBool  `json:"alertaPressaoIndividualAtivo" tier:"assinante"`
+	AlertaPressaoIndividualTime  string    `json:"alertaPressaoIndividualTime" tier:"assinante"`
+	AlertaPressaoIndividualNome  string    `json:"alertaPressaoIndividualNome" tier:"assinante"`
+	AlertaPressaoIndividualValor FlexValue `json:"alertaPressaoIndividualValor" tier:"assinante"`
+	PressaoTimeCasa              FlexValue `json:"pressaoTimeCasa" tier:"assinante"`
+	PressaoTimeFora              FlexValue `json:"pressaoTimeFora" tier:"assinante"`
+	ClassPressaoTimeCasa         string    `json:"classPressaoTimeCasa" tier:"assinante"`
+	ClassPressaoTimeFora         string    `json:"classPressaoTimeFora" tier:"assinante"`
+	SomaPressao                  FlexValue `json:"somaPressao" tier:"assinante"`
 
 	// Icones
 	IconeComentarioTimeCasa string `json:"iconeComentarioTimeCasa"`
 	IconeComentarioTimeFora string `json:"iconeComentarioTimeFora"`
 
 	// Acrescimos
-	Acrescimo1Tempo              FlexValue `json:"acrescimo1Tempo"`
-	Acrescimo2Tempo              FlexValue `json:"acrescimo2Tempo"`
-	ClassAcrescimo1Tempo         string    `json:"classAcrescimo1Tempo"`
-	ClassAcrescimo2Tempo         string    `json:"classAcrescimo2Tempo"`
-	PrevisaoAcrescimo1Tempo      FlexValue `json:"previsaoAcrescimo1Tempo"`
-	PrevisaoAcrescimo2Tempo      FlexValue `json:"previsaoAcrescimo2Tempo"`
-	ClassPrevisaoAcrescimo1Tempo string    `json:"classPrevisaoAcrescimo1Tempo"`
-	ClassPrevisaoAcrescimo2Tempo string    `json:"classPrevisaoAcrescimo2Tempo"`
+	Acrescimo1Tempo              FlexValue `json:"acrescimo1Tempo" tier:"assinante"`
+	Acrescimo2Tempo              FlexValue `json:"acrescimo2Tempo" tier:"assinante"`
+	ClassAcrescimo1Tempo         string    `json:"classAcrescimo1Tempo" tier:"assinante"`
+	ClassAcrescimo2Tempo         string    `json:"classAcrescimo2Tempo" tier:"assinante"`
+	PrevisaoAcrescimo1Tempo      FlexValue `json:"previsaoAcrescimo1Tempo" tier:"assinante"`
+	PrevisaoAcrescimo2Tempo      FlexValue `json:"previsaoAcrescimo2Tempo" tier:"assinante"`
+	ClassPrevisaoAcrescimo1Tempo string    `json:"classPrevisaoAcrescimo1Tempo" tier:"assinante"`
+	ClassPrevisaoAcrescimo2Tempo string    `json:"classPrevisaoAcrescimo2Tempo" tier:"assinante"`
 
 	// Extras
-	AnaliseIA          string                   `json:"analiseIA"`
+	AnaliseIA          string                   `json:"analiseIA" tier:"assinante"`
 	TemAnaliseIA       bool                     `json:"temAnaliseIA"`
 	TeamStreaks        []any                    `json:"teamStreaks"`
 	Favorito           FlexBool                 `json:"favorito"`
 	CampeonatoFavorito FlexBool                 `json:"campeonatoFavorito"`
-	LinhaDoTempo       []map[string]interface{} `json:"linhaDoTempo"`
+	LinhaDoTempo       []map[string]interface{} `json:"linhaDoTempo" tier:"assinante-lista"`
 }
 
 // Campeonato representa um campeonato com seus eventos
@@ -367,241 +369,54 @@ type HomeResponse struct {
 // Filtro esta definido em filtro.go
 
 // FiltrarParaFree retorna uma copia do evento com apenas campos liberados para usuarios free/anonimos
-// Remove: SL, alertas avancados, estatisticas, probabilidades, xG, pontos10Min, pressao, analise IA
+// Remove os campos marcados com tier:"assinante" (SL, alertas avancados, estatisticas,
+// probabilidades, xG, pontos10Min, pressao, analise IA) e tier:"assinante-lista" (linha do tempo)
 func (e *Evento) FiltrarParaFree() *Evento {
-	// Cria copia do evento
+	copia := *e
+	v := reflect.ValueOf(&copia).Elem()
+	for _, idx := range camposAssinante {
+		v.Field(idx).SetZero()
+	}
+	for _, idx := range camposAssinanteLista {
+		v.Field(idx).SetZero()
+	}
+	return &copia
+}
+
+// RemoverOdds retorna uma copia do evento sem odds, classes de odds e links das casas
+// Usado para parceiros sem o grupo de campos "odds"
+func (e *Evento) RemoverOdds() *Evento {
 	copia := *e
 
-	// Zera Score de Lances (SL)
-	copia.ScoreLances10MinTimeCasa = ""
-	copia.ScoreLances10MinTimeFora = ""
-	copia.ClassScoreLances10MinTimeCasa = ""
-	copia.ClassScoreLances10MinTimeFora = ""
-	copia.ScoreLances5MinTimeCasa = ""
-	copia.ScoreLances5MinTimeFora = ""
-	copia.ClassScoreLances5MinTimeCasa = ""
-	copia.ClassScoreLances5MinTimeFora = ""
-
-	// Zera alertas avancados (momento gol e pressao individual)
-	copia.AlertaMomentoGolAtivo = FlexBool(false)
-	copia.AlertaMomentoGolValor = ""
-	copia.AlertaPressaoIndividualAtivo = FlexBool(false)
-	copia.AlertaPressaoIndividualTime = ""
-	copia.AlertaPressaoIndividualNome = ""
-	copia.AlertaPressaoIndividualValor = ""
-	copia.PressaoTimeCasa = ""
-	copia.PressaoTimeFora = ""
-	copia.ClassPressaoTimeCasa = ""
-	copia.ClassPressaoTimeFora = ""
-	copia.SomaPressao = ""
-
-	// Zera estatisticas Time Casa
-	copia.PosseBolaTimeCasa = ""
-	copia.ChutesGolTimeCasa = ""
-	copia.ChutesForaTimeCasa = ""
-	copia.ChutesTraveTimeCasa = ""
-	copia.ChutesBloqueadoTimeCasa = ""
-	copia.EscanteiosTimeCasa = ""
-	copia.AtaquesPerigososTimeCasa = ""
-	copia.PenalidadesTimeCasa = ""
-	copia.ProbabilidadesTimeCasa = ""
-	copia.Pontos10MinTimeCasa = ""
-
-	// Zera estatisticas 1 Tempo Casa
-	copia.ChutesGolTimeCasa1Tempo = ""
-	copia.ChutesForaTimeCasa1Tempo = ""
-	copia.ChutesTraveTimeCasa1Tempo = ""
-	copia.ChutesBloqueadoTimeCasa1Tempo = ""
-	copia.EscanteiosTimeCasa1Tempo = ""
-	copia.AtaquesPerigososTimeCasa1Tempo = ""
-	copia.PenalidadesTimeCasa1Tempo = ""
-
-	// Zera estatisticas 2 Tempo Casa
-	copia.ChutesGolTimeCasa2Tempo = ""
-	copia.ChutesForaTimeCasa2Tempo = ""
-	copia.ChutesTraveTimeCasa2Tempo = ""
-	copia.ChutesBloqueadoTimeCasa2Tempo = ""
-	copia.EscanteiosTimeCasa2Tempo = ""
-	copia.AtaquesPerigososTimeCasa2Tempo = ""
-	copia.PenalidadesTimeCasa2Tempo = ""
-
-	// Zera estatisticas 5 Min Casa
-	copia.ChutesGolTimeCasa5Min = ""
-	copia.ChutesForaTimeCasa5Min = ""
-	copia.ChutesTraveTimeCasa5Min = ""
-	copia.ChutesBloqueadoTimeCasa5Min = ""
-	copia.EscanteiosTimeCasa5Min = ""
-	copia.AtaquesPerigososTimeCasa5Min = ""
-	copia.PenalidadesTimeCasa5Min = ""
-
-	// Zera estatisticas 10 Min Casa
-	copia.ChutesGolTimeCasa10Min = ""
-	copia.ChutesForaTimeCasa10Min = ""
-	copia.ChutesTraveTimeCasa10Min = ""
-	copia.ChutesBloqueadoTimeCasa10Min = ""
-	copia.EscanteiosTimeCasa10Min = ""
-	copia.AtaquesPerigososTimeCasa10Min = ""
-	copia.PenalidadesTimeCasa10Min = ""
-
-	// Zera classes CSS Time Casa
-	copia.ClassPosseBolaTimeCasa = ""
-	copia.ClassChutesGolTimeCasa = ""
-	copia.ClassChutesForaTimeCasa = ""
-	copia.ClassChutesTraveTimeCasa = ""
-	copia.ClassChutesBloqueadoTimeCasa = ""
-	copia.ClassEscanteiosTimeCasa = ""
-	copia.ClassAtaquesPerigososTimeCasa = ""
-	copia.ClassPenalidadesTimeCasa = ""
-	copia.ClassProbabilidadesTimeCasa = ""
-	copia.ClassPontos10MinTimeCasa = ""
-
-	// Zera classes CSS 1 Tempo Casa
-	copia.ClassChutesGolTimeCasa1Tempo = ""
-	copia.ClassChutesForaTimeCasa1Tempo = ""
-	copia.ClassChutesTraveTimeCasa1Tempo = ""
-	copia.ClassChutesBloqueadoTimeCasa1Tempo = ""
-	copia.ClassEscanteiosTimeCasa1Tempo = ""
-	copia.ClassAtaquesPerigososTimeCasa1Tempo = ""
-	copia.ClassPenalidadesTimeCasa1Tempo = ""
-
-	// Zera classes CSS 2 Tempo Casa
-	copia.ClassChutesGolTimeCasa2Tempo = ""
-	copia.ClassChutesForaTimeCasa2Tempo = ""
-	copia.ClassChutesTraveTimeCasa2Tempo = ""
-	copia.ClassChutesBloqueadoTimeCasa2Tempo = ""
-	copia.ClassEscanteiosTimeCasa2Tempo = ""
-	copia.ClassAtaquesPerigososTimeCasa2Tempo = ""
-	copia.ClassPenalidadesTimeCasa2Tempo = ""
-
-	// Zera classes CSS 5 Min Casa
-	copia.ClassChutesGolTimeCasa5Min = ""
-	copia.ClassChutesForaTimeCasa5Min = ""
-	copia.ClassChutesTraveTimeCasa5Min = ""
-	copia.ClassChutesBloqueadoTimeCasa5Min = ""
-	copia.ClassEscanteiosTimeCasa5Min = ""
-	copia.ClassAtaquesPerigososTimeCasa5Min = ""
-	copia.ClassPenalidadesTimeCasa5Min = ""
-
-	// Zera classes CSS 10 Min Casa
-	copia.ClassChutesGolTimeCasa10Min = ""
-	copia.ClassChutesForaTimeCasa10Min = ""
-	copia.ClassChutesTraveTimeCasa10Min = ""
-	copia.ClassChutesBloqueadoTimeCasa10Min = ""
-	copia.ClassEscanteiosTimeCasa10Min = ""
-	copia.ClassAtaquesPerigososTimeCasa10Min = ""
-	copia.ClassPenalidadesTimeCasa10Min = ""
-
-	// Zera estatisticas Time Fora
-	copia.PosseBolaTimeFora = ""
-	copia.ChutesGolTimeFora = ""
-	copia.ChutesForaTimeFora = ""
-	copia.ChutesTraveTimeFora = ""
-	copia.ChutesBloqueadoTimeFora = ""
-	copia.EscanteiosTimeFora = ""
-	copia.AtaquesPerigososTimeFora = ""
-	copia.PenalidadesTimeFora = ""
-	copia.ProbabilidadesTimeFora = ""
-	copia.Pontos10MinTimeFora = ""
-
-	// Zera estatisticas 1 Tempo Fora
-	copia.ChutesGolTimeFora1Tempo = ""
-	copia.ChutesForaTimeFora1Tempo = ""
-	copia.ChutesTraveTimeFora1Tempo = ""
-	copia.ChutesBloqueadoTimeFora1Tempo = ""
-	copia.EscanteiosTimeFora1Tempo = ""
-	copia.AtaquesPerigososTimeFora1Tempo = ""
-	copia.PenalidadesTimeFora1Tempo = ""
-
-	// Zera estatisticas 2 Tempo Fora
-	copia.ChutesGolTimeFora2Tempo = ""
-	copia.ChutesForaTimeFora2Tempo = ""
-	copia.ChutesTraveTimeFora2Tempo = ""
-	copia.ChutesBloqueadoTimeFora2Tempo = ""
-	copia.EscanteiosTimeFora2Tempo = ""
-	copia.AtaquesPerigososTimeFora2Tempo = ""
-	copia.PenalidadesTimeFora2Tempo = ""
-
-	// Zera estatisticas 5 Min Fora
-	copia.ChutesGolTimeFora5Min = ""
-	copia.ChutesForaTimeFora5Min = ""
-	copia.ChutesTraveTimeFora5Min = ""
-	copia.ChutesBloqueadoTimeFora5Min = ""
-	copia.EscanteiosTimeFora5Min = ""
-	copia.AtaquesPerigososTimeFora5Min = ""
-	copia.PenalidadesTimeFora5Min = ""
-
-	// Zera estatisticas 10 Min Fora
-	copia.ChutesGolTimeFora10Min = ""
-	copia.ChutesForaTimeFora10Min = ""
-	copia.ChutesTraveTimeFora10Min = ""
-	copia.ChutesBloqueadoTimeFora10Min = ""
-	copia.EscanteiosTimeFora10Min = ""
-	copia.AtaquesPerigososTimeFora10Min = ""
-	copia.PenalidadesTimeFora10Min = ""
-
-	// Zera classes CSS Time Fora
-	copia.ClassPosseBolaTimeFora = ""
-	copia.ClassChutesGolTimeFora = ""
-	copia.ClassChutesForaTimeFora = ""
-	copia.ClassChutesTraveTimeFora = ""
-	copia.ClassChutesBloqueadoTimeFora = ""
-	copia.ClassEscanteiosTimeFora = ""
-	copia.ClassAtaquesPerigososTimeFora = ""
-	copia.ClassPenalidadesTimeFora = ""
-	copia.ClassProbabilidadesTimeFora = ""
-	copia.ClassPontos10MinTimeFora = ""
-
-	// Zera classes CSS 1 Tempo Fora
-	copia.ClassChutesGolTimeFora1Tempo = ""
-	copia.ClassChutesForaTimeFora1Tempo = ""
-	copia.ClassChutesTraveTimeFora1Tempo = ""
-	copia.ClassChutesBloqueadoTimeFora1Tempo = ""
-	copia.ClassEscanteiosTimeFora1Tempo = ""
-	copia.ClassAtaquesPerigososTimeFora1Tempo = ""
-	copia.ClassPenalidadesTimeFora1Tempo = ""
-
-	// Zera classes CSS 2 Tempo Fora
-	copia.ClassChutesGolTimeFora2Tempo = ""
-	copia.ClassChutesForaTimeFora2Tempo = ""
-	copia.ClassChutesTraveTimeFora2Tempo = ""
-	copia.ClassChutesBloqueadoTimeFora2Tempo = ""
-	copia.ClassEscanteiosTimeFora2Tempo = ""
-	copia.ClassAtaquesPerigososTimeFora2Tempo = ""
-	copia.ClassPenalidadesTimeFora2Tempo = ""
-
-	// Zera classes CSS 5 Min Fora
-	copia.ClassChutesGolTimeFora5Min = ""
-	copia.ClassChutesForaTimeFora5Min = ""
-	copia.ClassChutesTraveTimeFora5Min = ""
-	copia.ClassChutesBloqueadoTimeFora5Min = ""
-	copia.ClassEscanteiosTimeFora5Min = ""
-	copia.ClassAtaquesPerigososTimeFora5Min = ""
-	copia.ClassPenalidadesTimeFora5Min = ""
-
-	// Zera classes CSS 10 Min Fora
-	copia.ClassChutesGolTimeFora10Min = ""
-	copia.ClassChutesForaTimeFora10Min = ""
-	copia.ClassChutesTraveTimeFora10Min = ""
-	copia.ClassChutesBloqueadoTimeFora10Min = ""
-	copia.ClassEscanteiosTimeFora10Min = ""
-	copia.ClassAtaquesPerigososTimeFora10Min = ""
-	copia.ClassPenalidadesTimeFora10Min = ""
-
-	// Zera acrescimos e previsao de acrescimos
-	copia.Acrescimo1Tempo = ""
-	copia.Acrescimo2Tempo = ""
-	copia.ClassAcrescimo1Tempo = ""
-	copia.ClassAcrescimo2Tempo = ""
-	copia.PrevisaoAcrescimo1Tempo = ""
-	copia.PrevisaoAcrescimo2Tempo = ""
-	copia.ClassPrevisaoAcrescimo1Tempo = ""
-	copia.ClassPrevisaoAcrescimo2Tempo = ""
-
-	// Zera analise IA
-	copia.AnaliseIA = ""
-
-	// Zera linha do tempo
-	copia.LinhaDoTempo = nil
+	// Zera odds
+	copia.OddTimeCasa = ""
+	copia.OddTimeFora = ""
+	copia.OddEmpate = ""
+	copia.OddUnder15FT = ""
+	copia.OddOver15FT = ""
+	copia.OddUnder25FT = ""
+	copia.OddOver25FT = ""
+	copia.OddBttsSim = ""
+	copia.OddBttsNao = ""
+
+	// Zera classes CSS odds
+	copia.ClassOddTimeCasa = ""
+	copia.ClassOddTimeFora = ""
+	copia.ClassOddEmpate = ""
+	copia.ClassOddUnder15FT = ""
+	copia.ClassOddOver15FT = ""
+	copia.ClassOddUnder25FT = ""
+	copia.ClassOddOver25FT = ""
+	copia.ClassOddBttsSim = ""
+	copia.ClassOddBttsNao = ""
+
+	// Zera links
+	copia.LinkWilliamhill = ""
+	copia.LinkBetfair = ""
+	copia.LinkOddjusta = ""
+	copia.LinkBolsadeaposta = ""
+	copia.LinkFulltbet = ""
+	copia.LinkOrbit = ""
 
 	return &copia
 }
@@ -36,6 +36,17 @@ type Filtro struct {
 	FiltroPressao               bool
 	FiltroAlertas               bool
 	FiltroDiferencaXg           bool
+
+	// Escopo de parceiro B2B (definido pelo handler a partir da API key)
+	CampeonatosPermitidos map[string]bool // IdCampeonatoUnico liberados (nil = todos)
+	OcultarOdds           bool            // Remove odds e links das casas
+
+	// Campos do Evento a serializar (parametro fields=, nil = todos)
+	// Aplicada na serializacao, depois do filtro por tier: nunca libera campos a mais
+	Projecao *Projecao
+
+	// Formato do payload (parametro encoding=: json, msgpack ou cbor)
+	Encoding string
 }
 
 // ParseFiltroFromRequest extrai filtros da query string igual ao Laravel
@@ -44,7 +55,7 @@ func ParseFiltroFromRequest(r *http.Request) *Filtro {
 
 	return &Filtro{
 		IdUsuario:                   getIntParam(q.Get("idUsuario"), 0),
-		Token:                       strings.TrimSpace(q.Get("token")),
+		Token:                       getTokenParam(r),
 		IsAssinante:                 false, // Sera definido pelo handler apos validacao
 		SomLigado:                   getBoolParam(q.Get("somLigado")),
 		OrdemInicio:                 getBoolParam(q.Get("ordemInicio")),
@@ -74,6 +85,15 @@ func ParseFiltroFromRequest(r *http.Request) *Filtro {
 	}
 }
 
+// getTokenParam extrai o token do header Authorization (Bearer) ou do parametro token
+// EventSource nao envia headers, por isso o parametro continua aceito
+func getTokenParam(r *http.Request) string {
+	if auth := r.Header.Get("Authorization"); len(auth) > 7 && strings.EqualFold(auth[:7], "Bearer ") {
+		return strings.TrimSpace(auth[7:])
+	}
+	return strings.TrimSpace(r.URL.Query().Get("token"))
+}
+
 // getBoolParam converte string para bool (igual filter_var do PHP)
 func getBoolParam(val string) bool {
 	val = strings.ToLower(strings.TrimSpace(val))
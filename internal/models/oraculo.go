@@ -0,0 +1,227 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// Oraculo payload do oraculo de um jogo (Redis oraculo-cache:idJogo-{idWilliamhill})
+// Usa os mesmos campos e tipos Flex do Evento, entao o filtro por tier e o mesmo
+// O Evento tipado serve para leitura; o JSON repassa os valores como vieram do Redis
+type Oraculo struct {
+	Evento
+
+	// Extras campos do Redis que o Evento nao conhece (repassados como vieram)
+	Extras map[string]json.RawMessage `json:"-"`
+
+	// raw valores originais das chaves do Evento presentes no payload (nil = montado em codigo)
+	// Numeros seguem numeros (sem arredondar) e chaves ausentes seguem ausentes
+	raw map[string]json.RawMessage
+}
+
+// AvisosDecode chaves do payload que nao entraram no Evento tipado (para metricas)
+type AvisosDecode struct {
+	Desconhecidos  []string // sem campo no Evento e fora de oraculoExtras (guardadas em Extras)
+	TiposInvalidos []string // tipo incompativel com o campo (campo tipado fica zerado, JSON repassa o valor)
+}
+
+// oraculoCamposFreeSet chaves removidas do payload para usuarios free/anonimos (tier:"assinante" no Evento)
+// A linha do tempo (tier:"assinante-lista") e restrita apenas nas listas de eventos
+var oraculoCamposFreeSet = func() map[string]bool {
+	set := make(map[string]bool, len(camposAssinante))
+	for _, idx := range camposAssinante {
+		set[eventoChaves[idx]] = true
+	}
+	return set
+}()
+
+// oraculoExtras chaves do oraculo sem campo no Evento ja revisadas (true = liberada para free)
+// Chaves fora desta lista contam como desconhecidas e so vao para assinantes ate serem revisadas
+var oraculoExtras = map[string]bool{
+	"graficoPressao": true,
+}
+
+// DecodeOraculo decodifica o JSON do oraculo campo a campo
+// Um campo com tipo inesperado nao invalida o payload: vai para AvisosDecode.TiposInvalidos
+func DecodeOraculo(data []byte) (*Oraculo, AvisosDecode, error) {
+	var avisos AvisosDecode
+
+	var campos map[string]json.RawMessage
+	if err := json.Unmarshal(data, &campos); err != nil {
+		return nil, avisos, err
+	}
+
+	oraculo := &Oraculo{raw: make(map[string]json.RawMessage, len(campos))}
+	v := reflect.ValueOf(&oraculo.Evento).Elem()
+	for chave, valor := range campos {
+		idx, ok := eventoCampos[chave]
+		if !ok {
+			if oraculo.Extras == nil {
+				oraculo.Extras = make(map[string]json.RawMessage)
+			}
+			oraculo.Extras[chave] = valor
+			if _, revisada := oraculoExtras[chave]; !revisada {
+				avisos.Desconhecidos = append(avisos.Desconhecidos, chave)
+			}
+			continue
+		}
+
+		oraculo.raw[chave] = valor
+		if err := json.Unmarshal(valor, v.Field(idx).Addr().Interface()); err != nil {
+			v.Field(idx).SetZero()
+			avisos.TiposInvalidos = append(avisos.TiposInvalidos, chave)
+		}
+	}
+	sort.Strings(avisos.Desconhecidos)
+	sort.Strings(avisos.TiposInvalidos)
+
+	return oraculo, avisos, nil
+}
+
+// valores retorna os valores JSON das chaves do Evento (os originais ou, se montado em codigo, os do struct)
+func (o *Oraculo) valores() (map[string]json.RawMessage, error) {
+	if o.raw != nil {
+		return o.raw, nil
+	}
+	data, err := json.Marshal(&o.Evento)
+	if err != nil {
+		return nil, err
+	}
+	var valores map[string]json.RawMessage
+	err = json.Unmarshal(data, &valores)
+	return valores, err
+}
+
+// copiaValores copia dos valores JSON (para os filtros alterarem sem tocar no compartilhado)
+func (o *Oraculo) copiaValores() (map[string]json.RawMessage, error) {
+	valores, err := o.valores()
+	if err != nil {
+		return nil, err
+	}
+	copia := make(map[string]json.RawMessage, len(valores))
+	for chave, valor := range valores {
+		copia[chave] = valor
+	}
+	return copia, nil
+}
+
+// MergeInfo sobrescreve status, escalacao, problema no radar e acrescimos com os dados atuais do evento
+// Acrescimos vao como numero no JSON (como o Laravel envia)
+func (o *Oraculo) MergeInfo(status string, temEscalacao, problemaRadar int, descontoHt, descontoFt *int) {
+	// Copia: o mapa pode ser compartilhado com a origem (ex: copia rasa do store)
+	if valores, err := o.copiaValores(); err == nil {
+		o.raw = valores
+	}
+	definir := func(chave string, valor interface{}) {
+		if data, err := json.Marshal(valor); err == nil && o.raw != nil {
+			o.raw[chave] = data
+		}
+	}
+
+	o.Status = status
+	o.TemEscalacao = temEscalacao
+	o.ProblemaRadar = problemaRadar
+	definir("status", status)
+	definir("temEscalacao", temEscalacao)
+	definir("problemaRadar", problemaRadar)
+	if descontoHt != nil {
+		o.Acrescimo1Tempo = FlexValue(strconv.Itoa(*descontoHt))
+		definir("acrescimo1Tempo", *descontoHt)
+	}
+	if descontoFt != nil {
+		o.Acrescimo2Tempo = FlexValue(strconv.Itoa(*descontoFt))
+		definir("acrescimo2Tempo", *descontoFt)
+	}
+}
+
+// MarshalJSON serializa os valores do Evento (na ordem do struct) seguidos dos Extras (em ordem de chave)
+func (o Oraculo) MarshalJSON() ([]byte, error) {
+	valores, err := o.valores()
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	escrever := func(chave string, valor json.RawMessage) {
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		nome, _ := json.Marshal(chave)
+		buf.Write(nome)
+		buf.WriteByte(':')
+		buf.Write(valor)
+	}
+
+	for _, chave := range eventoChaves {
+		if valor, ok := valores[chave]; ok && chave != "" {
+			escrever(chave, valor)
+		}
+	}
+
+	extras := make([]string, 0, len(o.Extras))
+	for chave := range o.Extras {
+		extras = append(extras, chave)
+	}
+	sort.Strings(extras)
+	for _, chave := range extras {
+		escrever(chave, o.Extras[chave])
+	}
+
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// FiltrarParaFree retorna uma copia sem os campos de assinante (tier:"assinante")
+// Dos Extras seguem apenas os liberados em oraculoExtras; as chaves removidas somem do JSON
+func (o *Oraculo) FiltrarParaFree() *Oraculo {
+	copia := &Oraculo{Evento: o.Evento}
+	for chave, valor := range o.Extras {
+		if !oraculoExtras[chave] {
+			continue
+		}
+		if copia.Extras == nil {
+			copia.Extras = make(map[string]json.RawMessage)
+		}
+		copia.Extras[chave] = valor
+	}
+	v := reflect.ValueOf(&copia.Evento).Elem()
+	for _, idx := range camposAssinante {
+		v.Field(idx).SetZero()
+	}
+
+	if valores, err := o.copiaValores(); err == nil {
+		for chave := range oraculoCamposFreeSet {
+			delete(valores, chave)
+		}
+		copia.raw = valores
+	}
+	return copia
+}
+
+// RemoverOdds retorna uma copia sem odds e links das casas (parceiros sem o grupo "odds")
+// As chaves continuam no JSON, com o valor zerado (como na lista de eventos)
+func (o *Oraculo) RemoverOdds() *Oraculo {
+	copia := &Oraculo{Evento: *o.Evento.RemoverOdds(), Extras: o.Extras}
+
+	valores, err := o.copiaValores()
+	if err != nil {
+		return copia
+	}
+	antes := reflect.ValueOf(&o.Evento).Elem()
+	depois := reflect.ValueOf(&copia.Evento).Elem()
+	for chave := range valores {
+		idx := eventoCampos[chave]
+		if reflect.DeepEqual(antes.Field(idx).Interface(), depois.Field(idx).Interface()) {
+			continue
+		}
+		if data, err := json.Marshal(depois.Field(idx).Interface()); err == nil {
+			valores[chave] = data
+		}
+	}
+	copia.raw = valores
+	return copia
+}
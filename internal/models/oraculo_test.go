@@ -0,0 +1,121 @@
+package models
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDecodeOraculo_CamposDesconhecidos(t *testing.T) {
+	raw := `{"idEvento": 10, "status": "inprogress", "scoreLances10MinTimeCasa": 7, "graficoPressao": [1,2], "novoCampo": "x"}`
+
+	oraculo, avisos, err := DecodeOraculo([]byte(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if oraculo.IdEvento != 10 || oraculo.ScoreLances10MinTimeCasa != "7" {
+		t.Errorf("Campos tipados nao decodificados: %+v", oraculo.Evento.IdEvento)
+	}
+	if !reflect.DeepEqual(avisos.Desconhecidos, []string{"novoCampo"}) {
+		t.Errorf("Campos desconhecidos inesperados: %v", avisos.Desconhecidos)
+	}
+
+	// Assinante recebe os extras no JSON
+	data, _ := json.Marshal(oraculo)
+	if !strings.Contains(string(data), `"graficoPressao":[1,2]`) || !strings.HasSuffix(string(data), `"novoCampo":"x"}`) {
+		t.Errorf("Extras ausentes no JSON: %s", data)
+	}
+
+	// Free: remove os campos de assinante e os extras ainda nao revisados
+	free := oraculo.FiltrarParaFree()
+	data, _ = json.Marshal(free)
+	if string(data) != `{"idEvento":10,"status":"inprogress","graficoPressao":[1,2]}` {
+		t.Errorf("JSON free inesperado: %s", data)
+	}
+	if oraculo.ScoreLances10MinTimeCasa != "7" {
+		t.Error("Filtro free nao pode modificar o oraculo compartilhado")
+	}
+}
+
+func TestDecodeOraculo_NumerosComoVieram(t *testing.T) {
+	// Numeros seguem numeros, sem arredondar (a ordem das chaves segue o struct)
+	raw := `{"acrescimo1Tempo":3,"posseBolaTimeCasa":60,"probabilidadesTimeCasa":55.25}`
+
+	oraculo, _, err := DecodeOraculo([]byte(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := json.Marshal(oraculo)
+	var entrada, saida map[string]json.RawMessage
+	json.Unmarshal([]byte(raw), &entrada)
+	json.Unmarshal(data, &saida)
+	if !reflect.DeepEqual(entrada, saida) {
+		t.Errorf("JSON diferente do Redis:\n  obteve   %s\n  esperava %s", data, raw)
+	}
+}
+
+func TestDecodeOraculo_TipoInvalido(t *testing.T) {
+	// Um campo com tipo inesperado nao pode derrubar o payload inteiro
+	raw := `{"idEvento":10,"oddTimeCasa":1.75}`
+
+	oraculo, avisos, err := DecodeOraculo([]byte(raw))
+	if err != nil {
+		t.Fatalf("Payload rejeitado por um campo: %v", err)
+	}
+	if oraculo.IdEvento != 10 {
+		t.Errorf("Demais campos deveriam ser decodificados: %d", oraculo.IdEvento)
+	}
+	if !reflect.DeepEqual(avisos.TiposInvalidos, []string{"oddTimeCasa"}) {
+		t.Errorf("Tipos invalidos inesperados: %v", avisos.TiposInvalidos)
+	}
+	if data, _ := json.Marshal(oraculo); string(data) != raw {
+		t.Errorf("Valor com tipo inesperado deveria ser repassado como veio: %s", data)
+	}
+}
+
+func TestOraculo_MergeInfoAcrescimosNumericos(t *testing.T) {
+	oraculo, _, err := DecodeOraculo([]byte(`{"status":"notstarted","acrescimo1Tempo":3}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ht, ft := 4, 6
+	oraculo.MergeInfo("inprogress", 1, 0, &ht, &ft)
+
+	data, _ := json.Marshal(oraculo)
+	if string(data) != `{"status":"inprogress","problemaRadar":0,"temEscalacao":1,"acrescimo1Tempo":4,"acrescimo2Tempo":6}` {
+		t.Errorf("JSON do merge inesperado: %s", data)
+	}
+	if oraculo.Acrescimo1Tempo != "4" {
+		t.Errorf("Campo tipado nao atualizado: %q", oraculo.Acrescimo1Tempo)
+	}
+}
+
+func TestEvento_TagTier(t *testing.T) {
+	// Valor de tier com erro de digitacao deixaria o campo liberado para free sem aviso
+	tipo := reflect.TypeOf(Evento{})
+	for i := 0; i < tipo.NumField(); i++ {
+		switch tier := tipo.Field(i).Tag.Get("tier"); tier {
+		case "", "assinante", "assinante-lista":
+		default:
+			t.Errorf("Campo %s com tier desconhecido: %q", tipo.Field(i).Name, tier)
+		}
+	}
+
+	// Amostra dos campos de assinante de cada grupo
+	for _, chave := range []string{"scoreLances10MinTimeCasa", "alertaMomentoGolAtivo", "somaPressao",
+		"posseBolaTimeFora", "classPenalidadesTimeCasa10Min", "previsaoAcrescimo2Tempo", "analiseIA"} {
+		if !oraculoCamposFreeSet[chave] {
+			t.Errorf("%s deveria ser restrito a assinantes", chave)
+		}
+	}
+	if oraculoCamposFreeSet["linhaDoTempo"] || oraculoCamposFreeSet["timeCasa"] {
+		t.Error("Linha do tempo e dados basicos sao liberados no oraculo")
+	}
+
+	// Filtro do Evento remove tambem a linha do tempo (listas)
+	e := &Evento{AnaliseIA: "x", LinhaDoTempo: []map[string]interface{}{{}}, TimeCasa: "Flamengo"}
+	if free := e.FiltrarParaFree(); free.AnaliseIA != "" || free.LinhaDoTempo != nil || free.TimeCasa != "Flamengo" {
+		t.Errorf("Filtro free do Evento inesperado: %+v", free)
+	}
+}
@@ -0,0 +1,203 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// eventoCampos indice chave JSON -> posicao do campo no struct Evento (montado uma vez)
+var eventoCampos = func() map[string]int {
+	campos := make(map[string]int)
+	t := reflect.TypeOf(Evento{})
+	for i := 0; i < t.NumField(); i++ {
+		tag := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if tag != "" && tag != "-" {
+			campos[tag] = i
+		}
+	}
+	return campos
+}()
+
+// eventoChaves chave JSON de cada campo do Evento, na ordem do struct ("" = campo sem chave)
+var eventoChaves = func() []string {
+	t := reflect.TypeOf(Evento{})
+	chaves := make([]string, t.NumField())
+	for chave, idx := range eventoCampos {
+		chaves[idx] = chave
+	}
+	return chaves
+}()
+
+// Campos restritos a assinantes, marcados no proprio Evento com a tag tier:
+//   - tier:"assinante": removidos para free/anonimos em qualquer payload (listas e oraculo)
+//   - tier:"assinante-lista": removidos para free apenas nas listas de eventos (painel/home)
+var (
+	camposAssinante      = camposPorTier("assinante")
+	camposAssinanteLista = camposPorTier("assinante-lista")
+)
+
+// camposPorTier posicoes dos campos do Evento com a tag tier informada
+func camposPorTier(tier string) []int {
+	var campos []int
+	t := reflect.TypeOf(Evento{})
+	for i := 0; i < t.NumField(); i++ {
+		if t.Field(i).Tag.Get("tier") == tier {
+			campos = append(campos, i)
+		}
+	}
+	return campos
+}
+
+// perfilList campos que a lista do painel realmente renderiza
+var perfilList = []string{
+	"idEvento", "idWilliamhill", "idBetfair", "slugEvento",
+	"timeCasa", "slugTimeCasa", "golTimeCasaFt", "cartaoVermelhoTimeCasa",
+	"timeFora", "slugTimeFora", "golTimeForaFt", "cartaoVermelhoTimeFora",
+	"status", "tempoAtual", "inicio", "oraculo",
+	"idCampeonatoUnico", "nomeCampeonatoReduzido", "flag",
+	"alertarGolTimeCasa", "alertarGolTimeFora",
+	"favorito", "campeonatoFavorito",
+}
+
+// Projecao conjunto de chaves JSON do Evento que serao serializadas
+// nil = todos os campos (perfil full)
+type Projecao struct {
+	indices []int
+	chaves  [][]byte // "chave": ja codificado
+}
+
+// ParseProjecao interpreta o parametro fields=
+// Aceita perfis (compact, list, full) e/ou chaves JSON do Evento separadas por virgula
+// Ex: fields=list,oddTimeCasa,oddTimeFora
+func ParseProjecao(param string) (*Projecao, error) {
+	param = strings.TrimSpace(param)
+	if param == "" {
+		return nil, nil
+	}
+
+	selecionados := make(map[string]bool)
+	for _, item := range strings.Split(param, ",") {
+		item = strings.TrimSpace(item)
+		switch item {
+		case "":
+			continue
+		case "full":
+			return nil, nil
+		case "list":
+			for _, c := range perfilList {
+				selecionados[c] = true
+			}
+		case "compact":
+			// Tudo menos as classes CSS (o front calcula a partir dos valores)
+			for c := range eventoCampos {
+				if !strings.HasPrefix(c, "class") {
+					selecionados[c] = true
+				}
+			}
+		default:
+			if _, ok := eventoCampos[item]; !ok {
+				return nil, fmt.Errorf("campo desconhecido em fields: %q", item)
+			}
+			selecionados[item] = true
+		}
+	}
+
+	// idEvento sempre presente (cliente usa como chave)
+	selecionados["idEvento"] = true
+
+	return projecaoDe(selecionados), nil
+}
+
+// projecaoDe monta a projecao a partir de chaves JSON do Evento ja validadas
+func projecaoDe(selecionados map[string]bool) *Projecao {
+	p := &Projecao{}
+	for c := range selecionados {
+		p.indices = append(p.indices, eventoCampos[c])
+	}
+	// Mantem a ordem de declaracao do struct (mesma ordem do JSON completo)
+	sort.Ints(p.indices)
+
+	t := reflect.TypeOf(Evento{})
+	for _, idx := range p.indices {
+		chave, _ := json.Marshal(strings.Split(t.Field(idx).Tag.Get("json"), ",")[0])
+		p.chaves = append(p.chaves, append(chave, ':'))
+	}
+	return p
+}
+
+// eventoProjetado serializa apenas os campos da projecao
+type eventoProjetado struct {
+	evento   *Evento
+	projecao *Projecao
+}
+
+func (e eventoProjetado) MarshalJSON() ([]byte, error) {
+	v := reflect.ValueOf(e.evento).Elem()
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, idx := range e.projecao.indices {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		valor, err := json.Marshal(v.Field(idx).Interface())
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(e.projecao.chaves[i])
+		buf.Write(valor)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// Evento retorna o evento pronto para json.Marshal com apenas os campos da projecao
+func (p *Projecao) Evento(e *Evento) json.Marshaler {
+	return eventoProjetado{evento: e, projecao: p}
+}
+
+// Painel aplica a projecao nos eventos do response do painel
+func (p *Projecao) Painel(r *PainelResponse) interface{} {
+	if p == nil {
+		return r
+	}
+
+	eventos := make([]json.Marshaler, len(r.Eventos))
+	for i, e := range r.Eventos {
+		eventos[i] = p.Evento(e)
+	}
+	return struct {
+		Eventos []json.Marshaler `json:"eventos"`
+		Counts  Counts           `json:"counts"`
+	}{eventos, r.Counts}
+}
+
+// campeonatoProjetado Campeonato com eventos projetados (campo externo prevalece no JSON)
+type campeonatoProjetado struct {
+	*Campeonato
+	Eventos map[string]json.Marshaler `json:"eventos"`
+}
+
+// Home aplica a projecao nos eventos de cada campeonato do response da home
+func (p *Projecao) Home(r *HomeResponse) interface{} {
+	if p == nil {
+		return r
+	}
+
+	campeonatos := make([]campeonatoProjetado, len(r.Campeonatos))
+	for i, c := range r.Campeonatos {
+		eventos := make(map[string]json.Marshaler, len(c.Eventos))
+		for k, e := range c.Eventos {
+			eventos[k] = p.Evento(e)
+		}
+		campeonatos[i] = campeonatoProjetado{Campeonato: c, Eventos: eventos}
+	}
+	return struct {
+		Campeonatos []campeonatoProjetado `json:"campeonatos"`
+		Counts      Counts                `json:"counts"`
+	}{campeonatos, r.Counts}
+}
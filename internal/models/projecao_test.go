@@ -0,0 +1,55 @@
+package models
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestParseProjecao_CampoDesconhecido(t *testing.T) {
+	if _, err := ParseProjecao("list,timeCasaa"); err == nil {
+		t.Error("Campo inexistente deveria retornar erro")
+	}
+	if p, err := ParseProjecao("full"); err != nil || p != nil {
+		t.Errorf("Perfil full deveria retornar projecao nil, recebeu %v %v", p, err)
+	}
+}
+
+func TestProjecao_PainelApenasCamposPedidos(t *testing.T) {
+	p, err := ParseProjecao("timeCasa,oddTimeCasa")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gol := 2
+	evento := &Evento{IdEvento: 10, TimeCasa: "Flamengo", OddTimeCasa: "1.50", TimeFora: "Vasco", GolTimeCasaFt: &gol}
+	data, err := json.Marshal(p.Painel(&PainelResponse{Eventos: []*Evento{evento}, Counts: Counts{Total: 1}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	esperado := `{"eventos":[{"idEvento":10,"timeCasa":"Flamengo","oddTimeCasa":"1.50"}],"counts":{"live":0,"total":1,"gols":0}}`
+	if string(data) != esperado {
+		t.Errorf("JSON inesperado:\n%s\nesperado:\n%s", data, esperado)
+	}
+}
+
+func TestProjecao_CompactSemClassesNaoLiberaCamposFree(t *testing.T) {
+	p, err := ParseProjecao("compact")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	evento := (&Evento{IdEvento: 1, ClassOddTimeCasa: "verde", PosseBolaTimeCasa: "60"}).FiltrarParaFree()
+	data, err := json.Marshal(p.Evento(evento))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(string(data), `"class`) {
+		t.Error("Perfil compact nao deveria conter campos class*")
+	}
+	if strings.Contains(string(data), `"60"`) {
+		t.Error("Projecao nao pode liberar estatistica zerada pelo filtro free")
+	}
+}
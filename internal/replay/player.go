@@ -0,0 +1,132 @@
+package replay
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// Player reproduz o arquivo em tempo real (speed 1) ou acelerado, expondo o estado
+// das chaves no instante atual da reproducao
+type Player struct {
+	path  string
+	speed float64
+	loop  bool
+
+	mu     sync.RWMutex
+	estado *Estado
+
+	stop chan struct{}
+}
+
+// NewPlayer abre o arquivo, aplica o primeiro frame e inicia a reproducao em background
+func NewPlayer(path string, speed float64, loop bool) (*Player, error) {
+	if speed <= 0 {
+		return nil, fmt.Errorf("replay: velocidade deve ser positiva: %v", speed)
+	}
+	f, reader, err := abrir(path)
+	if err != nil {
+		return nil, err
+	}
+	primeiro, err := reader.Next()
+	if err != nil {
+		f.Close()
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("replay: %s sem frames", path)
+		}
+		return nil, err
+	}
+
+	p := &Player{path: path, speed: speed, loop: loop, estado: novoEstado(), stop: make(chan struct{})}
+	p.estado.aplicar(primeiro)
+	log.Printf("Replay: %s gravado em %s, reproduzindo a %vx", path, reader.Header.StartedAt.Format(time.RFC3339), speed)
+
+	go p.run(f, reader, primeiro.At)
+	return p, nil
+}
+
+func abrir(path string) (*os.File, *Reader, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	reader, err := NewReader(f)
+	if err != nil {
+		f.Close()
+		return nil, nil, err
+	}
+	return f, reader, nil
+}
+
+// run aplica cada frame quando chega a sua hora (relativa ao primeiro frame, dividida pela velocidade)
+func (p *Player) run(f *os.File, reader *Reader, base time.Time) {
+	inicio := time.Now()
+	for {
+		frame, err := reader.Next()
+		if err != nil {
+			f.Close()
+			if !errors.Is(err, io.EOF) {
+				log.Printf("Replay: erro ao ler %s: %v", p.path, err)
+			}
+			if !p.loop {
+				log.Printf("Replay: fim de %s (mantendo o ultimo estado)", p.path)
+				return
+			}
+			if f, reader, err = abrir(p.path); err != nil {
+				log.Printf("Replay: erro ao reabrir %s: %v", p.path, err)
+				return
+			}
+			log.Printf("Replay: fim de %s, reiniciando", p.path)
+			p.mu.Lock()
+			p.estado = novoEstado()
+			p.mu.Unlock()
+			inicio, base = time.Now(), time.Time{}
+			continue
+		}
+		if base.IsZero() {
+			base = frame.At
+		}
+
+		due := inicio.Add(time.Duration(float64(frame.At.Sub(base)) / p.speed))
+		select {
+		case <-time.After(time.Until(due)):
+		case <-p.stop:
+			f.Close()
+			return
+		}
+
+		p.mu.Lock()
+		p.estado.aplicar(frame)
+		p.mu.Unlock()
+	}
+}
+
+// Eventos JSON dos eventos no instante atual da reproducao
+func (p *Player) Eventos() string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.estado.Eventos
+}
+
+// Oraculo JSON do oraculo do jogo no instante atual ("" = jogo fora do Redis)
+func (p *Player) Oraculo(idWilliamhill string) string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.estado.Oraculos[idWilliamhill]
+}
+
+// Posicao horario da gravacao que esta sendo reproduzido
+func (p *Player) Posicao() time.Time {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.estado.At
+}
+
+// Stop encerra a reproducao
+func (p *Player) Stop() {
+	close(p.stop)
+}
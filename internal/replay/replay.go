@@ -0,0 +1,152 @@
+// Package replay grava e reproduz as chaves do Redis lidas pelo servidor (JSON dos eventos
+// e oraculo-cache:idJogo-*), para reproduzir incidentes e rodadas inteiras localmente
+// Arquivo: gzip de JSON lines; a primeira linha e o Header e as demais sao Frames com
+// apenas o que mudou desde o frame anterior
+package replay
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Version versao do formato do arquivo
+const Version = 1
+
+// Header primeira linha do arquivo
+type Header struct {
+	Version    int       `json:"version"`
+	StartedAt  time.Time `json:"startedAt"`
+	EventosKey string    `json:"eventosKey"`
+	IntervalMs int64     `json:"intervalMs"` // intervalo de captura
+}
+
+// Frame mudancas capturadas em um instante
+type Frame struct {
+	At        time.Time         `json:"at"`
+	Eventos   *string           `json:"eventos,omitempty"`   // JSON dos eventos (nil = sem mudanca)
+	Oraculos  map[string]string `json:"oraculos,omitempty"`  // idWilliamhill -> JSON do oraculo (novo ou alterado)
+	Removidos []string          `json:"removidos,omitempty"` // oraculos que sairam do Redis
+}
+
+// Estado conteudo das chaves apos aplicar os frames ate um instante
+type Estado struct {
+	At       time.Time
+	Eventos  string
+	Oraculos map[string]string
+}
+
+func novoEstado() *Estado {
+	return &Estado{Oraculos: make(map[string]string)}
+}
+
+// aplicar atualiza o estado com as mudancas do frame
+func (e *Estado) aplicar(f *Frame) {
+	e.At = f.At
+	if f.Eventos != nil {
+		e.Eventos = *f.Eventos
+	}
+	for id, data := range f.Oraculos {
+		e.Oraculos[id] = data
+	}
+	for _, id := range f.Removidos {
+		delete(e.Oraculos, id)
+	}
+}
+
+// Writer grava os frames, apenas com o que mudou em relacao a captura anterior
+type Writer struct {
+	gz    *gzip.Writer
+	enc   *json.Encoder
+	atual *Estado
+
+	Frames int
+}
+
+// NewWriter escreve o header em w (o chamador fecha w depois do Close)
+func NewWriter(w io.Writer, h Header) (*Writer, error) {
+	h.Version = Version
+	gz := gzip.NewWriter(w)
+	writer := &Writer{gz: gz, enc: json.NewEncoder(gz), atual: novoEstado()}
+	if err := writer.enc.Encode(&h); err != nil {
+		return nil, err
+	}
+	return writer, gz.Flush()
+}
+
+// Gravar registra a captura; sem mudancas nao escreve nada
+// O flush a cada frame deixa o arquivo legivel ate o ultimo frame se o gravador morrer
+func (w *Writer) Gravar(at time.Time, eventos string, oraculos map[string]string) error {
+	frame := Frame{At: at}
+	if eventos != w.atual.Eventos || w.Frames == 0 {
+		frame.Eventos = &eventos
+	}
+	for id, data := range oraculos {
+		if w.atual.Oraculos[id] != data {
+			if frame.Oraculos == nil {
+				frame.Oraculos = make(map[string]string)
+			}
+			frame.Oraculos[id] = data
+		}
+	}
+	for id := range w.atual.Oraculos {
+		if _, ok := oraculos[id]; !ok {
+			frame.Removidos = append(frame.Removidos, id)
+		}
+	}
+	if frame.Eventos == nil && frame.Oraculos == nil && frame.Removidos == nil {
+		return nil
+	}
+
+	if err := w.enc.Encode(&frame); err != nil {
+		return err
+	}
+	w.atual.aplicar(&frame)
+	w.Frames++
+	return w.gz.Flush()
+}
+
+// Close finaliza o gzip
+func (w *Writer) Close() error {
+	return w.gz.Close()
+}
+
+// Reader le o header e os frames em ordem
+type Reader struct {
+	Header Header
+	dec    *json.Decoder
+}
+
+// NewReader le e valida o header
+func NewReader(r io.Reader) (*Reader, error) {
+	gz, err := gzip.NewReader(bufio.NewReader(r))
+	if err != nil {
+		return nil, err
+	}
+	reader := &Reader{dec: json.NewDecoder(gz)}
+	if err := reader.dec.Decode(&reader.Header); err != nil {
+		return nil, fmt.Errorf("replay: header invalido: %w", err)
+	}
+	if reader.Header.Version != Version {
+		return nil, fmt.Errorf("replay: versao %d nao suportada", reader.Header.Version)
+	}
+	return reader, nil
+}
+
+// Next retorna o proximo frame (io.EOF no fim)
+// Arquivo truncado (gravador encerrado sem Close) termina no ultimo frame completo
+func (r *Reader) Next() (*Frame, error) {
+	var frame Frame
+	err := r.dec.Decode(&frame)
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, io.EOF
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &frame, nil
+}
@@ -0,0 +1,97 @@
+package replay
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func gravarTeste(t *testing.T, capturas []map[string]string, eventos []string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "gravacao.jsonl.gz")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	inicio := time.Now()
+	w, err := NewWriter(f, Header{StartedAt: inicio, EventosKey: "eventos-painel-json"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, oraculos := range capturas {
+		if err := w.Gravar(inicio.Add(time.Duration(i)*time.Second), eventos[i], oraculos); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestWriter_Deltas(t *testing.T) {
+	path := gravarTeste(t,
+		[]map[string]string{{"1": "a", "2": "b"}, {"1": "a", "2": "b"}, {"1": "a2"}},
+		[]string{"[1]", "[1]", "[1]"},
+	)
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	r, err := NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Header.Version != Version || r.Header.EventosKey != "eventos-painel-json" {
+		t.Fatalf("header = %+v", r.Header)
+	}
+
+	// Captura repetida nao gera frame; a terceira so traz o oraculo alterado e o removido
+	primeiro, err := r.Next()
+	if err != nil || primeiro.Eventos == nil || len(primeiro.Oraculos) != 2 {
+		t.Fatalf("primeiro frame = %+v, %v", primeiro, err)
+	}
+	segundo, err := r.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if segundo.Eventos != nil || segundo.Oraculos["1"] != "a2" || len(segundo.Removidos) != 1 || segundo.Removidos[0] != "2" {
+		t.Fatalf("segundo frame = %+v", segundo)
+	}
+	if _, err := r.Next(); err == nil {
+		t.Fatal("esperava fim do arquivo")
+	}
+}
+
+func TestPlayer_Acelerado(t *testing.T) {
+	path := gravarTeste(t,
+		[]map[string]string{{"1": "a"}, {"1": "b"}},
+		[]string{"[1]", "[1,2]"},
+	)
+
+	// 1s de gravacao a 100x = 10ms
+	p, err := NewPlayer(path, 100, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Stop()
+	if p.Eventos() != "[1]" || p.Oraculo("1") != "a" {
+		t.Fatalf("estado inicial = %q %q", p.Eventos(), p.Oraculo("1"))
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for p.Eventos() != "[1,2]" {
+		if time.Now().After(deadline) {
+			t.Fatal("segundo frame nao foi aplicado")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if p.Oraculo("1") != "b" {
+		t.Fatalf("oraculo = %q, esperava b", p.Oraculo("1"))
+	}
+}
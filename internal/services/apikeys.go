@@ -0,0 +1,156 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// apiKeyLocalTTL tempo que uma chave fica no cache local antes de reler do Redis
+const apiKeyLocalTTL = 60 * time.Second
+
+// apiKeyCacheMax limite de entradas do cache local (chaves inexistentes deixam de ser cacheadas)
+const apiKeyCacheMax = 10000
+
+// Grupos de campos liberados para parceiros
+const (
+	GrupoOdds         = "odds"         // odds, classes de odds e links das casas
+	GrupoEstatisticas = "estatisticas" // campos de assinante (SL, alertas, estatisticas, IA)
+)
+
+// APIKey chave de parceiro B2B com escopo de acesso
+// Armazenada no Redis em sse-api-key:{sha256(chave)} como JSON (cadastrada pelo Laravel)
+type APIKey struct {
+	Id          string   `json:"-"` // sha256 da chave (nunca a chave em si)
+	Nome        string   `json:"nome"`
+	Ativo       bool     `json:"ativo"`
+	Endpoints   []string `json:"endpoints"`   // painel, home, oraculo
+	Campeonatos []string `json:"campeonatos"` // IdCampeonatoUnico permitidos (vazio = todos)
+	Grupos      []string `json:"grupos"`      // odds, estatisticas
+	Rate        float64  `json:"rate"`        // novas conexoes por minuto (0 = sem limite)
+	Burst       int      `json:"burst"`
+	MaxConexoes int      `json:"maxConexoes"` // streams simultaneos (0 = sem limite)
+}
+
+// PermiteEndpoint verifica se a chave pode acessar o endpoint
+func (k *APIKey) PermiteEndpoint(endpoint string) bool {
+	for _, e := range k.Endpoints {
+		if e == endpoint {
+			return true
+		}
+	}
+	return false
+}
+
+// TemGrupo verifica se o grupo de campos esta liberado
+func (k *APIKey) TemGrupo(grupo string) bool {
+	for _, g := range k.Grupos {
+		if g == grupo {
+			return true
+		}
+	}
+	return false
+}
+
+// CampeonatosPermitidos retorna o conjunto de campeonatos liberados (nil = todos)
+func (k *APIKey) CampeonatosPermitidos() map[string]bool {
+	if len(k.Campeonatos) == 0 {
+		return nil
+	}
+	permitidos := make(map[string]bool, len(k.Campeonatos))
+	for _, c := range k.Campeonatos {
+		permitidos[c] = true
+	}
+	return permitidos
+}
+
+// apiKeyCacheEntry entrada do cache local (key nil = chave inexistente)
+type apiKeyCacheEntry struct {
+	key      *APIKey
+	cachedAt time.Time
+}
+
+var apiKeyCache = struct {
+	sync.RWMutex
+	m         map[string]*apiKeyCacheEntry
+	lastSweep time.Time
+}{m: make(map[string]*apiKeyCacheEntry)}
+
+// HashAPIKey retorna o identificador da chave (sha256 em hex)
+func HashAPIKey(rawKey string) string {
+	sum := sha256.Sum256([]byte(rawKey))
+	return hex.EncodeToString(sum[:])
+}
+
+// GetAPIKey busca a chave do parceiro (cache local de 60s sobre o Redis)
+// Retorna nil se a chave nao existe
+func GetAPIKey(rawKey string) (*APIKey, error) {
+	id := HashAPIKey(rawKey)
+
+	apiKeyCache.RLock()
+	cached, exists := apiKeyCache.m[id]
+	apiKeyCache.RUnlock()
+
+	if exists && time.Since(cached.cachedAt) < apiKeyLocalTTL {
+		return cached.key, nil
+	}
+
+	key, err := getAPIKeyFromRedis(id)
+	if err != nil {
+		// Cache antigo so vale para chaves existentes (inexistente nao fica alem do TTL)
+		if exists && cached.key != nil {
+			log.Printf("APIKey: Erro ao buscar chave (usando cache antigo): %v", err)
+			return cached.key, nil
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	apiKeyCache.Lock()
+	// Remove entradas expiradas a cada TTL para o mapa nao crescer com chaves aleatorias
+	if now.Sub(apiKeyCache.lastSweep) > apiKeyLocalTTL {
+		for k, e := range apiKeyCache.m {
+			if now.Sub(e.cachedAt) >= apiKeyLocalTTL {
+				delete(apiKeyCache.m, k)
+			}
+		}
+		apiKeyCache.lastSweep = now
+	}
+	if key != nil || len(apiKeyCache.m) < apiKeyCacheMax {
+		apiKeyCache.m[id] = &apiKeyCacheEntry{key: key, cachedAt: now}
+	} else {
+		delete(apiKeyCache.m, id)
+	}
+	apiKeyCache.Unlock()
+
+	return key, nil
+}
+
+// getAPIKeyFromRedis le a chave sse-api-key:{id}
+func getAPIKeyFromRedis(id string) (*APIKey, error) {
+	if rdb == nil {
+		return nil, fmt.Errorf("redis nao inicializado")
+	}
+
+	data, err := cacheGet("sse-api-key:" + id)
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar api key: %w", err)
+	}
+
+	var key APIKey
+	if err := json.Unmarshal([]byte(data), &key); err != nil {
+		return nil, fmt.Errorf("erro ao decodificar api key: %w", err)
+	}
+	key.Id = id
+
+	return &key, nil
+}
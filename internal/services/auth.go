@@ -1,86 +1,231 @@
 package services
 
 import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"sync/atomic"
 	"time"
 
 	"github.com/redis/go-redis/v9"
+	"radarfutebol-sse/internal/config"
 )
 
-// authCacheTTL tempo de vida do cache no Redis (5 minutos)
-const authCacheTTL = 5 * time.Minute
+// authCacheTTL tempo de vida do cache no Redis (auth.cache_ttl)
+var authCacheTTL = 5 * time.Minute
+
+// authNegativeCacheTTL tempo de vida do cache de tokens invalidos (evita brute-force no MySQL)
+var authNegativeCacheTTL = 30 * time.Second
+
+// authAnonymousFallback MySQL indisponivel: conecta como anonimo em vez de Unavailable (auth.anonymous_fallback)
+var authAnonymousFallback atomic.Bool
 
 // authCacheEntry entrada do cache de autenticacao
 type authCacheEntry struct {
 	IdUsuario   int  `json:"id_usuario"`
 	IsAssinante bool `json:"is_assinante"`
 	TeamId      int  `json:"team_id"`
+	Invalid     bool `json:"invalid,omitempty"` // Token nao existe (cache negativo)
 }
 
+// authCacheSecret chave HMAC para derivar a chave do cache a partir do token
+var authCacheSecret []byte
+
 // InitAuthCache inicializa o cache de autenticacao
-func InitAuthCache() {
+// Sem AUTH_CACHE_SECRET deriva a chave das senhas do Redis/MySQL e do segredo JWT: estavel entre
+// restarts e no upgrade (SIGUSR2), senao o cache se perde e todos os tokens voltam ao MySQL
+func InitAuthCache(cfg *config.Config) {
+	authCacheTTL = cfg.Auth.CacheTTL
+	jwtRevocationTTL = cfg.Auth.JWTRevocationTTL
+	authNegativeCacheTTL = cfg.Auth.NegativeCacheTTL
+	SetAnonymousFallback(cfg.Auth.AnonymousFallback)
+
+	if cfg.Auth.CacheSecret != "" {
+		authCacheSecret = []byte(cfg.Auth.CacheSecret)
+	} else {
+		authCacheSecret = derivarCacheSecret(cfg)
+		log.Println("Aviso: AUTH_CACHE_SECRET nao definido, chave do cache derivada das senhas do Redis/MySQL (defina em producao)")
+	}
 	log.Println("Cache de autenticacao inicializado (usando Redis)")
 }
 
+// derivarCacheSecret chave HMAC estavel a partir dos segredos da configuracao
+// Muda apenas se alguma das senhas mudar (o cache antigo expira pelo TTL)
+func derivarCacheSecret(cfg *config.Config) []byte {
+	mac := hmac.New(sha256.New, []byte("sse-auth-cache"))
+	for _, segredo := range []string{cfg.Redis.Password, cfg.MySQL.Password, cfg.Auth.JWTSecret} {
+		mac.Write([]byte(segredo))
+		mac.Write([]byte{0})
+	}
+	return mac.Sum(nil)
+}
+
+// SetAnonymousFallback liga/desliga o acesso anonimo com MySQL indisponivel (recarregavel)
+func SetAnonymousFallback(enabled bool) {
+	authAnonymousFallback.Store(enabled)
+}
+
 // AuthResult resultado da validacao de token
 type AuthResult struct {
 	IdUsuario   int
 	IsValid     bool
 	IsAssinante bool
 	TeamId      int
+	Unavailable bool // MySQL indisponivel e sem fallback anonimo: token nao pode ser verificado agora
+}
+
+// authAnonimo usuario anonimo (sem token)
+var authAnonimo = AuthResult{IsValid: true}
+
+// Autenticador valida tokens: JWT localmente, os demais pelo AuthStore (cache + MySQL)
+type Autenticador struct {
+	store AuthStore
+}
+
+// NewAutenticador cria um autenticador sobre o store informado
+func NewAutenticador(store AuthStore) *Autenticador {
+	return &Autenticador{store: store}
+}
+
+// autenticador padrao (Redis + MySQL), usado por ValidateToken e RevalidateToken
+var autenticador = NewAutenticador(authRedisMySQL{})
+
+// GetAutenticador retorna o autenticador padrao (Redis + MySQL)
+func GetAutenticador() *Autenticador {
+	return autenticador
+}
+
+// ValidateToken valida o token com o autenticador padrao
+func ValidateToken(token string) AuthResult {
+	return autenticador.ValidateToken(token)
+}
+
+// RevalidateToken revalida o token com o autenticador padrao
+func RevalidateToken(token string) AuthResult {
+	return autenticador.RevalidateToken(token)
 }
 
 // ValidateToken valida o token e retorna dados do usuario
 // Busca apenas pelo token, sem precisar do idUsuario
-// Usa Redis como cache para evitar consultas frequentes ao MySQL
-func ValidateToken(token string) AuthResult {
-	// Sem token - usuario anonimo
+// Usa o cache do store para evitar consultas frequentes ao MySQL
+// JWTs assinados (se configurado) sao validados localmente, sem MySQL
+func (a *Autenticador) ValidateToken(token string) AuthResult {
+	// Sem token - usuario anonimo (sempre valido)
 	if token == "" {
-		return AuthResult{
-			IdUsuario:   0,
-			IsValid:     true, // Anonimo sempre valido
-			IsAssinante: false,
-			TeamId:      0,
-		}
+		return authAnonimo
 	}
 
-	// Verifica cache no Redis primeiro
-	cacheKey := getCacheKey(token)
-	if cached := getAuthFromRedis(cacheKey); cached != nil {
-		return AuthResult{
-			IdUsuario:   cached.IdUsuario,
-			IsValid:     true,
-			IsAssinante: cached.IsAssinante,
-			TeamId:      cached.TeamId,
+	if jwtAuth != nil && looksLikeJWT(token) {
+		result, iat := validateJWT(token)
+		if result.IsValid {
+			result = a.aplicarRevogacao(result, iat)
 		}
+		return result
+	}
+
+	// Verifica cache primeiro
+	if cached, ok := a.store.LerCache(token); ok {
+		return cached
+	}
+
+	result := a.refreshToken(token)
+	if result.Unavailable && authAnonymousFallback.Load() {
+		return authAnonimo
+	}
+	return result
+}
+
+// RevalidateToken consulta o MySQL ignorando o cache e atualiza o cache
+// Usado quando o plano do usuario muda com streams abertos (Unavailable = manter o estado atual)
+func (a *Autenticador) RevalidateToken(token string) AuthResult {
+	if token == "" || (jwtAuth != nil && looksLikeJWT(token)) {
+		return a.ValidateToken(token)
 	}
+	return a.refreshToken(token)
+}
 
+// refreshToken consulta o MySQL e grava o resultado no cache
+func (a *Autenticador) refreshToken(token string) AuthResult {
 	// Consulta MySQL
-	result := queryToken(token)
+	result := a.store.ConsultarToken(token)
 
-	// Se token valido, salva no cache Redis
+	// Se token valido, salva no cache
 	if result.IsValid && result.IdUsuario > 0 {
-		saveAuthToRedis(cacheKey, &authCacheEntry{
-			IdUsuario:   result.IdUsuario,
-			IsAssinante: result.IsAssinante,
-			TeamId:      result.TeamId,
-		})
+		a.store.GravarCache(token, result, authCacheTTL)
+	}
+
+	// Token inexistente: cache negativo curto para tentativas repetidas nao irem ao MySQL
+	if !result.IsValid && !result.Unavailable {
+		a.store.GravarCache(token, result, authNegativeCacheTTL)
 	}
 
 	return result
 }
 
+// InvalidarCache remove do cache os tokens do usuario (revogacao ou mudanca de plano)
+func (a *Autenticador) InvalidarCache(idUsuario int) {
+	a.store.InvalidarCache(idUsuario)
+}
+
+// RegistrarRevogacao grava a revogacao do usuario: JWTs emitidos antes dela passam a ser recusados
+// Apenas Redis (pode rodar no loop do pub/sub); sem JWT configurado nao ha o que registrar
+func (a *Autenticador) RegistrarRevogacao(idUsuario int) {
+	if jwtAuth == nil {
+		return
+	}
+	a.store.GravarRevogacao(idUsuario, RevogacaoJWT{Em: time.Now().Unix(), Revogado: true}, jwtRevocationTTL)
+}
+
+// RegistrarMudancaDePlano consulta o tier atual no MySQL e grava para os JWTs emitidos antes da mudanca
+// Consulta o MySQL: chamar fora do loop do pub/sub
+func (a *Autenticador) RegistrarMudancaDePlano(idUsuario int) {
+	if jwtAuth == nil {
+		return
+	}
+	em := time.Now().Unix()
+
+	result := a.store.ConsultarUsuario(idUsuario)
+	if result.Unavailable {
+		return // MySQL fora: JWTs seguem com o tier dos claims
+	}
+
+	// Revogacao prevalece ate expirar: os JWTs anteriores a ela nao voltam a valer
+	if atual, ok := a.store.LerRevogacao(idUsuario); ok && atual.Revogado {
+		return
+	}
+
+	rev := RevogacaoJWT{Em: em, Revogado: !result.IsValid, IsAssinante: result.IsAssinante, TeamId: result.TeamId}
+	a.store.GravarRevogacao(idUsuario, rev, jwtRevocationTTL)
+}
+
+// aplicarRevogacao aplica ao JWT a ultima revogacao/mudanca de plano do usuario, se o token for anterior a ela
+// Resolucao de segundos (iat); token sem iat conta como anterior. Redis fora: vale o que esta no token
+func (a *Autenticador) aplicarRevogacao(result AuthResult, iat int64) AuthResult {
+	rev, ok := a.store.LerRevogacao(result.IdUsuario)
+	if !ok || iat >= rev.Em {
+		return result
+	}
+	if rev.Revogado {
+		return AuthResult{IdUsuario: result.IdUsuario, IsValid: false}
+	}
+	result.IsAssinante = rev.IsAssinante
+	result.TeamId = rev.TeamId
+	return result
+}
+
 // getCacheKey gera a chave do cache para o token
+// Usa HMAC-SHA256 do token completo: sem colisao entre tokens com mesmo prefixo
+// e sem expor o token em texto puro no Redis
 func getCacheKey(token string) string {
-	// Usa apenas os primeiros 20 caracteres do token para a chave
-	tokenPrefix := token
-	if len(token) > 20 {
-		tokenPrefix = token[:20]
-	}
-	return fmt.Sprintf("sse-auth:%s", tokenPrefix)
+	mac := hmac.New(sha256.New, authCacheSecret)
+	mac.Write([]byte(token))
+	return fmt.Sprintf("sse-auth:%s", hex.EncodeToString(mac.Sum(nil)))
 }
 
 // getAuthFromRedis busca dados de autenticacao do Redis
@@ -89,12 +234,14 @@ func getAuthFromRedis(key string) *authCacheEntry {
 		return nil
 	}
 
-	data, err := rdb.Get(ctx, key).Result()
+	data, err := cacheGet(key)
 	if err == redis.Nil {
 		return nil // Chave nao existe
 	}
 	if err != nil {
-		log.Printf("Auth: Erro ao buscar cache Redis: %v", err)
+		if !errors.Is(err, ErrCircuitOpen) {
+			log.Printf("Auth: Erro ao buscar cache Redis: %v", err)
+		}
 		return nil
 	}
 
@@ -108,7 +255,7 @@ func getAuthFromRedis(key string) *authCacheEntry {
 }
 
 // saveAuthToRedis salva dados de autenticacao no Redis
-func saveAuthToRedis(key string, entry *authCacheEntry) {
+func saveAuthToRedis(key string, entry *authCacheEntry, ttl time.Duration) {
 	if rdb == nil {
 		return
 	}
@@ -119,28 +266,69 @@ func saveAuthToRedis(key string, entry *authCacheEntry) {
 		return
 	}
 
-	if err := rdb.Set(ctx, key, data, authCacheTTL).Err(); err != nil {
-		log.Printf("Auth: Erro ao salvar cache Redis: %v", err)
+	err = redisBreaker.Do(func() error {
+		return rdb.Set(ctx, key, data, ttl).Err()
+	})
+	if err != nil {
+		if !errors.Is(err, ErrCircuitOpen) {
+			log.Printf("Auth: Erro ao salvar cache Redis: %v", err)
+		}
+		return
+	}
+
+	// Indexa a chave pelo usuario para permitir invalidar o cache na revogacao
+	if entry.IdUsuario > 0 {
+		userKey := getUserAuthKeysKey(entry.IdUsuario)
+		pipe := rdb.Pipeline()
+		pipe.SAdd(ctx, userKey, key)
+		pipe.Expire(ctx, userKey, ttl)
+		if _, err := pipe.Exec(ctx); err != nil {
+			log.Printf("Auth: Erro ao indexar cache do usuario %d: %v", entry.IdUsuario, err)
+		}
+	}
+}
+
+// getUserAuthKeysKey chave do set com as chaves sse-auth: de um usuario
+func getUserAuthKeysKey(idUsuario int) string {
+	return fmt.Sprintf("sse-auth-usuario:%d", idUsuario)
+}
+
+// InvalidateAuthCache remove do Redis todas as entradas de cache de auth do usuario
+func InvalidateAuthCache(idUsuario int) {
+	if rdb == nil || idUsuario <= 0 {
+		return
+	}
+
+	userKey := getUserAuthKeysKey(idUsuario)
+	keys, err := rdb.SMembers(ctx, userKey).Result()
+	if err != nil {
+		log.Printf("Auth: Erro ao buscar cache do usuario %d: %v", idUsuario, err)
+		return
+	}
+
+	keys = append(keys, userKey)
+	if err := rdb.Del(ctx, keys...).Err(); err != nil {
+		log.Printf("Auth: Erro ao invalidar cache do usuario %d: %v", idUsuario, err)
 	}
 }
 
 // queryToken consulta o banco para validar token (busca apenas pelo token)
+// MySQL fora, lento (mysql.query_timeout) ou com circuito aberto retorna Unavailable
 func queryToken(token string) AuthResult {
 	if db == nil {
-		log.Printf("Auth: MySQL nao disponivel, tratando como anonimo")
-		return AuthResult{
-			IdUsuario:   0,
-			IsValid:     true, // Se nao tem MySQL, permite mas como anonimo
-			IsAssinante: false,
-			TeamId:      0,
-		}
+		log.Printf("Auth: MySQL nao disponivel")
+		return AuthResult{Unavailable: true}
 	}
 
 	var idUsuario, teamId int
-	err := db.QueryRow(
-		"SELECT id, current_team_id FROM users WHERE token_access = ?",
-		token,
-	).Scan(&idUsuario, &teamId)
+	err := mysqlBreaker.Do(func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), mysqlQueryTimeout)
+		defer cancel()
+		return db.QueryRowContext(ctx,
+			"SELECT id, current_team_id FROM users WHERE token_access = ?",
+			token,
+		).Scan(&idUsuario, &teamId)
+	})
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -153,14 +341,11 @@ func queryToken(token string) AuthResult {
 				TeamId:      0,
 			}
 		}
-		// Erro de conexao - trata como anonimo para nao bloquear
-		log.Printf("Auth: Erro ao consultar token: %v", err)
-		return AuthResult{
-			IdUsuario:   0,
-			IsValid:     true,
-			IsAssinante: false,
-			TeamId:      0,
+		// Erro de conexao - anonimo so se auth.anonymous_fallback (decidido em ValidateToken)
+		if !errors.Is(err, ErrCircuitOpen) {
+			log.Printf("Auth: Erro ao consultar token: %v", err)
 		}
+		return AuthResult{Unavailable: true}
 	}
 
 	// Token valido - verifica se é assinante (team_id 1-4)
@@ -174,6 +359,41 @@ func queryToken(token string) AuthResult {
 	}
 }
 
+// queryUsuario consulta o tier atual do usuario pelo id (IsValid false = usuario removido)
+func queryUsuario(idUsuario int) AuthResult {
+	if db == nil {
+		log.Printf("Auth: MySQL nao disponivel")
+		return AuthResult{Unavailable: true}
+	}
+
+	var teamId int
+	err := mysqlBreaker.Do(func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), mysqlQueryTimeout)
+		defer cancel()
+		return db.QueryRowContext(ctx,
+			"SELECT current_team_id FROM users WHERE id = ?",
+			idUsuario,
+		).Scan(&teamId)
+	})
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return AuthResult{IdUsuario: idUsuario, IsValid: false}
+		}
+		if !errors.Is(err, ErrCircuitOpen) {
+			log.Printf("Auth: Erro ao consultar usuario %d: %v", idUsuario, err)
+		}
+		return AuthResult{Unavailable: true}
+	}
+
+	return AuthResult{
+		IdUsuario:   idUsuario,
+		IsValid:     true,
+		IsAssinante: IsAssinanteTeamId(teamId),
+		TeamId:      teamId,
+	}
+}
+
 // IsAssinanteTeamId verifica se um team_id corresponde a assinante
 // Admin Root (1), Admin (2), VIP (3), Assinante (4) = assinante
 // Free (5), outros = nao assinante
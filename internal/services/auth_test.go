@@ -0,0 +1,44 @@
+package services
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"radarfutebol-sse/internal/config"
+)
+
+func TestGetCacheKey_TokensComMesmoPrefixo(t *testing.T) {
+	authCacheSecret = []byte("segredo-teste")
+
+	prefixo := "abcdefghijklmnopqrstuvwxyz"
+	keyA := getCacheKey(prefixo + "-usuario-a")
+	keyB := getCacheKey(prefixo + "-usuario-b")
+
+	if keyA == keyB {
+		t.Error("Tokens com mesmo prefixo nao podem gerar a mesma chave")
+	}
+	if strings.Contains(keyA, prefixo[:20]) {
+		t.Errorf("Chave nao deve conter o token em texto puro: %s", keyA)
+	}
+	if keyA != getCacheKey(prefixo+"-usuario-a") {
+		t.Error("Mesma entrada deve gerar a mesma chave")
+	}
+}
+
+func TestDerivarCacheSecret_EstavelEntreRestarts(t *testing.T) {
+	cfg := config.Defaults()
+	cfg.Redis.Password = "redis"
+	cfg.MySQL.Password = "mysql"
+
+	// Processo novo (restart/upgrade) com a mesma configuracao reaproveita o cache
+	if !bytes.Equal(derivarCacheSecret(cfg), derivarCacheSecret(cfg)) {
+		t.Fatal("Chave derivada deveria ser a mesma entre processos")
+	}
+
+	outra := config.Defaults()
+	outra.Redis.Password = "redismysql"
+	if bytes.Equal(derivarCacheSecret(cfg), derivarCacheSecret(outra)) {
+		t.Error("Senhas diferentes nao podem gerar a mesma chave")
+	}
+}
@@ -0,0 +1,202 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"radarfutebol-sse/internal/config"
+)
+
+// ErrCircuitOpen dependencia com circuito aberto: chamada nem foi feita
+var ErrCircuitOpen = errors.New("circuito aberto")
+
+// Estados do breaker
+const (
+	breakerClosed   = "closed"
+	breakerOpen     = "open"
+	breakerHalfOpen = "half_open"
+)
+
+// Limites compartilhados pelos breakers (breaker.*, recarregaveis)
+var (
+	breakerThreshold   atomic.Int64
+	breakerOpenTimeout atomic.Int64 // time.Duration
+	breakerMaxOpen     atomic.Int64 // time.Duration
+)
+
+func init() {
+	ConfigurarBreakers(config.Defaults().Breaker)
+}
+
+// ConfigurarBreakers aplica falhas seguidas para abrir e o backoff entre sondagens
+func ConfigurarBreakers(cfg config.BreakerConfig) {
+	breakerThreshold.Store(int64(cfg.FailureThreshold))
+	breakerOpenTimeout.Store(int64(cfg.OpenTimeout))
+	breakerMaxOpen.Store(int64(cfg.MaxOpenTimeout))
+}
+
+// Breaker circuit breaker de uma dependencia
+// Apos breaker.failure_threshold falhas seguidas abre e recusa as chamadas na hora (ErrCircuitOpen)
+// Passado o tempo de espera deixa uma unica chamada passar (half-open): sucesso fecha,
+// falha reabre dobrando a espera ate breaker.max_open_timeout
+type Breaker struct {
+	name string
+
+	mu       sync.Mutex
+	state    string
+	falhas   int // seguidas
+	openedAt time.Time
+	espera   time.Duration // espera atual ate a proxima sondagem
+	lastErr  error
+
+	opens    atomic.Int64 // vezes que abriu
+	rejected atomic.Int64 // chamadas recusadas com o circuito aberto
+}
+
+// Breakers por dependencia
+var (
+	redisBreaker = newBreaker("redis")
+	prefsBreaker = newBreaker("redisPrefs")
+	mysqlBreaker = newBreaker("mysql")
+)
+
+// breakers ordem de exibicao no /stats e /health/deps
+var breakers = []*Breaker{redisBreaker, prefsBreaker, mysqlBreaker}
+
+func newBreaker(name string) *Breaker {
+	return &Breaker{name: name, state: breakerClosed}
+}
+
+// allow libera a chamada ou retorna ErrCircuitOpen
+func (b *Breaker) allow(now time.Time) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if now.Sub(b.openedAt) < b.espera {
+			b.rejected.Add(1)
+			return ErrCircuitOpen
+		}
+		// Sondagem: so esta chamada passa ate o resultado
+		b.state = breakerHalfOpen
+		return nil
+	case breakerHalfOpen:
+		b.rejected.Add(1)
+		return ErrCircuitOpen
+	}
+	return nil
+}
+
+// record registra o resultado da chamada liberada
+func (b *Breaker) record(err error, now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err == nil {
+		if b.state != breakerClosed {
+			log.Printf("Breaker %s: dependencia respondeu, circuito fechado", b.name)
+		}
+		b.state, b.falhas, b.espera = breakerClosed, 0, 0
+		return
+	}
+
+	b.falhas++
+	b.lastErr = err
+	switch {
+	case b.state == breakerHalfOpen:
+		// Sondagem falhou: backoff exponencial ate o teto
+		b.espera *= 2
+		if maxOpen := time.Duration(breakerMaxOpen.Load()); b.espera > maxOpen {
+			b.espera = maxOpen
+		}
+	case b.state == breakerClosed && int64(b.falhas) >= breakerThreshold.Load():
+		b.espera = time.Duration(breakerOpenTimeout.Load())
+		b.opens.Add(1)
+	default:
+		return
+	}
+	b.state, b.openedAt = breakerOpen, now
+	log.Printf("Breaker %s: circuito aberto apos %d falhas seguidas, nova tentativa em %v: %v", b.name, b.falhas, b.espera, err)
+}
+
+// Do executa fn protegida pelo breaker e retorna o erro dela sem alterar
+// redis.Nil e sql.ErrNoRows sao respostas normais, nao falhas da dependencia
+func (b *Breaker) Do(fn func() error) error {
+	if err := b.allow(time.Now()); err != nil {
+		return err
+	}
+	err := fn()
+	if errors.Is(err, redis.Nil) || errors.Is(err, sql.ErrNoRows) {
+		b.record(nil, time.Now())
+	} else {
+		b.record(err, time.Now())
+	}
+	return err
+}
+
+// BreakerStatus estado do breaker no /stats e /health/deps
+type BreakerStatus struct {
+	State     string `json:"state"` // closed, open ou half_open
+	Falhas    int    `json:"failures"`
+	RetryInMs int64  `json:"retryInMs,omitempty"` // ate a proxima sondagem (aberto)
+	LastError string `json:"lastError,omitempty"`
+	Opens     int64  `json:"opens"`
+	Rejected  int64  `json:"rejected"`
+}
+
+// Status retorna o estado atual do breaker
+func (b *Breaker) Status() BreakerStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	status := BreakerStatus{
+		State:    b.state,
+		Falhas:   b.falhas,
+		Opens:    b.opens.Load(),
+		Rejected: b.rejected.Load(),
+	}
+	if b.state == breakerOpen {
+		if retry := b.espera - time.Since(b.openedAt); retry > 0 {
+			status.RetryInMs = retry.Milliseconds()
+		}
+	}
+	if b.lastErr != nil {
+		status.LastError = b.lastErr.Error()
+	}
+	return status
+}
+
+// BreakersStatus estado de todos os breakers
+func BreakersStatus() map[string]BreakerStatus {
+	result := make(map[string]BreakerStatus, len(breakers))
+	for _, b := range breakers {
+		result[b.name] = b.Status()
+	}
+	return result
+}
+
+// cacheGet GET no Redis principal protegido pelo breaker (redis.Nil se a chave nao existir)
+func cacheGet(key string) (string, error) {
+	var data string
+	err := redisBreaker.Do(func() (err error) {
+		data, err = rdb.Get(ctx, key).Result()
+		return err
+	})
+	return data, err
+}
+
+// prefsGet GET no Redis de preferencias protegido pelo breaker
+func prefsGet(key string) (string, error) {
+	var data string
+	err := prefsBreaker.Do(func() (err error) {
+		data, err = rdbPrefs.Get(ctx, key).Result()
+		return err
+	})
+	return data, err
+}
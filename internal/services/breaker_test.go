@@ -0,0 +1,66 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"radarfutebol-sse/internal/config"
+)
+
+func TestBreaker_AbreSondaEFecha(t *testing.T) {
+	ConfigurarBreakers(config.BreakerConfig{FailureThreshold: 3, OpenTimeout: time.Second, MaxOpenTimeout: 3 * time.Second})
+	defer ConfigurarBreakers(config.Defaults().Breaker)
+
+	b := newBreaker("teste")
+	falha := errors.New("timeout")
+	agora := time.Now()
+
+	// Chave inexistente nao e falha da dependencia
+	for i := 0; i < 5; i++ {
+		b.Do(func() error { return redis.Nil })
+	}
+	if st := b.Status(); st.State != breakerClosed || st.Falhas != 0 {
+		t.Fatalf("redis.Nil nao deveria contar como falha: %+v", st)
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := b.allow(agora); err != nil {
+			t.Fatalf("Circuito fechado deveria liberar: %v", err)
+		}
+		b.record(falha, agora)
+	}
+	if err := b.allow(agora.Add(500 * time.Millisecond)); err != ErrCircuitOpen {
+		t.Fatalf("Apos 3 falhas esperava circuito aberto, obteve %v", err)
+	}
+
+	// Sondagem: uma chamada passa, as demais sao recusadas ate o resultado
+	sonda := agora.Add(time.Second)
+	if err := b.allow(sonda); err != nil {
+		t.Fatalf("Apos open_timeout a sondagem deveria passar: %v", err)
+	}
+	if err := b.allow(sonda); err != ErrCircuitOpen {
+		t.Error("Com sondagem em andamento as demais chamadas devem ser recusadas")
+	}
+
+	// Sondagem falhou: espera dobra (2s), limitada a max_open_timeout
+	b.record(falha, sonda)
+	if err := b.allow(sonda.Add(1500 * time.Millisecond)); err != ErrCircuitOpen {
+		t.Error("Espera deveria ter dobrado apos a sondagem falhar")
+	}
+	sonda = sonda.Add(2 * time.Second)
+	b.allow(sonda)
+	b.record(falha, sonda)
+	if st := b.Status(); st.Opens != 1 || b.espera != 3*time.Second {
+		t.Errorf("Esperava 1 abertura e espera limitada a 3s, obteve %+v espera=%v", st, b.espera)
+	}
+
+	// Sondagem com sucesso fecha o circuito
+	sonda = sonda.Add(3 * time.Second)
+	b.allow(sonda)
+	b.record(nil, sonda)
+	if st := b.Status(); st.State != breakerClosed || st.Falhas != 0 {
+		t.Errorf("Sucesso na sondagem deveria fechar o circuito: %+v", st)
+	}
+}
@@ -1,11 +1,15 @@
 package services
 
 import (
-	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
 	"log"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"radarfutebol-sse/internal/codec"
 	"radarfutebol-sse/internal/models"
 )
 
@@ -14,41 +18,95 @@ import (
 type Broadcaster struct {
 	mu sync.RWMutex
 
-	// Cache de eventos em memoria (atualizado a cada 2s por uma unica goroutine)
-	eventosCache    []*models.Evento
-	eventosCacheAt  time.Time
+	// Fontes da lista de eventos (nil = fontes configuradas), favoritos e oraculos
+	fontes   []FonteEventos
+	prefs    PrefsStore
+	oraculos OraculoStore
+
+	// Snapshot de eventos em memoria (atualizado a cada 2s por uma unica goroutine)
+	// Eventos, fragmentos e indices trocados juntos; leitores nao usam lock
+	snapshot        atomic.Pointer[eventosSnapshot]
+	eventosCacheAt  time.Time // ultima leitura bem sucedida do Redis
 	eventosCacheTTL time.Duration
 
-	// Cache de oraculo por jogo
-	oraculoCache   map[string]*OraculoCache
-	oraculoCacheMu sync.RWMutex
+	// Ultimo erro ao atualizar o snapshot (para o /health/deps)
+	refreshErr   error
+	refreshErrAt time.Time
+
+	// Frescor dos dados servidos (event: status); canal fechado a cada mudanca de estado
+	dadosStatus     DadosStatus
+	dadosStatusChan chan struct{}
+	degradedAfter   atomic.Int64 // time.Duration (stream.degraded_after, recarregavel)
+	staleAfter      atomic.Int64 // time.Duration (stream.stale_after, recarregavel)
+
+	// Hubs do oraculo por jogo assistido e buscas avulsas (em andamento ou recentes, por oraculoHubInterval)
+	oraculoHubs         map[string]*oraculoHub
+	oraculoFetches      map[string]*oraculoFetch
+	oraculoFetchesSweep time.Time
+	oraculoHubsMu       sync.Mutex
+
+	// Status dos eventos consultados fora do snapshot (jogos finalizados), por eventoInfoTTL
+	eventoInfo   map[string]*EventoInfo
+	eventoInfoMu sync.RWMutex
+
+	// Warm start: snapshot carregado do disco, ate a primeira leitura do Redis com dados
+	warm          atomic.Bool
+	oraculosDisco map[string]*models.Oraculo // guardado por oraculoHubsMu
 
 	// Controle
 	stopChan chan struct{}
 	running  bool
 }
 
-// OraculoCache cache individual de cada jogo do oraculo
-type OraculoCache struct {
-	Data      map[string]interface{}
-	UpdatedAt time.Time
-}
-
 var broadcaster *Broadcaster
 var broadcasterOnce sync.Once
 
-// GetBroadcaster retorna singleton do broadcaster
+// GetBroadcaster retorna singleton do broadcaster (fontes configuradas e Redis/MySQL)
 func GetBroadcaster() *Broadcaster {
 	broadcasterOnce.Do(func() {
-		broadcaster = &Broadcaster{
-			eventosCacheTTL: 2 * time.Second,
-			oraculoCache:    make(map[string]*OraculoCache),
-			stopChan:        make(chan struct{}),
-		}
+		broadcaster = NewBroadcaster(Stores{})
 	})
 	return broadcaster
 }
 
+// NewBroadcaster cria um broadcaster com as dependencias informadas (testes usam stores em memoria)
+func NewBroadcaster(stores Stores) *Broadcaster {
+	b := &Broadcaster{
+		fontes:          stores.Eventos,
+		prefs:           stores.Prefs,
+		oraculos:        stores.Oraculos,
+		eventosCacheTTL: 2 * time.Second,
+		oraculoHubs:     make(map[string]*oraculoHub),
+		oraculoFetches:  make(map[string]*oraculoFetch),
+		eventoInfo:      make(map[string]*EventoInfo),
+		stopChan:        make(chan struct{}),
+		dadosStatus:     DadosStatus{Status: "ok"},
+		dadosStatusChan: make(chan struct{}),
+	}
+	if b.prefs == nil {
+		b.prefs = prefsRedis{}
+	}
+	if b.oraculos == nil {
+		b.oraculos = oraculoRedisMySQL{}
+	}
+	b.snapshot.Store(snapshotVazio)
+	b.SetLimitesFrescor(10*time.Second, 90*time.Second)
+	return b
+}
+
+// fontesEventos fontes em ordem de preferencia: as injetadas ou as configuradas (event_source.order)
+func (b *Broadcaster) fontesEventos() []FonteEventos {
+	if b.fontes != nil {
+		return b.fontes
+	}
+	return fontesEventos
+}
+
+// fontePrimaria nome da fonte preferida (servir de outra e modo degradado)
+func (b *Broadcaster) fontePrimaria() string {
+	return b.fontesEventos()[0].Nome()
+}
+
 // Start inicia o broadcaster em background
 func (b *Broadcaster) Start() {
 	b.mu.Lock()
@@ -59,23 +117,35 @@ func (b *Broadcaster) Start() {
 	b.running = true
 	b.mu.Unlock()
 
+	// Snapshot do disco antes da primeira leitura do Redis
+	if path := warmStartCfg.File; path != "" {
+		if err := b.carregarWarmStart(path); err != nil {
+			log.Printf("Warm start: erro ao carregar %s (iniciando vazio): %v", path, err)
+		}
+		go b.warmStartPersister(path)
+	}
+
 	// Goroutine que atualiza cache de eventos a cada 2 segundos
 	go b.eventosUpdater()
 
-	// Goroutine que limpa cache de oraculo antigo
-	go b.oraculoCleaner()
-
 	log.Println("Broadcaster iniciado")
 }
 
-// Stop para o broadcaster
+// Stop para o broadcaster e salva o snapshot para o proximo warm start
 func (b *Broadcaster) Stop() {
 	b.mu.Lock()
-	defer b.mu.Unlock()
-	if b.running {
+	running := b.running
+	if running {
 		close(b.stopChan)
 		b.running = false
 	}
+	b.mu.Unlock()
+
+	if path := warmStartCfg.File; running && path != "" {
+		if err := b.persistirSnapshot(path); err != nil {
+			log.Printf("Warm start: erro ao salvar %s: %v", path, err)
+		}
+	}
 }
 
 // eventosUpdater atualiza cache de eventos periodicamente
@@ -85,6 +155,7 @@ func (b *Broadcaster) eventosUpdater() {
 
 	// Primeira carga imediata
 	b.refreshEventosCache()
+	b.avaliarFrescor(time.Now())
 
 	for {
 		select {
@@ -92,43 +163,132 @@ func (b *Broadcaster) eventosUpdater() {
 			return
 		case <-ticker.C:
 			b.refreshEventosCache()
+			b.avaliarFrescor(time.Now())
 		}
 	}
 }
 
-// refreshEventosCache atualiza o cache de eventos do Redis
+// refreshEventosCache atualiza o cache de eventos a partir das fontes (em ordem de preferencia)
+// Fonte fora, sem dados ou com JSON invalido passa para a proxima; se nenhuma servir,
+// segue com o ultimo snapshot bom
 func (b *Broadcaster) refreshEventosCache() {
-	eventos, err := getEventosFromRedis()
-	if err != nil {
-		log.Printf("Broadcaster: erro ao buscar eventos: %v", err)
+	atual := b.snapshotAtual()
+
+	fontes := b.fontesEventos()
+	var erros []error
+	vazias := 0
+	for _, fonte := range fontes {
+		data, err := fonte.Buscar()
+		if err == nil && data == "" {
+			vazias++
+			err = fmt.Errorf("fonte %s sem eventos", fonte.Nome())
+		} else if err == nil {
+			if err = b.publicarEventos(fonte.Nome(), data, atual); err == nil {
+				return
+			}
+		}
+		// Circuito aberto: sem log a cada ciclo (o breaker loga ao abrir)
+		if !errors.Is(err, ErrCircuitOpen) {
+			log.Printf("Broadcaster: erro ao buscar eventos (%s): %v", fonte.Nome(), err)
+		}
+		erros = append(erros, err)
+	}
+
+	// Todas as fontes responderam sem dados: lista vazia e o estado real
+	// Exceto no warm start (Redis reiniciado e Laravel ainda nao repopulou): segue com o snapshot do disco
+	if vazias == len(fontes) && !b.warm.Load() {
+		b.publicarEventos(b.fontePrimaria(), "", atual)
 		return
 	}
+	b.registrarErroRefresh(errors.Join(erros...))
+}
+
+// publicarEventos decodifica o JSON da fonte e publica o novo snapshot
+// Se o JSON nao mudou desde a ultima leitura, nao decodifica de novo
+func (b *Broadcaster) publicarEventos(fonte, data string, atual *eventosSnapshot) error {
+	hash := hashString(data)
+
+	if atual.generation > 0 && hash == atual.hash && fonte == atual.fonte {
+		b.mu.Lock()
+		b.eventosCacheAt = time.Now()
+		b.mu.Unlock()
+		return nil
+	}
+
+	var eventos []*models.Evento
+	if data != "" {
+		var err error
+		if eventos, err = decodeEventos(data); err != nil {
+			return err
+		}
+	}
+
+	// Serializa cada evento uma vez aqui em vez de uma vez por conexao
+	fragmentos, err := construirFragmentos(eventos)
+	if err != nil {
+		log.Printf("Broadcaster: erro ao pre-serializar eventos (usando serializacao por conexao): %v", err)
+		fragmentos = nil
+	}
+
+	if atual.fonte != "" && atual.fonte != fonte {
+		log.Printf("Broadcaster: eventos agora vem da fonte %s (antes %s)", fonte, atual.fonte)
+	}
+
+	// Indices montados antes da publicacao: conexoes nunca veem snapshot pela metade
+	// (refresh roda em uma unica goroutine, entao atual ainda e o ultimo publicado)
+	snap := novoSnapshot(eventos, fragmentos, atual.generation+1, hash)
+	snap.fonte = fonte
+	b.snapshot.Store(snap)
 
 	b.mu.Lock()
-	b.eventosCache = eventos
 	b.eventosCacheAt = time.Now()
 	b.mu.Unlock()
+	b.encerrarWarmStart()
+	return nil
+}
+
+// registrarErroRefresh guarda o erro da ultima atualizacao que falhou
+func (b *Broadcaster) registrarErroRefresh(err error) {
+	b.mu.Lock()
+	b.refreshErr, b.refreshErrAt = err, time.Now()
+	b.mu.Unlock()
+}
+
+// Generation retorna a geracao atual do snapshot de eventos (0 = ainda nao carregado)
+func (b *Broadcaster) Generation() uint64 {
+	return b.snapshotAtual().generation
+}
+
+// Live indica se o snapshot publicado veio de uma fonte (nao apenas do warm start do disco)
+func (b *Broadcaster) Live() bool {
+	return b.snapshotAtual().generation > 0 && !b.warm.Load()
+}
+
+// hashString hash FNV-1a de 64 bits
+func hashString(s string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	return h.Sum64()
 }
 
 // GetEventosCache retorna eventos do cache em memoria
+// O slice e do snapshot publicado (imutavel): nao modificar
 func (b *Broadcaster) GetEventosCache() []*models.Evento {
-	b.mu.RLock()
-	defer b.mu.RUnlock()
-
-	// Retorna copia para evitar race conditions
-	if b.eventosCache == nil {
-		return nil
-	}
+	return b.snapshotAtual().eventos
+}
 
-	return b.eventosCache
+// snapshotAtual retorna o snapshot publicado (eventos, fragmentos e indices consistentes entre si)
+func (b *Broadcaster) snapshotAtual() *eventosSnapshot {
+	return b.snapshot.Load()
 }
 
 // GetEventosPainelFiltradoCached aplica filtros sobre cache em memoria
 func (b *Broadcaster) GetEventosPainelFiltradoCached(filtro *models.Filtro) ([]byte, error) {
-	eventos := b.GetEventosCache()
+	snap := b.snapshotAtual()
+	eventos := snap.eventos
 
 	if len(eventos) == 0 {
-		return json.Marshal(&models.PainelResponse{
+		return codec.Marshal(filtro.Encoding, &models.PainelResponse{
 			Eventos: []*models.Evento{},
 			Counts:  models.Counts{Live: 0, Total: 0, Gols: 0},
 		})
@@ -138,27 +298,33 @@ func (b *Broadcaster) GetEventosPainelFiltradoCached(filtro *models.Filtro) ([]b
 	var prefs *PreferenciasUsuario
 	var err error
 	if filtro.IdUsuario > 0 {
-		prefs, err = GetPreferenciasUsuarioCompletas(filtro.IdUsuario)
-		if err != nil {
+		prefs, err = b.prefs.Preferencias(filtro.IdUsuario)
+		if err != nil && !errors.Is(err, ErrCircuitOpen) {
 			log.Printf("Erro ao buscar preferencias do usuario %d: %v", filtro.IdUsuario, err)
 		}
 	}
 
+	// JSON completo: concatena os fragmentos pre-serializados
+	if snap.fragmentos != nil && usaFragmentos(filtro) {
+		return montarPainelJSON(eventos, snap.fragmentos, filtro, prefs, b.prefs)
+	}
+
 	// Aplica filtros
-	response, err := FiltrarEventosPainel(eventos, filtro, prefs)
+	response, err := filtrarEventosPainel(eventos, filtro, prefs, b.prefs)
 	if err != nil {
 		return nil, err
 	}
 
-	return json.Marshal(response)
+	return codec.Marshal(filtro.Encoding, filtro.Projecao.Painel(response))
 }
 
 // GetEventosHomeFiltradoCached aplica filtros sobre cache em memoria
 func (b *Broadcaster) GetEventosHomeFiltradoCached(filtro *models.Filtro) ([]byte, error) {
-	eventos := b.GetEventosCache()
+	snap := b.snapshotAtual()
+	eventos := snap.eventos
 
 	if len(eventos) == 0 {
-		return json.Marshal(&models.HomeResponse{
+		return codec.Marshal(filtro.Encoding, &models.HomeResponse{
 			Campeonatos: []*models.Campeonato{},
 			Counts:      models.Counts{Live: 0, Total: 0, Gols: 0},
 		})
@@ -168,99 +334,54 @@ func (b *Broadcaster) GetEventosHomeFiltradoCached(filtro *models.Filtro) ([]byt
 	var prefs *PreferenciasUsuario
 	var err error
 	if filtro.IdUsuario > 0 {
-		prefs, err = GetPreferenciasUsuarioCompletas(filtro.IdUsuario)
-		if err != nil {
+		prefs, err = b.prefs.Preferencias(filtro.IdUsuario)
+		if err != nil && !errors.Is(err, ErrCircuitOpen) {
 			log.Printf("Erro ao buscar preferencias do usuario %d: %v", filtro.IdUsuario, err)
 		}
 	}
 
-	// Aplica filtros
-	response, err := FiltrarEventosHome(eventos, filtro, prefs)
-	if err != nil {
-		return nil, err
-	}
-
-	return json.Marshal(response)
-}
-
-// GetOraculoCached busca oraculo do cache ou Redis e mergeia dados do evento (status, acrescimos)
-func (b *Broadcaster) GetOraculoCached(idWilliamhill string) (map[string]interface{}, error) {
-	b.oraculoCacheMu.RLock()
-	cached, exists := b.oraculoCache[idWilliamhill]
-	b.oraculoCacheMu.RUnlock()
-
-	// Se cache existe e tem menos de 2 segundos, usa cache
-	if exists && time.Since(cached.UpdatedAt) < 2*time.Second {
-		return cached.Data, nil
+	// JSON completo: concatena os fragmentos pre-serializados
+	if snap.fragmentos != nil && usaFragmentos(filtro) {
+		return montarHomeJSON(eventos, snap.fragmentos, filtro, prefs, b.prefs)
 	}
 
-	// Busca do Redis
-	data, err := GetOraculoCache(idWilliamhill)
+	// Aplica filtros
+	response, err := filtrarEventosHome(eventos, filtro, prefs, b.prefs)
 	if err != nil {
 		return nil, err
 	}
 
-	// Mergeia dados do evento (status, acrescimos, temEscalacao) do cache em memoria
-	if data != nil {
-		b.mergeEventoNoOraculo(data, idWilliamhill)
+	return codec.Marshal(filtro.Encoding, filtro.Projecao.Home(response))
+}
 
-		b.oraculoCacheMu.Lock()
-		b.oraculoCache[idWilliamhill] = &OraculoCache{
-			Data:      data,
-			UpdatedAt: time.Now(),
-		}
-		b.oraculoCacheMu.Unlock()
+// GetOraculoCached retorna o oraculo do jogo (status e acrescimos do evento ja mergeados)
+// Jogo assistido: dados do hub; senao busca no Redis uma vez para chamadas concorrentes
+// O oraculo pode ser compartilhado entre conexoes: copiar antes de modificar
+func (b *Broadcaster) GetOraculoCached(idWilliamhill string) (*models.Oraculo, error) {
+	if hub := b.activeOraculoHub(idWilliamhill); hub != nil {
+		<-hub.ready
+		return hub.latest()
 	}
-
-	return data, nil
+	return b.fetchOraculoOnce(idWilliamhill)
 }
 
 // mergeEventoNoOraculo injeta status, temEscalacao e acrescimos reais do evento no oraculo
 // Primeiro tenta o cache de eventos em memoria (jogos ativos), depois MySQL com cache local
-func (b *Broadcaster) mergeEventoNoOraculo(data map[string]interface{}, idWilliamhill string) {
+func (b *Broadcaster) mergeEventoNoOraculo(data *models.Oraculo, idWilliamhill string) {
 	// Tenta cache de eventos em memoria (jogos ativos, atualizado a cada 2s)
-	evento := b.findEventoByIdWilliamhill(idWilliamhill)
+	evento := b.FindEventoByIdWilliamhill(idWilliamhill)
 	if evento != nil {
-		data["status"] = evento.Status
-		data["temEscalacao"] = evento.TemEscalacao
-		data["problemaRadar"] = evento.ProblemaRadar
-		if evento.DescontoHt != nil {
-			data["acrescimo1Tempo"] = *evento.DescontoHt
-		}
-		if evento.DescontoFt != nil {
-			data["acrescimo2Tempo"] = *evento.DescontoFt
-		}
+		data.MergeInfo(evento.Status, evento.TemEscalacao, evento.ProblemaRadar, evento.DescontoHt, evento.DescontoFt)
 		return
 	}
 
 	// Evento nao esta no cache de ativos - busca status do MySQL com cache local
 	info := b.getEventoInfoCached(idWilliamhill)
 	if info != nil {
-		data["status"] = info.Status
-		data["temEscalacao"] = info.TemEscalacao
-		data["problemaRadar"] = info.ProblemaRadar
-		if info.DescontoHt != nil {
-			data["acrescimo1Tempo"] = *info.DescontoHt
-		}
-		if info.DescontoFt != nil {
-			data["acrescimo2Tempo"] = *info.DescontoFt
-		}
+		data.MergeInfo(info.Status, info.TemEscalacao, info.ProblemaRadar, info.DescontoHt, info.DescontoFt)
 	}
 }
 
-// findEventoByIdWilliamhill busca evento no cache em memoria pelo idWilliamhill
-func (b *Broadcaster) findEventoByIdWilliamhill(idWilliamhill string) *models.Evento {
-	b.mu.RLock()
-	defer b.mu.RUnlock()
-
-	for _, e := range b.eventosCache {
-		if e.IdWilliamhill == idWilliamhill {
-			return e
-		}
-	}
-	return nil
-}
-
 // EventoInfo dados minimos do evento para merge no oraculo
 type EventoInfo struct {
 	Status        string
@@ -271,63 +392,33 @@ type EventoInfo struct {
 	CachedAt      time.Time
 }
 
-// Cache local de eventos consultados no MySQL (para jogos finalizados que saem do eventosCache)
-var eventoInfoCache = struct {
-	sync.RWMutex
-	m map[string]*EventoInfo
-}{m: make(map[string]*EventoInfo)}
+// eventoInfoTTL validade do cache local de status dos eventos (oraculo.evento_info_ttl)
+var eventoInfoTTL = 10 * time.Second
 
-// getEventoInfoCached busca status do evento no MySQL com cache de 10s
+// getEventoInfoCached busca status do evento no MySQL com cache de eventoInfoTTL
 func (b *Broadcaster) getEventoInfoCached(idWilliamhill string) *EventoInfo {
-	eventoInfoCache.RLock()
-	cached, exists := eventoInfoCache.m[idWilliamhill]
-	eventoInfoCache.RUnlock()
+	b.eventoInfoMu.RLock()
+	cached, exists := b.eventoInfo[idWilliamhill]
+	b.eventoInfoMu.RUnlock()
 
-	if exists && time.Since(cached.CachedAt) < 10*time.Second {
+	if exists && time.Since(cached.CachedAt) < eventoInfoTTL {
 		return cached
 	}
 
-	info, err := getEventoInfoFromDB(idWilliamhill)
+	info, err := b.oraculos.EventoInfo(idWilliamhill)
 	if err != nil {
-		log.Printf("Broadcaster: erro ao buscar evento %s do MySQL: %v", idWilliamhill, err)
+		if !errors.Is(err, ErrCircuitOpen) {
+			log.Printf("Broadcaster: erro ao buscar evento %s do MySQL: %v", idWilliamhill, err)
+		}
 		return cached // retorna cache antigo se houver
 	}
 
 	if info != nil {
 		info.CachedAt = time.Now()
-		eventoInfoCache.Lock()
-		eventoInfoCache.m[idWilliamhill] = info
-		eventoInfoCache.Unlock()
+		b.eventoInfoMu.Lock()
+		b.eventoInfo[idWilliamhill] = info
+		b.eventoInfoMu.Unlock()
 	}
 
 	return info
 }
-
-// oraculoCleaner limpa cache de oraculo antigo periodicamente
-func (b *Broadcaster) oraculoCleaner() {
-	ticker := time.NewTicker(5 * time.Minute)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-b.stopChan:
-			return
-		case <-ticker.C:
-			b.cleanOldOraculoCache()
-		}
-	}
-}
-
-// cleanOldOraculoCache remove entradas antigas do cache de oraculo
-func (b *Broadcaster) cleanOldOraculoCache() {
-	b.oraculoCacheMu.Lock()
-	defer b.oraculoCacheMu.Unlock()
-
-	now := time.Now()
-	for id, cached := range b.oraculoCache {
-		// Remove se tem mais de 10 minutos sem uso
-		if now.Sub(cached.UpdatedAt) > 10*time.Minute {
-			delete(b.oraculoCache, id)
-		}
-	}
-}
@@ -5,14 +5,15 @@ import (
 	"fmt"
 	"log"
 
+	"radarfutebol-sse/internal/codec"
 	"radarfutebol-sse/internal/models"
 )
 
 // Prefix usado pelo Laravel Redis (database 1)
 const redisPrefix = "radarfutebolcom_database_"
 
-// Chave JSON pura criada pelo Laravel para o Go
-const eventosJsonKey = "eventos-painel-json"
+// Chave JSON pura criada pelo Laravel para o Go (redis.eventos_key)
+var eventosJsonKey = "eventos-painel-json"
 
 // GetEventosPainelFiltrado busca eventos do Redis e aplica filtros
 func GetEventosPainelFiltrado(filtro *models.Filtro) ([]byte, error) {
@@ -23,7 +24,7 @@ func GetEventosPainelFiltrado(filtro *models.Filtro) ([]byte, error) {
 	}
 
 	if len(eventos) == 0 {
-		return json.Marshal(&models.PainelResponse{
+		return codec.Marshal(filtro.Encoding, &models.PainelResponse{
 			Eventos: []*models.Evento{},
 			Counts:  models.Counts{Live: 0, Total: 0, Gols: 0},
 		})
@@ -44,7 +45,7 @@ func GetEventosPainelFiltrado(filtro *models.Filtro) ([]byte, error) {
 		return nil, err
 	}
 
-	return json.Marshal(response)
+	return codec.Marshal(filtro.Encoding, filtro.Projecao.Painel(response))
 }
 
 // GetEventosHomeFiltrado busca eventos do Redis e aplica filtros para Home
@@ -56,7 +57,7 @@ func GetEventosHomeFiltrado(filtro *models.Filtro) ([]byte, error) {
 	}
 
 	if len(eventos) == 0 {
-		return json.Marshal(&models.HomeResponse{
+		return codec.Marshal(filtro.Encoding, &models.HomeResponse{
 			Campeonatos: []*models.Campeonato{},
 			Counts:      models.Counts{Live: 0, Total: 0, Gols: 0},
 		})
@@ -77,23 +78,37 @@ func GetEventosHomeFiltrado(filtro *models.Filtro) ([]byte, error) {
 		return nil, err
 	}
 
-	return json.Marshal(response)
+	return codec.Marshal(filtro.Encoding, filtro.Projecao.Home(response))
 }
 
 // getEventosFromRedis busca eventos do cache Redis
 func getEventosFromRedis() ([]*models.Evento, error) {
-	// Busca da chave JSON pura criada pelo Laravel para o Go
-	// Chave: eventos-painel-json (sem prefixo, JSON puro)
-	data, err := GetString(eventosJsonKey)
+	data, err := getEventosRawFromRedis()
 	if err != nil {
-		return nil, fmt.Errorf("erro ao buscar eventos do Redis: %w", err)
+		return nil, err
 	}
 
 	if data == "" {
-		log.Println("SSE: Chave eventos-painel-json nao encontrada no Redis")
+		log.Printf("SSE: Chave %s nao encontrada no Redis", eventosJsonKey)
 		return nil, nil
 	}
 
+	return decodeEventos(data)
+}
+
+// getEventosRawFromRedis busca o JSON de eventos sem decodificar
+func getEventosRawFromRedis() (string, error) {
+	// Busca da chave JSON pura criada pelo Laravel para o Go
+	// Chave: eventos-painel-json (sem prefixo, JSON puro)
+	data, err := GetString(eventosJsonKey)
+	if err != nil {
+		return "", fmt.Errorf("erro ao buscar eventos do Redis: %w", err)
+	}
+	return data, nil
+}
+
+// decodeEventos decodifica o JSON de eventos gerado pelo Laravel
+func decodeEventos(data string) ([]*models.Evento, error) {
 	var eventos []*models.Evento
 	if err := json.Unmarshal([]byte(data), &eventos); err != nil {
 		return nil, fmt.Errorf("erro ao decodificar eventos JSON: %w", err)
@@ -104,7 +119,7 @@ func getEventosFromRedis() ([]*models.Evento, error) {
 		e.TemAnaliseIA = e.AnaliseIA != ""
 	}
 
-	log.Printf("SSE: Carregados %d eventos do Redis", len(eventos))
+	log.Printf("SSE: Carregados %d eventos", len(eventos))
 	return eventos, nil
 }
 
@@ -20,7 +20,7 @@ func getAlertasGolUsuario(userID int) map[string]string {
 	}
 
 	key := fmt.Sprintf("alerta-gol-usuario-%d", userID)
-	data, err := rdbPrefs.Get(ctx, key).Result()
+	data, err := prefsGet(key)
 	if err != nil || data == "" {
 		return make(map[string]string)
 	}
@@ -54,7 +54,9 @@ func setAlertasGolUsuario(userID int, alertas map[string]string) {
 	if err != nil {
 		return
 	}
-	rdbPrefs.Set(ctx, key, string(data), 5*time.Minute)
+	prefsBreaker.Do(func() error {
+		return rdbPrefs.Set(ctx, key, string(data), 5*time.Minute).Err()
+	})
 }
 
 // PreferenciasUsuario preferencias de campeonatos e jogos favoritos
@@ -65,71 +67,17 @@ type PreferenciasUsuario struct {
 
 // FiltrarEventosPainel filtra eventos para o painel igual ao Laravel
 func FiltrarEventosPainel(eventos []*models.Evento, filtro *models.Filtro, prefs *PreferenciasUsuario) (*models.PainelResponse, error) {
-	// Inicializa como slice vazio (nunca nil) para JSON serializar como [] ao inves de null
-	jogosFiltrados := make([]*models.Evento, 0)
-	countJogosLive := 0
-	countJogosTotal := 0
-	countGols := 0
-
-	// Cache de alertas de gol do usuario (evita som repetido)
-	var alertasGol map[string]string
-	if filtro.SomLigado && filtro.IdUsuario > 0 {
-		alertasGol = getAlertasGolUsuario(filtro.IdUsuario)
-	}
-	alertasGolModificado := false
-
-	for _, evento := range eventos {
-		if !aplicaFiltros(evento, filtro, prefs) {
-			continue
-		}
-
-		if evento.Status == "inprogress" {
-			countJogosLive++
-		}
-		countJogosTotal++
-
-		// Conta gols para som de alerta
-		// Usa AlertarGolTimeCasa/Fora que duram 30s (AlertarSomGol dura apenas 1 ciclo e o broadcaster pode perder)
-		// Dedup por placar: so conta se o placar mudou desde a ultima notificacao
-		if filtro.SomLigado && filtro.IdUsuario > 0 && (evento.AlertarGolTimeCasa.Bool() || evento.AlertarGolTimeFora.Bool()) {
-			idEventoStr := strconv.Itoa(evento.IdEvento)
-			placar := placarEvento(evento)
-			placarAnterior, jaNotificou := alertasGol[idEventoStr]
-
-			if !jaNotificou || placarAnterior != placar {
-				countGols++
-				alertasGol[idEventoStr] = placar
-				alertasGolModificado = true
-			}
-		}
-
-		// IMPORTANTE: Cria copia do evento para nao modificar o cache compartilhado
-		// Isso evita que favoritos de um usuario vazem para outros usuarios
-		eventoCopia := *evento
-
-		// Marca favoritos na copia (nao no original)
-		if prefs != nil {
-			idEventoStr := strconv.Itoa(eventoCopia.IdEvento)
-			eventoCopia.Favorito = models.FlexBool(prefs.JogosFavoritos[idEventoStr])
-			eventoCopia.CampeonatoFavorito = models.FlexBool(prefs.CampeonatosFavoritos[eventoCopia.IdCampeonatoUnico])
-		} else {
-			// Se nao tem preferencias, garante que favorito esta false
-			eventoCopia.Favorito = models.FlexBool(false)
-			eventoCopia.CampeonatoFavorito = models.FlexBool(false)
-		}
+	return filtrarEventosPainel(eventos, filtro, prefs, prefsRedis{})
+}
 
-		// Se usuario free/anonimo, filtra dados sensiveis
-		if !filtro.IsAssinante {
-			eventoFiltrado := eventoCopia.FiltrarParaFree()
-			jogosFiltrados = append(jogosFiltrados, eventoFiltrado)
-		} else {
-			jogosFiltrados = append(jogosFiltrados, &eventoCopia)
-		}
-	}
+// filtrarEventosPainel filtra o painel guardando os alertas de gol no store informado
+func filtrarEventosPainel(eventos []*models.Evento, filtro *models.Filtro, prefs *PreferenciasUsuario, alertas PrefsStore) (*models.PainelResponse, error) {
+	selecionados, counts := selecionarEventos(eventos, filtro, prefs, alertas)
 
-	// Salva cache de alertas se foi modificado
-	if alertasGolModificado {
-		setAlertasGolUsuario(filtro.IdUsuario, alertasGol)
+	// Inicializa como slice vazio (nunca nil) para JSON serializar como [] ao inves de null
+	jogosFiltrados := make([]*models.Evento, 0, len(selecionados))
+	for _, evento := range selecionados {
+		jogosFiltrados = append(jogosFiltrados, copiaFiltrada(evento, filtro, prefs))
 	}
 
 	// Ordenar
@@ -140,33 +88,56 @@ func FiltrarEventosPainel(eventos []*models.Evento, filtro *models.Filtro, prefs
 		jogosFiltrados = jogosFiltrados[:filtro.CountJogosMostrar]
 	}
 
-	// Garante que o slice nunca seja nil para JSON serializar como []
-	if jogosFiltrados == nil {
-		jogosFiltrados = []*models.Evento{}
-	}
-
 	return &models.PainelResponse{
 		Eventos: jogosFiltrados,
-		Counts: models.Counts{
-			Live:  countJogosLive,
-			Total: countJogosTotal,
-			Gols:  countGols,
-		},
+		Counts:  counts,
 	}, nil
 }
 
 // FiltrarEventosHome filtra eventos para a home igual ao Laravel
 func FiltrarEventosHome(eventos []*models.Evento, filtro *models.Filtro, prefs *PreferenciasUsuario) (*models.HomeResponse, error) {
-	// Inicializa como slice vazio (nunca nil) para JSON serializar como [] ao inves de null
-	jogosFiltrados := make([]*models.Evento, 0)
+	return filtrarEventosHome(eventos, filtro, prefs, prefsRedis{})
+}
+
+// filtrarEventosHome filtra a home guardando os alertas de gol no store informado
+func filtrarEventosHome(eventos []*models.Evento, filtro *models.Filtro, prefs *PreferenciasUsuario, alertas PrefsStore) (*models.HomeResponse, error) {
+	selecionados, counts := selecionarEventos(eventos, filtro, prefs, alertas)
+
+	jogosFiltrados := make([]*models.Evento, 0, len(selecionados))
+	for _, evento := range selecionados {
+		jogosFiltrados = append(jogosFiltrados, copiaFiltrada(evento, filtro, prefs))
+	}
+
+	// Ordenar
+	ordenarEventosHome(jogosFiltrados, filtro.OrdemInicio)
+
+	// Agrupar por campeonato e limitar
+	campeonatos := agruparPorCampeonato(jogosFiltrados, filtro.CountJogosMostrar, prefs)
+
+	// Garante que o slice nunca seja nil para JSON serializar como []
+	if campeonatos == nil {
+		campeonatos = []*models.Campeonato{}
+	}
+
+	return &models.HomeResponse{
+		Campeonatos: campeonatos,
+		Counts:      counts,
+	}, nil
+}
+
+// selecionarEventos aplica os filtros e conta live/total/gols sem copiar os eventos
+// Os ponteiros retornados sao do cache compartilhado: nao modificar
+// alertas guarda os gols ja notificados (nil = sem dedup entre updates)
+func selecionarEventos(eventos []*models.Evento, filtro *models.Filtro, prefs *PreferenciasUsuario, alertas PrefsStore) ([]*models.Evento, models.Counts) {
+	selecionados := make([]*models.Evento, 0)
 	countJogosLive := 0
 	countJogosTotal := 0
 	countGols := 0
 
 	// Cache de alertas de gol do usuario (evita som repetido)
-	var alertasGol map[string]string
-	if filtro.SomLigado && filtro.IdUsuario > 0 {
-		alertasGol = getAlertasGolUsuario(filtro.IdUsuario)
+	alertasGol := make(map[string]string)
+	if filtro.SomLigado && filtro.IdUsuario > 0 && alertas != nil {
+		alertasGol = alertas.AlertasGol(filtro.IdUsuario)
 	}
 	alertasGolModificado := false
 
@@ -195,58 +166,60 @@ func FiltrarEventosHome(eventos []*models.Evento, filtro *models.Filtro, prefs *
 			}
 		}
 
-		// IMPORTANTE: Cria copia do evento para nao modificar o cache compartilhado
-		// Isso evita que favoritos de um usuario vazem para outros usuarios
-		eventoCopia := *evento
+		selecionados = append(selecionados, evento)
+	}
 
-		// Marca favoritos na copia (nao no original)
-		if prefs != nil {
-			idEventoStr := strconv.Itoa(eventoCopia.IdEvento)
-			eventoCopia.Favorito = models.FlexBool(prefs.JogosFavoritos[idEventoStr])
-			eventoCopia.CampeonatoFavorito = models.FlexBool(prefs.CampeonatosFavoritos[eventoCopia.IdCampeonatoUnico])
-		} else {
-			// Se nao tem preferencias, garante que favorito esta false
-			eventoCopia.Favorito = models.FlexBool(false)
-			eventoCopia.CampeonatoFavorito = models.FlexBool(false)
-		}
+	// Salva cache de alertas se foi modificado
+	if alertasGolModificado && alertas != nil {
+		alertas.SalvarAlertasGol(filtro.IdUsuario, alertasGol)
+	}
 
-		// Se usuario free/anonimo, filtra dados sensiveis
-		if !filtro.IsAssinante {
-			eventoFiltrado := eventoCopia.FiltrarParaFree()
-			jogosFiltrados = append(jogosFiltrados, eventoFiltrado)
-		} else {
-			jogosFiltrados = append(jogosFiltrados, &eventoCopia)
-		}
+	return selecionados, models.Counts{
+		Live:  countJogosLive,
+		Total: countJogosTotal,
+		Gols:  countGols,
 	}
+}
 
-	// Salva cache de alertas se foi modificado
-	if alertasGolModificado {
-		setAlertasGolUsuario(filtro.IdUsuario, alertasGol)
+// favoritosDoUsuario retorna as flags de favorito (jogo, campeonato) do evento para o usuario
+func favoritosDoUsuario(prefs *PreferenciasUsuario) func(*models.Evento) (bool, bool) {
+	return func(evento *models.Evento) (bool, bool) {
+		if prefs == nil {
+			return false, false
+		}
+		return prefs.JogosFavoritos[strconv.Itoa(evento.IdEvento)], prefs.CampeonatosFavoritos[evento.IdCampeonatoUnico]
 	}
+}
 
-	// Ordenar
-	ordenarEventosHome(jogosFiltrados, filtro.OrdemInicio)
+// copiaFiltrada copia o evento com os favoritos do usuario e aplica tier/escopo do parceiro
+func copiaFiltrada(evento *models.Evento, filtro *models.Filtro, prefs *PreferenciasUsuario) *models.Evento {
+	// IMPORTANTE: Cria copia do evento para nao modificar o cache compartilhado
+	// Isso evita que favoritos de um usuario vazem para outros usuarios
+	eventoCopia := *evento
 
-	// Agrupar por campeonato e limitar
-	campeonatos := agruparPorCampeonato(jogosFiltrados, filtro.CountJogosMostrar, prefs)
+	// Marca favoritos na copia (nao no original)
+	favorito, campeonatoFavorito := favoritosDoUsuario(prefs)(evento)
+	eventoCopia.Favorito = models.FlexBool(favorito)
+	eventoCopia.CampeonatoFavorito = models.FlexBool(campeonatoFavorito)
 
-	// Garante que o slice nunca seja nil para JSON serializar como []
-	if campeonatos == nil {
-		campeonatos = []*models.Campeonato{}
+	// Se usuario free/anonimo, filtra dados sensiveis
+	eventoFiltrado := &eventoCopia
+	if !filtro.IsAssinante {
+		eventoFiltrado = eventoCopia.FiltrarParaFree()
 	}
-
-	return &models.HomeResponse{
-		Campeonatos: campeonatos,
-		Counts: models.Counts{
-			Live:  countJogosLive,
-			Total: countJogosTotal,
-			Gols:  countGols,
-		},
-	}, nil
+	if filtro.OcultarOdds {
+		eventoFiltrado = eventoFiltrado.RemoverOdds()
+	}
+	return eventoFiltrado
 }
 
 // aplicaFiltros verifica se evento passa nos filtros (igual ao PHP)
 func aplicaFiltros(evento *models.Evento, filtro *models.Filtro, prefs *PreferenciasUsuario) bool {
+	// Escopo do parceiro: apenas campeonatos liberados na API key
+	if filtro.CampeonatosPermitidos != nil && !filtro.CampeonatosPermitidos[evento.IdCampeonatoUnico] {
+		return false
+	}
+
 	// Filtro acrescimo
 	if filtro.MostrarFiltroAcrescimo {
 		prev1, _ := strconv.Atoi(evento.PrevisaoAcrescimo1Tempo.String())
@@ -420,13 +393,21 @@ func aplicaFiltros(evento *models.Evento, filtro *models.Filtro, prefs *Preferen
 
 // ordenarEventosPainel ordena eventos do painel
 func ordenarEventosPainel(eventos []*models.Evento, ordemInicio bool) {
+	ordenarEventosPainelPor(eventos, ordemInicio, favoritosDoEvento)
+}
+
+// ordenarEventosPainelPor ordena eventos do painel com as flags de favorito informadas
+func ordenarEventosPainelPor(eventos []*models.Evento, ordemInicio bool, favoritos func(*models.Evento) (bool, bool)) {
 	sort.SliceStable(eventos, func(i, j int) bool {
+		favI, campFavI := favoritos(eventos[i])
+		favJ, campFavJ := favoritos(eventos[j])
+
 		// Favoritos primeiro
-		if eventos[i].Favorito.Bool() != eventos[j].Favorito.Bool() {
-			return eventos[i].Favorito.Bool()
+		if favI != favJ {
+			return favI
 		}
-		if eventos[i].CampeonatoFavorito.Bool() != eventos[j].CampeonatoFavorito.Bool() {
-			return eventos[i].CampeonatoFavorito.Bool()
+		if campFavI != campFavJ {
+			return campFavI
 		}
 
 		if ordemInicio {
@@ -447,10 +428,18 @@ func ordenarEventosPainel(eventos []*models.Evento, ordemInicio bool) {
 
 // ordenarEventosHome ordena eventos da home
 func ordenarEventosHome(eventos []*models.Evento, ordemInicio bool) {
+	ordenarEventosHomePor(eventos, ordemInicio, favoritosDoEvento)
+}
+
+// ordenarEventosHomePor ordena eventos da home com as flags de favorito informadas
+func ordenarEventosHomePor(eventos []*models.Evento, ordemInicio bool, favoritos func(*models.Evento) (bool, bool)) {
 	sort.SliceStable(eventos, func(i, j int) bool {
+		_, campFavI := favoritos(eventos[i])
+		_, campFavJ := favoritos(eventos[j])
+
 		// Campeonato favorito primeiro
-		if eventos[i].CampeonatoFavorito.Bool() != eventos[j].CampeonatoFavorito.Bool() {
-			return eventos[i].CampeonatoFavorito.Bool()
+		if campFavI != campFavJ {
+			return campFavI
 		}
 
 		if ordemInicio {
@@ -467,6 +456,11 @@ func ordenarEventosHome(eventos []*models.Evento, ordemInicio bool) {
 	})
 }
 
+// favoritosDoEvento flags de favorito ja marcadas na copia do evento
+func favoritosDoEvento(evento *models.Evento) (bool, bool) {
+	return evento.Favorito.Bool(), evento.CampeonatoFavorito.Bool()
+}
+
 // agruparPorCampeonato agrupa eventos por campeonato
 func agruparPorCampeonato(eventos []*models.Evento, maxJogos int, prefs *PreferenciasUsuario) []*models.Campeonato {
 	campeonatosMap := make(map[string]*models.Campeonato)
@@ -618,165 +612,3 @@ func levenshteinDistance(a, b string) int {
 
 	return matrix[len(a)][len(b)]
 }
-
-// FiltrarOraculoParaFree filtra dados do oraculo para usuarios free/anonimos
-// Remove: SL, alertas avancados, estatisticas, probabilidades, xG, pontos10Min, pressao, analise IA
-func FiltrarOraculoParaFree(data map[string]interface{}) map[string]interface{} {
-	// Cria copia do mapa
-	copia := make(map[string]interface{})
-	for k, v := range data {
-		copia[k] = v
-	}
-
-	// Campos a zerar (definir como nil/vazio)
-	camposZerar := []string{
-		// Score de Lances (SL)
-		"scoreLances10MinTimeCasa", "scoreLances10MinTimeFora",
-		"classScoreLances10MinTimeCasa", "classScoreLances10MinTimeFora",
-		"scoreLances5MinTimeCasa", "scoreLances5MinTimeFora",
-		"classScoreLances5MinTimeCasa", "classScoreLances5MinTimeFora",
-
-		// Alertas avancados
-		"alertaMomentoGolAtivo", "alertaMomentoGolValor",
-		"alertaPressaoIndividualAtivo", "alertaPressaoIndividualTime",
-		"alertaPressaoIndividualNome", "alertaPressaoIndividualValor",
-		"pressaoTimeCasa", "pressaoTimeFora",
-		"classPressaoTimeCasa", "classPressaoTimeFora",
-		"somaPressao",
-
-		// Estatisticas Time Casa
-		"posseBolaTimeCasa", "chutesGolTimeCasa", "chutesForaTimeCasa",
-		"chutesTraveTimeCasa", "chutesBloqueadoTimeCasa", "escanteiosTimeCasa",
-		"ataquesPerigososTimeCasa", "penalidadesTimeCasa",
-		"probabilidadesTimeCasa", "pontos10MinTimeCasa",
-
-		// Estatisticas 1 Tempo Casa
-		"chutesGolTimeCasa1Tempo", "chutesForaTimeCasa1Tempo",
-		"chutesTraveTimeCasa1Tempo", "chutesBloqueadoTimeCasa1Tempo",
-		"escanteiosTimeCasa1Tempo", "ataquesPerigososTimeCasa1Tempo",
-		"penalidadesTimeCasa1Tempo",
-
-		// Estatisticas 2 Tempo Casa
-		"chutesGolTimeCasa2Tempo", "chutesForaTimeCasa2Tempo",
-		"chutesTraveTimeCasa2Tempo", "chutesBloqueadoTimeCasa2Tempo",
-		"escanteiosTimeCasa2Tempo", "ataquesPerigososTimeCasa2Tempo",
-		"penalidadesTimeCasa2Tempo",
-
-		// Estatisticas 5 Min Casa
-		"chutesGolTimeCasa5Min", "chutesForaTimeCasa5Min",
-		"chutesTraveTimeCasa5Min", "chutesBloqueadoTimeCasa5Min",
-		"escanteiosTimeCasa5Min", "ataquesPerigososTimeCasa5Min",
-		"penalidadesTimeCasa5Min",
-
-		// Estatisticas 10 Min Casa
-		"chutesGolTimeCasa10Min", "chutesForaTimeCasa10Min",
-		"chutesTraveTimeCasa10Min", "chutesBloqueadoTimeCasa10Min",
-		"escanteiosTimeCasa10Min", "ataquesPerigososTimeCasa10Min",
-		"penalidadesTimeCasa10Min",
-
-		// Classes CSS Time Casa
-		"classPosseBolaTimeCasa", "classChutesGolTimeCasa", "classChutesForaTimeCasa",
-		"classChutesTraveTimeCasa", "classChutesBloqueadoTimeCasa", "classEscanteiosTimeCasa",
-		"classAtaquesPerigososTimeCasa", "classPenalidadesTimeCasa",
-		"classProbabilidadesTimeCasa", "classPontos10MinTimeCasa",
-
-		// Classes CSS 1 Tempo Casa
-		"classChutesGolTimeCasa1Tempo", "classChutesForaTimeCasa1Tempo",
-		"classChutesTraveTimeCasa1Tempo", "classChutesBloqueadoTimeCasa1Tempo",
-		"classEscanteiosTimeCasa1Tempo", "classAtaquesPerigososTimeCasa1Tempo",
-		"classPenalidadesTimeCasa1Tempo",
-
-		// Classes CSS 2 Tempo Casa
-		"classChutesGolTimeCasa2Tempo", "classChutesForaTimeCasa2Tempo",
-		"classChutesTraveTimeCasa2Tempo", "classChutesBloqueadoTimeCasa2Tempo",
-		"classEscanteiosTimeCasa2Tempo", "classAtaquesPerigososTimeCasa2Tempo",
-		"classPenalidadesTimeCasa2Tempo",
-
-		// Classes CSS 5 Min Casa
-		"classChutesGolTimeCasa5Min", "classChutesForaTimeCasa5Min",
-		"classChutesTraveTimeCasa5Min", "classChutesBloqueadoTimeCasa5Min",
-		"classEscanteiosTimeCasa5Min", "classAtaquesPerigososTimeCasa5Min",
-		"classPenalidadesTimeCasa5Min",
-
-		// Classes CSS 10 Min Casa
-		"classChutesGolTimeCasa10Min", "classChutesForaTimeCasa10Min",
-		"classChutesTraveTimeCasa10Min", "classChutesBloqueadoTimeCasa10Min",
-		"classEscanteiosTimeCasa10Min", "classAtaquesPerigososTimeCasa10Min",
-		"classPenalidadesTimeCasa10Min",
-
-		// Estatisticas Time Fora
-		"posseBolaTimeFora", "chutesGolTimeFora", "chutesForaTimeFora",
-		"chutesTraveTimeFora", "chutesBloqueadoTimeFora", "escanteiosTimeFora",
-		"ataquesPerigososTimeFora", "penalidadesTimeFora",
-		"probabilidadesTimeFora", "pontos10MinTimeFora",
-
-		// Estatisticas 1 Tempo Fora
-		"chutesGolTimeFora1Tempo", "chutesForaTimeFora1Tempo",
-		"chutesTraveTimeFora1Tempo", "chutesBloqueadoTimeFora1Tempo",
-		"escanteiosTimeFora1Tempo", "ataquesPerigososTimeFora1Tempo",
-		"penalidadesTimeFora1Tempo",
-
-		// Estatisticas 2 Tempo Fora
-		"chutesGolTimeFora2Tempo", "chutesForaTimeFora2Tempo",
-		"chutesTraveTimeFora2Tempo", "chutesBloqueadoTimeFora2Tempo",
-		"escanteiosTimeFora2Tempo", "ataquesPerigososTimeFora2Tempo",
-		"penalidadesTimeFora2Tempo",
-
-		// Estatisticas 5 Min Fora
-		"chutesGolTimeFora5Min", "chutesForaTimeFora5Min",
-		"chutesTraveTimeFora5Min", "chutesBloqueadoTimeFora5Min",
-		"escanteiosTimeFora5Min", "ataquesPerigososTimeFora5Min",
-		"penalidadesTimeFora5Min",
-
-		// Estatisticas 10 Min Fora
-		"chutesGolTimeFora10Min", "chutesForaTimeFora10Min",
-		"chutesTraveTimeFora10Min", "chutesBloqueadoTimeFora10Min",
-		"escanteiosTimeFora10Min", "ataquesPerigososTimeFora10Min",
-		"penalidadesTimeFora10Min",
-
-		// Classes CSS Time Fora
-		"classPosseBolaTimeFora", "classChutesGolTimeFora", "classChutesForaTimeFora",
-		"classChutesTraveTimeFora", "classChutesBloqueadoTimeFora", "classEscanteiosTimeFora",
-		"classAtaquesPerigososTimeFora", "classPenalidadesTimeFora",
-		"classProbabilidadesTimeFora", "classPontos10MinTimeFora",
-
-		// Classes CSS 1 Tempo Fora
-		"classChutesGolTimeFora1Tempo", "classChutesForaTimeFora1Tempo",
-		"classChutesTraveTimeFora1Tempo", "classChutesBloqueadoTimeFora1Tempo",
-		"classEscanteiosTimeFora1Tempo", "classAtaquesPerigososTimeFora1Tempo",
-		"classPenalidadesTimeFora1Tempo",
-
-		// Classes CSS 2 Tempo Fora
-		"classChutesGolTimeFora2Tempo", "classChutesForaTimeFora2Tempo",
-		"classChutesTraveTimeFora2Tempo", "classChutesBloqueadoTimeFora2Tempo",
-		"classEscanteiosTimeFora2Tempo", "classAtaquesPerigososTimeFora2Tempo",
-		"classPenalidadesTimeFora2Tempo",
-
-		// Classes CSS 5 Min Fora
-		"classChutesGolTimeFora5Min", "classChutesForaTimeFora5Min",
-		"classChutesTraveTimeFora5Min", "classChutesBloqueadoTimeFora5Min",
-		"classEscanteiosTimeFora5Min", "classAtaquesPerigososTimeFora5Min",
-		"classPenalidadesTimeFora5Min",
-
-		// Classes CSS 10 Min Fora
-		"classChutesGolTimeFora10Min", "classChutesForaTimeFora10Min",
-		"classChutesTraveTimeFora10Min", "classChutesBloqueadoTimeFora10Min",
-		"classEscanteiosTimeFora10Min", "classAtaquesPerigososTimeFora10Min",
-		"classPenalidadesTimeFora10Min",
-
-		// Acrescimos e previsao de acrescimos
-		"acrescimo1Tempo", "acrescimo2Tempo",
-		"classAcrescimo1Tempo", "classAcrescimo2Tempo",
-		"previsaoAcrescimo1Tempo", "previsaoAcrescimo2Tempo",
-		"classPrevisaoAcrescimo1Tempo", "classPrevisaoAcrescimo2Tempo",
-
-		// Analise IA
-		"analiseIA",
-	}
-
-	for _, campo := range camposZerar {
-		delete(copia, campo)
-	}
-
-	return copia
-}
@@ -1,8 +1,10 @@
 package services
 
 import (
+	"encoding/json"
 	"testing"
 
+	"radarfutebol-sse/internal/codec"
 	"radarfutebol-sse/internal/models"
 )
 
@@ -878,6 +880,101 @@ func TestEdgeCase_OddsVazias(t *testing.T) {
 	}
 }
 
+// =============================================================================
+// TESTES DE ESCOPO DE PARCEIRO (API key)
+// =============================================================================
+
+func TestEscopoParceiro_CampeonatosEOdds(t *testing.T) {
+	outro := criarEvento(2, "Boca", "River", "inprogress")
+	outro.IdCampeonatoUnico = "ar-primera"
+	outro.LinkBetfair = "https://betfair/2"
+	eventos := []*models.Evento{criarEvento(1, "Flamengo", "Palmeiras", "inprogress"), outro}
+
+	filtro := &models.Filtro{
+		CountJogosMostrar:     100,
+		CampeonatosPermitidos: map[string]bool{"ar-primera": true},
+		OcultarOdds:           true,
+	}
+
+	resultado, err := FiltrarEventosPainel(eventos, filtro, nil)
+	if err != nil {
+		t.Fatalf("Erro inesperado: %v", err)
+	}
+
+	if len(resultado.Eventos) != 1 || resultado.Eventos[0].IdEvento != 2 {
+		t.Fatalf("Esperado apenas evento 2 (campeonato liberado), recebeu %d eventos", len(resultado.Eventos))
+	}
+	if resultado.Eventos[0].OddTimeCasa != "" || resultado.Eventos[0].LinkBetfair != "" {
+		t.Error("Odds e links deveriam ser removidos sem o grupo odds")
+	}
+	if outro.LinkBetfair == "" {
+		t.Error("Evento original do cache nao pode ser modificado")
+	}
+}
+
+// =============================================================================
+// TESTES DE FRAGMENTOS PRE-SERIALIZADOS
+// =============================================================================
+
+func criarEventosFragmentos() []*models.Evento {
+	eventos := make([]*models.Evento, 30)
+	for i := range eventos {
+		eventos[i] = criarEvento(i+1, "Time <A>", "Time B", "inprogress")
+		eventos[i].IdCampeonatoUnico = "camp-" + string(rune('a'+i%4))
+		eventos[i].Prioridade = i % 3
+		eventos[i].ScoreLances10MinTimeCasa = "12"
+		eventos[i].LinhaDoTempo = []map[string]interface{}{{"favorito": false, "minuto": i}}
+	}
+	return eventos
+}
+
+// Montagem por fragmentos deve gerar exatamente o mesmo JSON do caminho por conexao
+func TestFragmentos_MesmoJSONDoResponse(t *testing.T) {
+	eventos := criarEventosFragmentos()
+	fragmentos, err := construirFragmentos(eventos)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prefs := &PreferenciasUsuario{
+		JogosFavoritos:       map[string]bool{"3": true, "10": true},
+		CampeonatosFavoritos: map[string]bool{"camp-b": true},
+	}
+
+	for _, assinante := range []bool{true, false} {
+		for _, p := range []*PreferenciasUsuario{nil, prefs} {
+			filtro := &models.Filtro{CountJogosMostrar: 20, IsAssinante: assinante}
+
+			painel, _ := FiltrarEventosPainel(eventos, filtro, p)
+			esperado, _ := json.Marshal(painel)
+			obtido, err := montarPainelJSON(eventos, fragmentos, filtro, p, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(obtido) != string(esperado) {
+				t.Errorf("Painel (assinante=%v, prefs=%v) difere do json.Marshal", assinante, p != nil)
+			}
+
+			home, _ := FiltrarEventosHome(eventos, filtro, p)
+			esperado, _ = json.Marshal(home)
+			obtido, err = montarHomeJSON(eventos, fragmentos, filtro, p, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(obtido) != string(esperado) {
+				t.Errorf("Home (assinante=%v, prefs=%v) difere do json.Marshal", assinante, p != nil)
+			}
+		}
+	}
+
+	// Favoritos de um usuario nao podem vazar para o cache compartilhado
+	for _, e := range eventos {
+		if e.Favorito.Bool() || e.CampeonatoFavorito.Bool() {
+			t.Fatalf("Evento %d do cache foi modificado", e.IdEvento)
+		}
+	}
+}
+
 // =============================================================================
 // BENCHMARK - Performance
 // =============================================================================
@@ -918,3 +1015,62 @@ func BenchmarkFiltrarEventosHome_100Eventos(b *testing.B) {
 		FiltrarEventosHome(eventos, filtro, nil)
 	}
 }
+
+// =============================================================================
+// BENCHMARK - Encoding (json x msgpack x cbor)
+// =============================================================================
+
+func benchmarkEncodingPainel(b *testing.B, encoding string) {
+	eventos := make([]*models.Evento, 100)
+	for i := 0; i < 100; i++ {
+		eventos[i] = criarEvento(i+1, "Time A", "Time B", "inprogress")
+	}
+
+	filtro := &models.Filtro{
+		CountJogosMostrar: 50,
+		IsAssinante:       true,
+	}
+	response, err := FiltrarEventosPainel(eventos, filtro, nil)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	var size int
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		data, err := codec.Marshal(encoding, response)
+		if err != nil {
+			b.Fatal(err)
+		}
+		size = len(data)
+	}
+	b.ReportMetric(float64(size), "bytes/payload")
+}
+
+func BenchmarkEncodingPainel_JSON(b *testing.B)    { benchmarkEncodingPainel(b, codec.JSON) }
+func BenchmarkEncodingPainel_MsgPack(b *testing.B) { benchmarkEncodingPainel(b, codec.MsgPack) }
+func BenchmarkEncodingPainel_CBOR(b *testing.B)    { benchmarkEncodingPainel(b, codec.CBOR) }
+
+func BenchmarkPainelJSON_PorConexao(b *testing.B) {
+	eventos := criarEventosFragmentos()
+	filtro := &models.Filtro{CountJogosMostrar: 50}
+	prefs := &PreferenciasUsuario{JogosFavoritos: map[string]bool{"1": true}}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		response, _ := FiltrarEventosPainel(eventos, filtro, prefs)
+		json.Marshal(response)
+	}
+}
+
+func BenchmarkPainelJSON_Fragmentos(b *testing.B) {
+	eventos := criarEventosFragmentos()
+	fragmentos, _ := construirFragmentos(eventos)
+	filtro := &models.Filtro{CountJogosMostrar: 50}
+	prefs := &PreferenciasUsuario{JogosFavoritos: map[string]bool{"1": true}}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		montarPainelJSON(eventos, fragmentos, filtro, prefs, nil)
+	}
+}
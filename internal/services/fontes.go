@@ -0,0 +1,135 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"radarfutebol-sse/internal/config"
+	"radarfutebol-sse/internal/replay"
+)
+
+// FonteEventos origem da lista de eventos lida pelo Broadcaster a cada ciclo
+// As fontes sao tentadas em ordem (event_source.order): vale a primeira com JSON valido
+type FonteEventos interface {
+	Nome() string
+
+	// Buscar retorna o JSON da lista de eventos, no formato da chave do Laravel
+	// Vazio = fonte sem dados no momento (passa para a proxima)
+	Buscar() (string, error)
+}
+
+// fontesEventos fontes em ordem de preferencia (a primeira e a primaria)
+var fontesEventos = []FonteEventos{fonteRedis{}}
+
+// InitFontesEventos monta as fontes de eventos na ordem configurada (antes de iniciar o Broadcaster)
+func InitFontesEventos(cfg config.EventSourceConfig) error {
+	fontes := make([]FonteEventos, 0, len(cfg.Order))
+	for _, nome := range cfg.Order {
+		switch nome {
+		case "redis":
+			fontes = append(fontes, fonteRedis{})
+		case "mysql":
+			fontes = append(fontes, &fonteMySQL{intervalo: cfg.MySQLInterval})
+		case "file":
+			fontes = append(fontes, &fonteArquivo{path: cfg.File})
+		case "replay":
+			player, err := replay.NewPlayer(cfg.ReplayFile, cfg.ReplaySpeed, cfg.ReplayLoop)
+			if err != nil {
+				return fmt.Errorf("replay: %w", err)
+			}
+			replayPlayer = player
+			fontes = append(fontes, fonteReplay{player: player})
+		default:
+			return fmt.Errorf("fonte de eventos desconhecida: %q", nome)
+		}
+	}
+	if len(fontes) == 0 {
+		return fmt.Errorf("nenhuma fonte de eventos configurada")
+	}
+	fontesEventos = fontes
+	log.Printf("Fontes de eventos: %v", cfg.Order)
+	return nil
+}
+
+// fonteRedis chave JSON gravada pelo Laravel (redis.eventos_key)
+type fonteRedis struct{}
+
+func (fonteRedis) Nome() string { return "redis" }
+
+func (fonteRedis) Buscar() (string, error) {
+	return getEventosRawFromRedis()
+}
+
+// fonteMySQL eventos montados das tabelas (sem estatisticas ao vivo)
+// Consulta no maximo uma vez por intervalo; entre consultas repete o ultimo resultado
+type fonteMySQL struct {
+	intervalo time.Duration
+
+	mu   sync.Mutex
+	data string
+	at   time.Time
+}
+
+func (f *fonteMySQL) Nome() string { return "mysql" }
+
+func (f *fonteMySQL) Buscar() (string, error) {
+	if db == nil {
+		return "", fmt.Errorf("mysql nao inicializado")
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.data != "" && time.Since(f.at) < f.intervalo {
+		return f.data, nil
+	}
+
+	eventos, err := getEventosFromDB()
+	if err != nil {
+		return "", err
+	}
+	if len(eventos) == 0 {
+		return "", nil
+	}
+	data, err := json.Marshal(eventos)
+	if err != nil {
+		return "", err
+	}
+	f.data, f.at = string(data), time.Now()
+	return f.data, nil
+}
+
+// fonteArquivo arquivo JSON local no formato da chave do Laravel (desenvolvimento)
+// Relido apenas quando o arquivo muda
+type fonteArquivo struct {
+	path string
+
+	mu      sync.Mutex
+	data    string
+	modTime time.Time
+}
+
+func (f *fonteArquivo) Nome() string { return "file" }
+
+func (f *fonteArquivo) Buscar() (string, error) {
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return "", err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if info.ModTime().Equal(f.modTime) {
+		return f.data, nil
+	}
+
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return "", err
+	}
+	f.data, f.modTime = string(data), info.ModTime()
+	return f.data, nil
+}
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// fonteTeste fonte de eventos com resposta fixa
+type fonteTeste struct {
+	nome string
+	data string
+	err  error
+}
+
+func (f *fonteTeste) Nome() string            { return f.nome }
+func (f *fonteTeste) Buscar() (string, error) { return f.data, f.err }
+
+func TestRefreshEventosCache_Failover(t *testing.T) {
+	primaria := &fonteTeste{nome: "redis", data: `[{"idEvento": 1, "status": "inprogress"}]`}
+	reserva := &fonteTeste{nome: "mysql", data: `[{"idEvento": 2, "status": "notstarted"}]`}
+	original := fontesEventos
+	fontesEventos = []FonteEventos{primaria, reserva}
+	defer func() { fontesEventos = original }()
+
+	b := novoBroadcasterTeste()
+	b.refreshEventosCache()
+	if snap := b.snapshotAtual(); snap.fonte != "redis" || snap.porId[1] == nil {
+		t.Fatalf("Esperava eventos da primaria, obteve fonte %q", snap.fonte)
+	}
+
+	// Primaria fora: failover para a reserva e modo degradado
+	primaria.err = errors.New("connection refused")
+	b.refreshEventosCache()
+	b.avaliarFrescor(time.Now())
+	if snap := b.snapshotAtual(); snap.fonte != "mysql" || snap.porId[2] == nil {
+		t.Fatalf("Esperava failover para mysql, obteve fonte %q", snap.fonte)
+	}
+	if st, _ := b.DadosStatus(); st.Status != "degraded" || st.Reason != "fallback_source" {
+		t.Errorf("Fonte de failover deveria ser degraded, obteve %+v", st)
+	}
+
+	// Primaria com JSON invalido tambem passa para a reserva
+	primaria.err, primaria.data = nil, `{"truncado`
+	b.refreshEventosCache()
+	if snap := b.snapshotAtual(); snap.fonte != "mysql" {
+		t.Errorf("JSON invalido deveria manter a reserva, obteve fonte %q", snap.fonte)
+	}
+
+	// Primaria de volta
+	primaria.data = `[{"idEvento": 1, "status": "inprogress"}]`
+	b.refreshEventosCache()
+	b.avaliarFrescor(time.Now())
+	if st, _ := b.DadosStatus(); b.snapshotAtual().fonte != "redis" || st.Status != "ok" {
+		t.Errorf("Esperava volta para a primaria com status ok, obteve %q %+v", b.snapshotAtual().fonte, st)
+	}
+
+	// Todas fora: mantem o ultimo snapshot bom e registra o erro
+	generation := b.Generation()
+	primaria.err, reserva.err = errors.New("fora"), errors.New("fora")
+	b.refreshEventosCache()
+	if b.Generation() != generation || b.refreshErr == nil {
+		t.Error("Sem fonte disponivel deveria manter o snapshot e registrar o erro")
+	}
+}
+
+func TestFonteArquivo_ReleQuandoMuda(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "eventos.json")
+	if err := os.WriteFile(path, []byte(`[{"idEvento": 1}]`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	fonte := &fonteArquivo{path: path}
+	data, err := fonte.Buscar()
+	if err != nil || data != `[{"idEvento": 1}]` {
+		t.Fatalf("Leitura inicial: %q %v", data, err)
+	}
+
+	os.WriteFile(path, []byte(`[{"idEvento": 2}]`), 0o644)
+	os.Chtimes(path, time.Now(), time.Now().Add(time.Second))
+	if data, _ := fonte.Buscar(); data != `[{"idEvento": 2}]` {
+		t.Errorf("Arquivo alterado deveria ser relido, obteve %q", data)
+	}
+}
@@ -0,0 +1,221 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"radarfutebol-sse/internal/codec"
+	"radarfutebol-sse/internal/models"
+)
+
+// Indices do tier nos fragmentos pre-serializados
+const (
+	tierAssinante = 0
+	tierFree      = 1
+)
+
+// eventoFragmento JSON de um evento serializado uma vez por snapshot
+// Serializado com favorito=false e campeonatoFavorito=false; as posicoes dos dois
+// valores permitem trocar por true na montagem da resposta de cada usuario
+type eventoFragmento struct {
+	json       []byte
+	favPos     int // offset do valor de "favorito"
+	campFavPos int // offset do valor de "campeonatoFavorito"
+}
+
+// fragmentosSnapshot fragmentos de todos os eventos do snapshot, por tier
+type fragmentosSnapshot map[*models.Evento]*[2]eventoFragmento
+
+// construirFragmentos serializa cada evento do snapshot para os dois tiers
+func construirFragmentos(eventos []*models.Evento) (fragmentosSnapshot, error) {
+	fragmentos := make(fragmentosSnapshot, len(eventos))
+	for _, evento := range eventos {
+		base := *evento
+		base.Favorito = models.FlexBool(false)
+		base.CampeonatoFavorito = models.FlexBool(false)
+
+		var tiers [2]eventoFragmento
+		var err error
+		if tiers[tierAssinante], err = novoFragmento(&base); err != nil {
+			return nil, err
+		}
+		if tiers[tierFree], err = novoFragmento(base.FiltrarParaFree()); err != nil {
+			return nil, err
+		}
+		fragmentos[evento] = &tiers
+	}
+	return fragmentos, nil
+}
+
+// novoFragmento serializa o evento e localiza os valores de favorito/campeonatoFavorito
+// As posicoes sao achadas comparando com a serializacao com cada flag ligada,
+// sem depender de busca textual (linhaDoTempo pode ter chaves com o mesmo nome)
+func novoFragmento(evento *models.Evento) (eventoFragmento, error) {
+	data, err := json.Marshal(evento)
+	if err != nil {
+		return eventoFragmento{}, err
+	}
+
+	comFav := *evento
+	comFav.Favorito = models.FlexBool(true)
+	dataFav, err := json.Marshal(&comFav)
+	if err != nil {
+		return eventoFragmento{}, err
+	}
+
+	comCampFav := *evento
+	comCampFav.CampeonatoFavorito = models.FlexBool(true)
+	dataCampFav, err := json.Marshal(&comCampFav)
+	if err != nil {
+		return eventoFragmento{}, err
+	}
+
+	favPos := primeiraDiferenca(data, dataFav)
+	campFavPos := primeiraDiferenca(data, dataCampFav)
+	if favPos < 0 || campFavPos <= favPos {
+		return eventoFragmento{}, fmt.Errorf("fragmento: favoritos nao localizados (evento %d)", evento.IdEvento)
+	}
+
+	return eventoFragmento{json: data, favPos: favPos, campFavPos: campFavPos}, nil
+}
+
+// primeiraDiferenca retorna o primeiro offset em que a e b diferem (-1 se iguais)
+func primeiraDiferenca(a, b []byte) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return i
+		}
+	}
+	return -1
+}
+
+// writeTo escreve o fragmento com as flags de favorito do usuario
+func (f *eventoFragmento) writeTo(buf *bytes.Buffer, favorito, campeonatoFavorito bool) {
+	if !favorito && !campeonatoFavorito {
+		buf.Write(f.json)
+		return
+	}
+
+	buf.Write(f.json[:f.favPos])
+	buf.WriteString(strconv.FormatBool(favorito))
+	buf.Write(f.json[f.favPos+len("false") : f.campFavPos])
+	buf.WriteString(strconv.FormatBool(campeonatoFavorito))
+	buf.Write(f.json[f.campFavPos+len("false"):])
+}
+
+// usaFragmentos indica se a resposta pode ser montada pelos fragmentos
+// (JSON completo sem escopo de odds; projecao e formatos binarios serializam o response)
+func usaFragmentos(filtro *models.Filtro) bool {
+	return (filtro.Encoding == "" || filtro.Encoding == codec.JSON) && filtro.Projecao == nil && !filtro.OcultarOdds
+}
+
+// tierDoFiltro indice do tier do usuario nos fragmentos
+func tierDoFiltro(filtro *models.Filtro) int {
+	if filtro.IsAssinante {
+		return tierAssinante
+	}
+	return tierFree
+}
+
+// montarPainelJSON monta o JSON do painel concatenando os fragmentos (mesmo resultado de FiltrarEventosPainel + json.Marshal)
+func montarPainelJSON(eventos []*models.Evento, fragmentos fragmentosSnapshot, filtro *models.Filtro, prefs *PreferenciasUsuario, alertas PrefsStore) ([]byte, error) {
+	selecionados, counts := selecionarEventos(eventos, filtro, prefs, alertas)
+	favoritos := favoritosDoUsuario(prefs)
+
+	ordenarEventosPainelPor(selecionados, filtro.OrdemInicio, favoritos)
+	if filtro.CountJogosMostrar > 0 && len(selecionados) > filtro.CountJogosMostrar {
+		selecionados = selecionados[:filtro.CountJogosMostrar]
+	}
+
+	tier := tierDoFiltro(filtro)
+	var buf bytes.Buffer
+	buf.WriteString(`{"eventos":[`)
+	for i, evento := range selecionados {
+		fragmento, ok := fragmentos[evento]
+		if !ok {
+			return nil, fmt.Errorf("fragmento ausente para o evento %d", evento.IdEvento)
+		}
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		fav, campFav := favoritos(evento)
+		fragmento[tier].writeTo(&buf, fav, campFav)
+	}
+	buf.WriteString(`],"counts":`)
+	if err := writeJSON(&buf, counts); err != nil {
+		return nil, err
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// montarHomeJSON monta o JSON da home concatenando os fragmentos (mesmo resultado de FiltrarEventosHome + json.Marshal)
+func montarHomeJSON(eventos []*models.Evento, fragmentos fragmentosSnapshot, filtro *models.Filtro, prefs *PreferenciasUsuario, alertas PrefsStore) ([]byte, error) {
+	selecionados, counts := selecionarEventos(eventos, filtro, prefs, alertas)
+	favoritos := favoritosDoUsuario(prefs)
+
+	ordenarEventosHomePor(selecionados, filtro.OrdemInicio, favoritos)
+	campeonatos := agruparPorCampeonato(selecionados, filtro.CountJogosMostrar, prefs)
+
+	tier := tierDoFiltro(filtro)
+	var buf bytes.Buffer
+	buf.WriteString(`{"campeonatos":[`)
+	for i, campeonato := range campeonatos {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+
+		// Campeonato sem eventos termina em "eventos":{}} - reabre o objeto para inserir os fragmentos
+		eventosCampeonato := campeonato.Eventos
+		campeonato.Eventos = map[string]*models.Evento{}
+		data, err := json.Marshal(campeonato)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(data[:len(data)-len("}}")])
+
+		// Mesma ordem do json.Marshal (chaves do map ordenadas)
+		ids := make([]string, 0, len(eventosCampeonato))
+		for id := range eventosCampeonato {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+
+		for j, id := range ids {
+			evento := eventosCampeonato[id]
+			fragmento, ok := fragmentos[evento]
+			if !ok {
+				return nil, fmt.Errorf("fragmento ausente para o evento %d", evento.IdEvento)
+			}
+			if j > 0 {
+				buf.WriteByte(',')
+			}
+			writeJSON(&buf, id)
+			buf.WriteByte(':')
+			fav, campFav := favoritos(evento)
+			fragmento[tier].writeTo(&buf, fav, campFav)
+		}
+		buf.WriteString("}}")
+	}
+	buf.WriteString(`],"counts":`)
+	if err := writeJSON(&buf, counts); err != nil {
+		return nil, err
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// writeJSON serializa v direto no buffer
+func writeJSON(buf *bytes.Buffer, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	buf.Write(data)
+	return nil
+}
@@ -0,0 +1,75 @@
+package services
+
+import (
+	"log"
+	"time"
+)
+
+// DadosStatus frescor dos dados servidos aos clientes (event: status)
+type DadosStatus struct {
+	Status      string `json:"status"`           // ok, degraded ou stale
+	Reason      string `json:"reason,omitempty"` // source_unreachable, fallback_source, source_not_updating ou warm_start
+	SourceAgeMs int64  `json:"sourceAgeMs"`      // desde a ultima mudanca do JSON no Redis
+	Since       int64  `json:"since"`            // inicio do estado atual (unix)
+}
+
+// SetLimitesFrescor altera os limites de degraded (sem leitura bem sucedida do Redis)
+// e stale (JSON do Laravel parado com jogos ao vivo)
+func (b *Broadcaster) SetLimitesFrescor(degradedAfter, staleAfter time.Duration) {
+	b.degradedAfter.Store(int64(degradedAfter))
+	b.staleAfter.Store(int64(staleAfter))
+}
+
+// DadosStatus retorna o estado atual e um canal fechado na proxima mudanca de estado
+func (b *Broadcaster) DadosStatus() (DadosStatus, <-chan struct{}) {
+	b.mu.RLock()
+	status, changed := b.dadosStatus, b.dadosStatusChan
+	b.mu.RUnlock()
+
+	if snap := b.snapshotAtual(); snap.generation > 0 {
+		status.SourceAgeMs = time.Since(snap.createdAt).Milliseconds()
+	}
+	return status, changed
+}
+
+// avaliarFrescor recalcula o estado apos cada ciclo de atualizacao
+// degraded: nenhuma fonte com leitura bem sucedida ha mais de degradedAfter (snapshot antigo
+// segue servido) ou eventos vindo de uma fonte de failover (MySQL sem estatisticas ao vivo)
+// stale: Redis responde mas o JSON nao muda ha mais de staleAfter com jogos ao vivo
+// (relogio congelado; sem jogo ao vivo o JSON parado e normal) ou snapshot veio do disco
+func (b *Broadcaster) avaliarFrescor(now time.Time) {
+	snap := b.snapshotAtual()
+	if snap.generation == 0 {
+		return // nada servido ainda
+	}
+
+	novo := DadosStatus{Status: "ok"}
+
+	b.mu.Lock()
+	switch {
+	case b.warm.Load():
+		novo.Status, novo.Reason = "stale", "warm_start"
+	case now.Sub(b.eventosCacheAt) > time.Duration(b.degradedAfter.Load()):
+		novo.Status, novo.Reason = "degraded", "source_unreachable"
+	case snap.fonte != "" && snap.fonte != b.fontePrimaria():
+		novo.Status, novo.Reason = "degraded", "fallback_source"
+	case snap.aoVivo > 0 && now.Sub(snap.createdAt) > time.Duration(b.staleAfter.Load()):
+		novo.Status, novo.Reason = "stale", "source_not_updating"
+	}
+	if novo.Status == b.dadosStatus.Status {
+		b.mu.Unlock()
+		return
+	}
+	anterior := b.dadosStatus.Status
+	novo.Since = now.Unix()
+	b.dadosStatus = novo
+	close(b.dadosStatusChan)
+	b.dadosStatusChan = make(chan struct{})
+	b.mu.Unlock()
+
+	if novo.Status == "ok" {
+		log.Printf("Broadcaster: dados normalizados (estava %s)", anterior)
+	} else {
+		log.Printf("Broadcaster: dados %s (%s), JSON sem mudar ha %v", novo.Status, novo.Reason, now.Sub(snap.createdAt).Round(time.Second))
+	}
+}
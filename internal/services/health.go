@@ -0,0 +1,154 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// DepStatus estado de uma dependencia no /health/deps
+type DepStatus struct {
+	Status      string  `json:"status"` // ok, down ou disabled (nao configurada)
+	LatencyMs   float64 `json:"latencyMs"`
+	LastError   string  `json:"lastError,omitempty"`
+	LastErrorAt int64   `json:"lastErrorAt,omitempty"` // unix
+
+	Breaker BreakerStatus `json:"breaker"` // circuito das chamadas normais (o ping ignora o breaker)
+}
+
+// depErrors ultimo erro de cada dependencia (mantido entre as verificacoes)
+var depErrors = struct {
+	sync.Mutex
+	m map[string]depError
+}{m: make(map[string]depError)}
+
+type depError struct {
+	msg string
+	at  time.Time
+}
+
+// errDepDisabled dependencia nao inicializada (ex: MySQL opcional indisponivel no startup)
+var errDepDisabled = errors.New("nao inicializado")
+
+// depChecks ping de cada dependencia
+var depChecks = map[string]func(context.Context) error{
+	"redis": func(ctx context.Context) error {
+		if rdb == nil {
+			return errDepDisabled
+		}
+		return rdb.Ping(ctx).Err()
+	},
+	"redisPrefs": func(ctx context.Context) error {
+		if rdbPrefs == nil {
+			return errDepDisabled
+		}
+		return rdbPrefs.Ping(ctx).Err()
+	},
+	"mysql": func(ctx context.Context) error {
+		if db == nil {
+			return errDepDisabled
+		}
+		return db.PingContext(ctx)
+	},
+}
+
+// PingRedis verifica o Redis principal (eventos e oraculo)
+func PingRedis(ctx context.Context) (time.Duration, error) {
+	return checkDep(ctx, "redis", depChecks["redis"])
+}
+
+// CheckDeps verifica Redis, Redis de preferencias e MySQL em paralelo
+func CheckDeps(ctx context.Context) map[string]DepStatus {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	result := make(map[string]DepStatus, len(depChecks))
+	circuitos := BreakersStatus()
+	for name, ping := range depChecks {
+		wg.Add(1)
+		go func(name string, ping func(context.Context) error) {
+			defer wg.Done()
+			latency, err := checkDep(ctx, name, ping)
+			status := DepStatus{Status: "ok", LatencyMs: float64(latency.Microseconds()) / 1000, Breaker: circuitos[name]}
+			switch {
+			case err == errDepDisabled:
+				status.Status = "disabled"
+			case err != nil:
+				status.Status = "down"
+			}
+
+			depErrors.Lock()
+			if last, ok := depErrors.m[name]; ok {
+				status.LastError = last.msg
+				status.LastErrorAt = last.at.Unix()
+			}
+			depErrors.Unlock()
+
+			mu.Lock()
+			result[name] = status
+			mu.Unlock()
+		}(name, ping)
+	}
+	wg.Wait()
+
+	return result
+}
+
+// checkDep mede a latencia do ping e guarda o erro (se houver)
+func checkDep(ctx context.Context, name string, ping func(context.Context) error) (time.Duration, error) {
+	start := time.Now()
+	err := ping(ctx)
+	latency := time.Since(start)
+
+	if err != nil && err != errDepDisabled {
+		depErrors.Lock()
+		depErrors.m[name] = depError{msg: err.Error(), at: time.Now()}
+		depErrors.Unlock()
+	}
+	return latency, err
+}
+
+// SnapshotStatus estado do snapshot de eventos no /health/deps
+type SnapshotStatus struct {
+	Status       string `json:"status"` // ok, stale ou empty (ainda nao carregado)
+	Generation   uint64 `json:"generation"`
+	Eventos      int    `json:"eventos"`
+	Fonte        string `json:"source,omitempty"` // fonte de eventos atual (redis, mysql ou file)
+	AgeMs        int64  `json:"ageMs"`            // desde a ultima leitura bem sucedida do Redis
+	ChangedAgoMs int64  `json:"changedAgoMs"`     // desde a ultima mudanca do JSON
+	LastError    string `json:"lastError,omitempty"`
+	LastErrorAt  int64  `json:"lastErrorAt,omitempty"`
+
+	Dados DadosStatus `json:"dados"` // frescor informado aos clientes (event: status)
+}
+
+// SnapshotStatus retorna o estado do snapshot (stale se a ultima leitura for mais velha que maxAge)
+func (b *Broadcaster) SnapshotStatus(maxAge time.Duration) SnapshotStatus {
+	snap := b.snapshotAtual()
+
+	b.mu.RLock()
+	refreshedAt, lastErr, lastErrAt := b.eventosCacheAt, b.refreshErr, b.refreshErrAt
+	b.mu.RUnlock()
+
+	status := SnapshotStatus{
+		Status:     "ok",
+		Generation: snap.generation,
+		Eventos:    len(snap.eventos),
+		Fonte:      snap.fonte,
+	}
+	if snap.generation == 0 {
+		status.Status = "empty"
+	} else {
+		status.AgeMs = time.Since(refreshedAt).Milliseconds()
+		status.ChangedAgoMs = time.Since(snap.createdAt).Milliseconds()
+		if time.Since(refreshedAt) > maxAge {
+			status.Status = "stale"
+		}
+	}
+	status.Dados, _ = b.DadosStatus()
+	if lastErr != nil {
+		status.LastError = lastErr.Error()
+		status.LastErrorAt = lastErrAt.Unix()
+	}
+	return status
+}
@@ -0,0 +1,335 @@
+package services
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"radarfutebol-sse/internal/config"
+	"radarfutebol-sse/internal/models"
+)
+
+// jwtLeeway tolerancia de relogio para exp/nbf
+const jwtLeeway = 30 * time.Second
+
+// jwksCheckInterval intervalo minimo entre verificacoes de mudanca no arquivo JWKS
+const jwksCheckInterval = 30 * time.Second
+
+// jwtKey chave de verificacao (HS256 ou RS256)
+type jwtKey struct {
+	alg     string
+	hmacKey []byte
+	rsaKey  *rsa.PublicKey
+}
+
+// jwtClaims claims aceitos no token emitido pelo Laravel
+// sub = id do usuario, tier = "assinante"/"free" (ou team_id, mesma regra do MySQL)
+type jwtClaims struct {
+	Sub    models.FlexInt `json:"sub"`
+	TeamId models.FlexInt `json:"team_id"`
+	Tier   string         `json:"tier"`
+	Exp    int64          `json:"exp"`
+	Nbf    int64          `json:"nbf"`
+	Iat    int64          `json:"iat"`
+	Iss    string         `json:"iss"`
+}
+
+// jwtVerifier valida JWTs com chaves da configuracao e do arquivo JWKS
+type jwtVerifier struct {
+	mu     sync.RWMutex
+	static []*jwtKey          // chaves fixas (config), usadas quando o token nao tem kid conhecido
+	byKid  map[string]*jwtKey // chaves do JWKS indexadas por kid
+
+	issuer string
+
+	jwksFile      string
+	jwksModTime   time.Time
+	jwksCheckedAt time.Time
+}
+
+var jwtAuth *jwtVerifier
+
+// InitJWT configura a autenticacao por JWT (opcional)
+// Sem nenhuma chave configurada, tokens continuam sendo validados apenas no MySQL
+func InitJWT(cfg config.AuthConfig) error {
+	v := &jwtVerifier{
+		byKid:    make(map[string]*jwtKey),
+		issuer:   cfg.JWTIssuer,
+		jwksFile: cfg.JWTJWKSFile,
+	}
+
+	if cfg.JWTSecret != "" {
+		v.static = append(v.static, &jwtKey{alg: "HS256", hmacKey: []byte(cfg.JWTSecret)})
+	}
+
+	if cfg.JWTPublicKeyFile != "" {
+		key, err := loadRSAPublicKey(cfg.JWTPublicKeyFile)
+		if err != nil {
+			return fmt.Errorf("erro ao carregar chave publica JWT: %w", err)
+		}
+		v.static = append(v.static, &jwtKey{alg: "RS256", rsaKey: key})
+	}
+
+	if v.jwksFile != "" {
+		if err := v.reloadJWKS(); err != nil {
+			return fmt.Errorf("erro ao carregar JWKS: %w", err)
+		}
+	}
+
+	if len(v.static) == 0 && v.jwksFile == "" {
+		return nil
+	}
+
+	jwtAuth = v
+	log.Printf("Auth: JWT habilitado (chaves fixas: %d, JWKS: %q)", len(v.static), v.jwksFile)
+	return nil
+}
+
+// looksLikeJWT verifica se o token tem o formato header.payload.assinatura
+func looksLikeJWT(token string) bool {
+	return strings.Count(token, ".") == 2
+}
+
+// validateJWT valida o JWT e monta o resultado a partir dos claims (sem MySQL)
+// Retorna tambem o iat, para aplicar revogacoes posteriores a emissao
+func validateJWT(token string) (AuthResult, int64) {
+	claims, err := jwtAuth.verify(token, time.Now())
+	if err != nil {
+		log.Printf("Auth: JWT invalido: %v", err)
+		return AuthResult{IsValid: false}, 0
+	}
+
+	isAssinante := IsAssinanteTeamId(claims.TeamId.Int())
+	if claims.Tier != "" {
+		isAssinante = claims.Tier == "assinante"
+	}
+
+	return AuthResult{
+		IdUsuario:   claims.Sub.Int(),
+		IsValid:     true,
+		IsAssinante: isAssinante,
+		TeamId:      claims.TeamId.Int(),
+	}, claims.Iat
+}
+
+// verify confere assinatura, algoritmo e validade temporal do token
+func (v *jwtVerifier) verify(token string, now time.Time) (*jwtClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("formato invalido")
+	}
+
+	headerJSON, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("header invalido: %w", err)
+	}
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := json.Unmarshal(headerJSON, &header); err != nil {
+		return nil, fmt.Errorf("header invalido: %w", err)
+	}
+	if header.Alg != "HS256" && header.Alg != "RS256" {
+		return nil, fmt.Errorf("algoritmo nao suportado: %q", header.Alg)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("assinatura invalida: %w", err)
+	}
+
+	signed := []byte(parts[0] + "." + parts[1])
+	verified := false
+	for _, key := range v.keysFor(header.Kid, now) {
+		// O algoritmo vem da chave, nunca do token (evita troca RS256 -> HS256)
+		if key.alg == header.Alg && key.verify(signed, signature) {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, errors.New("assinatura nao confere")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("payload invalido: %w", err)
+	}
+	var claims jwtClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("payload invalido: %w", err)
+	}
+
+	if claims.Exp == 0 || now.After(time.Unix(claims.Exp, 0).Add(jwtLeeway)) {
+		return nil, errors.New("token expirado")
+	}
+	if claims.Nbf != 0 && now.Add(jwtLeeway).Before(time.Unix(claims.Nbf, 0)) {
+		return nil, errors.New("token ainda nao valido")
+	}
+	if v.issuer != "" && claims.Iss != v.issuer {
+		return nil, fmt.Errorf("issuer invalido: %q", claims.Iss)
+	}
+	if claims.Sub.Int() <= 0 {
+		return nil, errors.New("sub ausente")
+	}
+
+	return &claims, nil
+}
+
+// keysFor retorna as chaves candidatas para o kid do token
+func (v *jwtVerifier) keysFor(kid string, now time.Time) []*jwtKey {
+	v.maybeReloadJWKS(now)
+
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	if key, ok := v.byKid[kid]; ok && kid != "" {
+		return []*jwtKey{key}
+	}
+	return v.static
+}
+
+// maybeReloadJWKS recarrega o JWKS se o arquivo mudou (rotacao de chaves)
+func (v *jwtVerifier) maybeReloadJWKS(now time.Time) {
+	if v.jwksFile == "" {
+		return
+	}
+
+	v.mu.RLock()
+	recent := now.Sub(v.jwksCheckedAt) < jwksCheckInterval
+	v.mu.RUnlock()
+	if recent {
+		return
+	}
+
+	if err := v.reloadJWKS(); err != nil {
+		log.Printf("Auth: Erro ao recarregar JWKS (mantendo chaves atuais): %v", err)
+	}
+}
+
+// reloadJWKS le o arquivo JWKS se o mtime mudou desde a ultima carga
+func (v *jwtVerifier) reloadJWKS() error {
+	v.mu.Lock()
+	v.jwksCheckedAt = time.Now()
+	v.mu.Unlock()
+
+	info, err := os.Stat(v.jwksFile)
+	if err != nil {
+		return err
+	}
+
+	v.mu.RLock()
+	unchanged := info.ModTime().Equal(v.jwksModTime)
+	v.mu.RUnlock()
+	if unchanged {
+		return nil
+	}
+
+	data, err := os.ReadFile(v.jwksFile)
+	if err != nil {
+		return err
+	}
+	keys, err := parseJWKS(data)
+	if err != nil {
+		return err
+	}
+
+	v.mu.Lock()
+	v.byKid = keys
+	v.jwksModTime = info.ModTime()
+	v.mu.Unlock()
+
+	log.Printf("Auth: JWKS carregado (%d chaves)", len(keys))
+	return nil
+}
+
+// parseJWKS converte um JWKS (RFC 7517) em chaves indexadas por kid
+// Suporta kty=RSA (RS256) e kty=oct (HS256)
+func parseJWKS(data []byte) (map[string]*jwtKey, error) {
+	var set struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+			K   string `json:"k"`
+		} `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("JWKS invalido: %w", err)
+	}
+
+	keys := make(map[string]*jwtKey)
+	for _, k := range set.Keys {
+		if k.Kid == "" {
+			continue
+		}
+		switch k.Kty {
+		case "RSA":
+			n, errN := base64.RawURLEncoding.DecodeString(k.N)
+			e, errE := base64.RawURLEncoding.DecodeString(k.E)
+			if errN != nil || errE != nil {
+				return nil, fmt.Errorf("chave RSA %q invalida", k.Kid)
+			}
+			keys[k.Kid] = &jwtKey{alg: "RS256", rsaKey: &rsa.PublicKey{
+				N: new(big.Int).SetBytes(n),
+				E: int(new(big.Int).SetBytes(e).Int64()),
+			}}
+		case "oct":
+			secret, err := base64.RawURLEncoding.DecodeString(k.K)
+			if err != nil {
+				return nil, fmt.Errorf("chave oct %q invalida", k.Kid)
+			}
+			keys[k.Kid] = &jwtKey{alg: "HS256", hmacKey: secret}
+		}
+	}
+	return keys, nil
+}
+
+// verify confere a assinatura com a chave
+func (k *jwtKey) verify(signed, signature []byte) bool {
+	switch k.alg {
+	case "HS256":
+		mac := hmac.New(sha256.New, k.hmacKey)
+		mac.Write(signed)
+		return hmac.Equal(mac.Sum(nil), signature)
+	case "RS256":
+		hash := sha256.Sum256(signed)
+		return rsa.VerifyPKCS1v15(k.rsaKey, crypto.SHA256, hash[:], signature) == nil
+	}
+	return false
+}
+
+// loadRSAPublicKey le chave publica RSA em PEM (PKIX ou PKCS1)
+func loadRSAPublicKey(path string) (*rsa.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("PEM invalido")
+	}
+
+	if pub, err := x509.ParsePKIXPublicKey(block.Bytes); err == nil {
+		if rsaKey, ok := pub.(*rsa.PublicKey); ok {
+			return rsaKey, nil
+		}
+		return nil, errors.New("chave publica nao e RSA")
+	}
+	return x509.ParsePKCS1PublicKey(block.Bytes)
+}
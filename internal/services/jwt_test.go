@@ -0,0 +1,152 @@
+package services
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// Helper para assinar JWT de teste
+func assinarJWT(t *testing.T, alg, kid string, claims map[string]interface{}, hsKey []byte, rsKey *rsa.PrivateKey) string {
+	t.Helper()
+	header, _ := json.Marshal(map[string]string{"alg": alg, "typ": "JWT", "kid": kid})
+	payload, _ := json.Marshal(claims)
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	var sig []byte
+	switch alg {
+	case "HS256":
+		mac := hmac.New(sha256.New, hsKey)
+		mac.Write([]byte(signed))
+		sig = mac.Sum(nil)
+	case "RS256":
+		hash := sha256.Sum256([]byte(signed))
+		var err error
+		sig, err = rsa.SignPKCS1v15(rand.Reader, rsKey, crypto.SHA256, hash[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func TestJWT_HS256(t *testing.T) {
+	secret := []byte("segredo-hs256")
+	v := &jwtVerifier{static: []*jwtKey{{alg: "HS256", hmacKey: secret}}}
+	now := time.Now()
+
+	token := assinarJWT(t, "HS256", "", map[string]interface{}{
+		"sub": "42", "tier": "assinante", "exp": now.Add(time.Hour).Unix(),
+	}, secret, nil)
+
+	claims, err := v.verify(token, now)
+	if err != nil {
+		t.Fatalf("Token valido recusado: %v", err)
+	}
+	if claims.Sub.Int() != 42 || claims.Tier != "assinante" {
+		t.Errorf("Claims inesperados: %+v", claims)
+	}
+
+	// Assinatura com outro segredo
+	forjado := assinarJWT(t, "HS256", "", map[string]interface{}{
+		"sub": 42, "exp": now.Add(time.Hour).Unix(),
+	}, []byte("outro"), nil)
+	if _, err := v.verify(forjado, now); err == nil {
+		t.Error("Token com segredo errado deveria ser recusado")
+	}
+
+	// Expirado
+	expirado := assinarJWT(t, "HS256", "", map[string]interface{}{
+		"sub": 42, "exp": now.Add(-time.Hour).Unix(),
+	}, secret, nil)
+	if _, err := v.verify(expirado, now); err == nil {
+		t.Error("Token expirado deveria ser recusado")
+	}
+}
+
+func TestJWT_RS256ViaJWKS(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jwks, _ := json.Marshal(map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "2026-10",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString([]byte{1, 0, 1}),
+		}},
+	})
+	keys, err := parseJWKS(jwks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := &jwtVerifier{byKid: keys}
+	now := time.Now()
+
+	token := assinarJWT(t, "RS256", "2026-10", map[string]interface{}{
+		"sub": 7, "team_id": 5, "exp": now.Add(time.Hour).Unix(),
+	}, nil, key)
+	if _, err := v.verify(token, now); err != nil {
+		t.Fatalf("Token RS256 valido recusado: %v", err)
+	}
+
+	// Algoritmo trocado para HS256 nao pode usar a chave RSA
+	confuso := assinarJWT(t, "HS256", "2026-10", map[string]interface{}{
+		"sub": 7, "exp": now.Add(time.Hour).Unix(),
+	}, key.N.Bytes(), nil)
+	if _, err := v.verify(confuso, now); err == nil {
+		t.Error("Token HS256 com kid RSA deveria ser recusado")
+	}
+}
+
+func TestAutenticador_RevogacaoJWT(t *testing.T) {
+	secret := []byte("segredo-hs256")
+	jwtAuth = &jwtVerifier{static: []*jwtKey{{alg: "HS256", hmacKey: secret}}}
+	defer func() { jwtAuth = nil }()
+
+	store := NewAuthMemoria()
+	store.AddToken("tok-mysql", 42, 4)
+	auth := NewAutenticador(store)
+	now := time.Now()
+
+	jwtCom := func(iat time.Time) string {
+		return assinarJWT(t, "HS256", "", map[string]interface{}{
+			"sub": 42, "tier": "assinante", "iat": iat.Unix(), "exp": now.Add(time.Hour).Unix(),
+		}, secret, nil)
+	}
+	antigo := jwtCom(now.Add(-10 * time.Minute))
+
+	if r := auth.ValidateToken(antigo); !r.IsValid || !r.IsAssinante {
+		t.Fatalf("JWT sem revogacao deveria valer como nos claims: %+v", r)
+	}
+
+	// Plano cancelado: JWT emitido antes passa a valer como free
+	store.AddToken("tok-mysql", 42, 5)
+	auth.RegistrarMudancaDePlano(42)
+	if r := auth.ValidateToken(antigo); !r.IsValid || r.IsAssinante || r.TeamId != 5 {
+		t.Errorf("JWT anterior deveria receber o novo tier: %+v", r)
+	}
+
+	// Revogacao: JWT anterior recusado, JWT emitido depois aceito
+	auth.RegistrarRevogacao(42)
+	if r := auth.ValidateToken(antigo); r.IsValid {
+		t.Errorf("JWT anterior a revogacao deveria ser recusado: %+v", r)
+	}
+	if r := auth.ValidateToken(jwtCom(now.Add(time.Minute))); !r.IsValid || !r.IsAssinante {
+		t.Errorf("JWT emitido apos a revogacao deveria valer: %+v", r)
+	}
+
+	// Mudanca de plano posterior nao desfaz a revogacao
+	auth.RegistrarMudancaDePlano(42)
+	if r := auth.ValidateToken(antigo); r.IsValid {
+		t.Errorf("Revogacao foi sobrescrita pela mudanca de plano: %+v", r)
+	}
+}
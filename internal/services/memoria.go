@@ -0,0 +1,306 @@
+package services
+
+import (
+	"sync"
+	"time"
+
+	"radarfutebol-sse/internal/models"
+)
+
+// Stores em memoria para testes do Broadcaster e dos handlers (sem Redis/MySQL)
+
+// FonteMemoria fonte de eventos com o JSON definido pelo teste
+type FonteMemoria struct {
+	mu   sync.Mutex
+	data string
+	err  error
+}
+
+// NewFonteMemoria cria a fonte com o JSON inicial dos eventos
+func NewFonteMemoria(data string) *FonteMemoria {
+	return &FonteMemoria{data: data}
+}
+
+func (f *FonteMemoria) Nome() string { return "memoria" }
+
+func (f *FonteMemoria) Buscar() (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.data, f.err
+}
+
+// Set troca o JSON dos eventos (vale no proximo refresh)
+func (f *FonteMemoria) Set(data string) {
+	f.mu.Lock()
+	f.data, f.err = data, nil
+	f.mu.Unlock()
+}
+
+// SetErro simula a fonte fora do ar
+func (f *FonteMemoria) SetErro(err error) {
+	f.mu.Lock()
+	f.err = err
+	f.mu.Unlock()
+}
+
+// PrefsMemoria favoritos e alertas de gol por usuario
+type PrefsMemoria struct {
+	mu      sync.Mutex
+	prefs   map[int]*PreferenciasUsuario
+	alertas map[int]map[string]string
+}
+
+func NewPrefsMemoria() *PrefsMemoria {
+	return &PrefsMemoria{
+		prefs:   make(map[int]*PreferenciasUsuario),
+		alertas: make(map[int]map[string]string),
+	}
+}
+
+// SetFavoritos define campeonatos e jogos favoritos do usuario
+func (p *PrefsMemoria) SetFavoritos(userID int, campeonatos, jogos []string) {
+	prefs := &PreferenciasUsuario{
+		CampeonatosFavoritos: make(map[string]bool),
+		JogosFavoritos:       make(map[string]bool),
+	}
+	for _, id := range campeonatos {
+		prefs.CampeonatosFavoritos[id] = true
+	}
+	for _, id := range jogos {
+		prefs.JogosFavoritos[id] = true
+	}
+	p.mu.Lock()
+	p.prefs[userID] = prefs
+	p.mu.Unlock()
+}
+
+func (p *PrefsMemoria) Preferencias(userID int) (*PreferenciasUsuario, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if prefs := p.prefs[userID]; prefs != nil {
+		return prefs, nil
+	}
+	return &PreferenciasUsuario{
+		CampeonatosFavoritos: make(map[string]bool),
+		JogosFavoritos:       make(map[string]bool),
+	}, nil
+}
+
+func (p *PrefsMemoria) AlertasGol(userID int) map[string]string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	alertas := make(map[string]string, len(p.alertas[userID]))
+	for id, placar := range p.alertas[userID] {
+		alertas[id] = placar
+	}
+	return alertas
+}
+
+func (p *PrefsMemoria) SalvarAlertasGol(userID int, alertas map[string]string) {
+	p.mu.Lock()
+	p.alertas[userID] = alertas
+	p.mu.Unlock()
+}
+
+// AuthMemoria tokens conhecidos (como a tabela users) e cache com expiracao
+type AuthMemoria struct {
+	mu           sync.Mutex
+	tokens       map[string]AuthResult
+	cache        map[string]authMemoriaEntry
+	revogacoes   map[int]authMemoriaRevogacao
+	indisponivel bool
+	consultas    int
+}
+
+type authMemoriaEntry struct {
+	result   AuthResult
+	expiraEm time.Time
+}
+
+type authMemoriaRevogacao struct {
+	rev      RevogacaoJWT
+	expiraEm time.Time
+}
+
+func NewAuthMemoria() *AuthMemoria {
+	return &AuthMemoria{
+		tokens:     make(map[string]AuthResult),
+		cache:      make(map[string]authMemoriaEntry),
+		revogacoes: make(map[int]authMemoriaRevogacao),
+	}
+}
+
+// AddToken cadastra o token do usuario (team_id define o tier, como no MySQL)
+func (a *AuthMemoria) AddToken(token string, idUsuario, teamId int) {
+	a.mu.Lock()
+	a.tokens[token] = AuthResult{
+		IdUsuario:   idUsuario,
+		IsValid:     true,
+		IsAssinante: IsAssinanteTeamId(teamId),
+		TeamId:      teamId,
+	}
+	a.mu.Unlock()
+}
+
+// SetIndisponivel simula o MySQL fora (ConsultarToken retorna Unavailable)
+func (a *AuthMemoria) SetIndisponivel(indisponivel bool) {
+	a.mu.Lock()
+	a.indisponivel = indisponivel
+	a.mu.Unlock()
+}
+
+// Consultas quantidade de ConsultarToken (idas ao "MySQL")
+func (a *AuthMemoria) Consultas() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.consultas
+}
+
+func (a *AuthMemoria) LerCache(token string) (AuthResult, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	entry, ok := a.cache[token]
+	if !ok || time.Now().After(entry.expiraEm) {
+		return AuthResult{}, false
+	}
+	return entry.result, true
+}
+
+func (a *AuthMemoria) GravarCache(token string, result AuthResult, ttl time.Duration) {
+	a.mu.Lock()
+	a.cache[token] = authMemoriaEntry{result: result, expiraEm: time.Now().Add(ttl)}
+	a.mu.Unlock()
+}
+
+func (a *AuthMemoria) InvalidarCache(idUsuario int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for token, entry := range a.cache {
+		if entry.result.IdUsuario == idUsuario {
+			delete(a.cache, token)
+		}
+	}
+}
+
+func (a *AuthMemoria) ConsultarToken(token string) AuthResult {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.consultas++
+	if a.indisponivel {
+		return AuthResult{Unavailable: true}
+	}
+	if result, ok := a.tokens[token]; ok {
+		return result
+	}
+	return AuthResult{IsValid: false}
+}
+
+func (a *AuthMemoria) ConsultarUsuario(idUsuario int) AuthResult {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.consultas++
+	if a.indisponivel {
+		return AuthResult{Unavailable: true}
+	}
+	for _, result := range a.tokens {
+		if result.IdUsuario == idUsuario {
+			return result
+		}
+	}
+	return AuthResult{IsValid: false}
+}
+
+func (a *AuthMemoria) LerRevogacao(idUsuario int) (RevogacaoJWT, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	entry, ok := a.revogacoes[idUsuario]
+	if !ok || time.Now().After(entry.expiraEm) {
+		return RevogacaoJWT{}, false
+	}
+	return entry.rev, true
+}
+
+func (a *AuthMemoria) GravarRevogacao(idUsuario int, rev RevogacaoJWT, ttl time.Duration) {
+	a.mu.Lock()
+	a.revogacoes[idUsuario] = authMemoriaRevogacao{rev: rev, expiraEm: time.Now().Add(ttl)}
+	a.mu.Unlock()
+}
+
+// OraculoMemoria oraculos por jogo e status de eventos fora do snapshot
+type OraculoMemoria struct {
+	mu       sync.Mutex
+	oraculos map[string]*models.Oraculo
+	eventos  map[string]*EventoInfo
+}
+
+func NewOraculoMemoria() *OraculoMemoria {
+	return &OraculoMemoria{
+		oraculos: make(map[string]*models.Oraculo),
+		eventos:  make(map[string]*EventoInfo),
+	}
+}
+
+// SetOraculo define o oraculo do jogo (nil remove)
+func (o *OraculoMemoria) SetOraculo(idWilliamhill string, data *models.Oraculo) {
+	o.mu.Lock()
+	o.oraculos[idWilliamhill] = data
+	o.mu.Unlock()
+}
+
+// SetEventoInfo define o status do evento consultado fora do snapshot
+func (o *OraculoMemoria) SetEventoInfo(idWilliamhill string, info *EventoInfo) {
+	o.mu.Lock()
+	o.eventos[idWilliamhill] = info
+	o.mu.Unlock()
+}
+
+// Oraculo retorna uma copia (o merge modifica o retorno, como um oraculo recem decodificado)
+func (o *OraculoMemoria) Oraculo(idWilliamhill string) (*models.Oraculo, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	data := o.oraculos[idWilliamhill]
+	if data == nil {
+		return nil, nil
+	}
+	copia := *data
+	return &copia, nil
+}
+
+func (o *OraculoMemoria) EventoInfo(idWilliamhill string) (*EventoInfo, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	info := o.eventos[idWilliamhill]
+	if info == nil {
+		return nil, nil
+	}
+	copia := *info
+	return &copia, nil
+}
+
+// APIKeysMemoria chaves de parceiros cadastradas pelo teste
+type APIKeysMemoria struct {
+	mu   sync.Mutex
+	keys map[string]APIKey
+}
+
+func NewAPIKeysMemoria() *APIKeysMemoria {
+	return &APIKeysMemoria{keys: make(map[string]APIKey)}
+}
+
+// AddAPIKey cadastra a chave (Id = sha256 da chave, como no Redis)
+func (k *APIKeysMemoria) AddAPIKey(rawKey string, key APIKey) {
+	key.Id = HashAPIKey(rawKey)
+	k.mu.Lock()
+	k.keys[rawKey] = key
+	k.mu.Unlock()
+}
+
+func (k *APIKeysMemoria) APIKey(rawKey string) (*APIKey, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	key, ok := k.keys[rawKey]
+	if !ok {
+		return nil, nil
+	}
+	return &key, nil
+}
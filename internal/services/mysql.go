@@ -1,21 +1,30 @@
 package services
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
+	"strconv"
+	"time"
 
 	_ "github.com/go-sql-driver/mysql"
 	"radarfutebol-sse/internal/config"
+	"radarfutebol-sse/internal/models"
 )
 
 var db *sql.DB
 
+// mysqlQueryTimeout tempo maximo das consultas no caminho das conexoes (mysql.query_timeout)
+var mysqlQueryTimeout = 2 * time.Second
+
 // InitMySQL inicializa a conexao com o MySQL
 func InitMySQL(cfg config.MySQLConfig) error {
 	dsn := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?parseTime=true&charset=utf8mb4",
 		cfg.User, cfg.Password, cfg.Host, cfg.Port, cfg.Database)
 
+	mysqlQueryTimeout = cfg.QueryTimeout
+
 	var err error
 	db, err = sql.Open("mysql", dsn)
 	if err != nil {
@@ -38,162 +47,114 @@ func GetDB() *sql.DB {
 	return db
 }
 
-// GetEventosBase busca eventos base do banco de dados
-func GetEventosBase(filtro map[string]interface{}) ([]map[string]interface{}, error) {
+// getEventosFromDB monta os eventos ativos a partir de eventos/times/campeonatos/categorias
+// Usado pela fonte MySQL (failover da chave do Laravel): sem odds, placar e estatisticas ao vivo
+func getEventosFromDB() ([]*models.Evento, error) {
 	query := `
 		SELECT
-			e.id,
-			e.id_williamhill,
-			e.id_betfair,
-			e.status,
-			e.escalacao as tem_escalacao,
-			e.desconto_ht,
-			e.desconto_ft,
-			e.ativo,
-			e.oraculo,
-			e.oraculo_free,
-			e.over as over_evento,
-			e.laycs as lay_cs_evento,
-			e.problema_radar,
-			e.williamhill_ivertido,
-			e.inicio,
-			e.id_campeonato,
-			e.id_time_casa,
-			e.id_time_fora,
-			tc.name as time_casa,
-			tc.slug as slug_time_casa,
-			tc.tem_imagem as tem_imagem_time_casa,
-			tf.name as time_fora,
-			tf.slug as slug_time_fora,
-			tf.tem_imagem as tem_imagem_time_fora,
-			c.name as nome_campeonato,
-			c.name as nome_campeonato_reduzido,
-			c.slug as slug_campeonato,
-			c.prioridade,
-			c.classificacao as tem_classificacao,
-			cat.name as nome_categoria,
-			cat.slug as slug_categoria,
-			cat.flag
+			e.id, e.id_williamhill, e.id_betfair, e.status, e.escalacao,
+			e.desconto_ht, e.desconto_ft, e.oraculo, e.oraculo_free,
+			e.over, e.laycs, e.problema_radar, e.williamhill_ivertido, e.inicio,
+			e.id_campeonato, e.id_time_casa, e.id_time_fora,
+			tc.name, tc.slug, tf.name, tf.slug,
+			c.name, c.slug, c.prioridade, c.classificacao,
+			cat.name, cat.slug, cat.flag
 		FROM eventos e
 		LEFT JOIN times tc ON e.id_time_casa = tc.id
 		LEFT JOIN times tf ON e.id_time_fora = tf.id
 		LEFT JOIN campeonatos c ON e.id_campeonato = c.id
 		LEFT JOIN categorias cat ON c.id_categoria = cat.id
-		WHERE 1=1
-		ORDER BY
-			CASE e.status
-				WHEN 'first_half' THEN 1
-				WHEN 'second_half' THEN 2
-				WHEN 'half_time' THEN 3
-				WHEN 'extra_time' THEN 4
-				WHEN 'penalties' THEN 5
-				ELSE 6
-			END,
-			c.prioridade ASC,
-			e.inicio ASC
-		LIMIT 50
+		WHERE e.ativo = 1
+		ORDER BY c.prioridade ASC, e.inicio ASC
+		LIMIT 2000
 	`
 
-	rows, err := db.Query(query)
-	if err != nil {
-		return nil, fmt.Errorf("erro ao buscar eventos: %w", err)
-	}
-	defer rows.Close()
-
-	var eventos []map[string]interface{}
-
-	for rows.Next() {
-		var (
-			id, idTimeCasa, idTimeFora, idCampeonato int
-			idWilliamhill, idBetfair sql.NullString
-			status string
-			temEscalacao, ativo, oraculo, oraculoFree int
-			overEvento, layCsEvento, problemaRadar, williamhillIvertido int
-			descontoHt, descontoFt sql.NullInt64
-			inicio string
-			timeCasa, slugTimeCasa string
-			temImagemTimeCasa int
-			timeFora, slugTimeFora string
-			temImagemTimeFora int
-			nomeCampeonato, nomeCampeonatoReduzido, slugCampeonato string
-			prioridade, temClassificacao int
-			nomeCategoria, slugCategoria, flag sql.NullString
-		)
-
-		err := rows.Scan(
-			&id, &idWilliamhill, &idBetfair, &status, &temEscalacao,
-			&descontoHt, &descontoFt, &ativo, &oraculo, &oraculoFree,
-			&overEvento, &layCsEvento, &problemaRadar, &williamhillIvertido,
-			&inicio, &idCampeonato, &idTimeCasa, &idTimeFora,
-			&timeCasa, &slugTimeCasa, &temImagemTimeCasa,
-			&timeFora, &slugTimeFora, &temImagemTimeFora,
-			&nomeCampeonato, &nomeCampeonatoReduzido, &slugCampeonato,
-			&prioridade, &temClassificacao,
-			&nomeCategoria, &slugCategoria, &flag,
-		)
+	var eventos []*models.Evento
+	err := mysqlBreaker.Do(func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), mysqlQueryTimeout)
+		defer cancel()
+
+		rows, err := db.QueryContext(ctx, query)
 		if err != nil {
-			log.Printf("Erro ao scan evento: %v", err)
-			continue
+			return err
 		}
-
-		evento := map[string]interface{}{
-			"id":               id,
-			"idWilliamhill":    nullStringToString(idWilliamhill),
-			"idBetfair":        nullStringToString(idBetfair),
-			"status":           status,
-			"temEscalacao":     temEscalacao,
-			"descontoHt":       nullIntToPtr(descontoHt),
-			"descontoFt":       nullIntToPtr(descontoFt),
-			"ativo":            ativo,
-			"oraculo":          oraculo,
-			"oraculoFree":      oraculoFree,
-			"overEvento":       overEvento,
-			"layCsEvento":      layCsEvento,
-			"problemaRadar":    problemaRadar,
-			"williamhillIvertido": williamhillIvertido,
-			"inicio":           inicio,
-			"idCampeonato":     idCampeonato,
-			"idTimeCasa":       idTimeCasa,
-			"idTimeFora":       idTimeFora,
-			"timeCasa":         timeCasa,
-			"slugTimeCasa":     slugTimeCasa,
-			"timeFora":         timeFora,
-			"slugTimeFora":     slugTimeFora,
-			"nomeCampeonato":   nomeCampeonato,
-			"nomeCampeonatoReduzido": nomeCampeonatoReduzido,
-			"slugCampeonato":   slugCampeonato,
-			"prioridade":       prioridade,
-			"temClassificacao": temClassificacao,
-			"nomeCategoria":    nullStringToString(nomeCategoria),
-			"slugCategoria":    nullStringToString(slugCategoria),
-			"flag":             nullStringToString(flag),
+		defer rows.Close()
+
+		eventos = eventos[:0]
+		for rows.Next() {
+			var (
+				e                                  models.Evento
+				idWilliamhill, idBetfair           sql.NullString
+				descontoHt, descontoFt             sql.NullInt64
+				inicio                             sql.NullTime
+				timeCasa, slugTimeCasa             sql.NullString
+				timeFora, slugTimeFora             sql.NullString
+				nomeCampeonato, slugCampeonato     sql.NullString
+				prioridade, temClassificacao       sql.NullInt64
+				nomeCategoria, slugCategoria, flag sql.NullString
+			)
+			err := rows.Scan(
+				&e.IdEvento, &idWilliamhill, &idBetfair, &e.Status, &e.TemEscalacao,
+				&descontoHt, &descontoFt, &e.Oraculo, &e.OraculoFree,
+				&e.OverEvento, &e.LayCsEvento, &e.ProblemaRadar, &e.WilliamhillIvertido, &inicio,
+				&e.IdCampeonato, &e.IdTimeCasa, &e.IdTimeFora,
+				&timeCasa, &slugTimeCasa, &timeFora, &slugTimeFora,
+				&nomeCampeonato, &slugCampeonato, &prioridade, &temClassificacao,
+				&nomeCategoria, &slugCategoria, &flag,
+			)
+			if err != nil {
+				log.Printf("Erro ao scan evento: %v", err)
+				continue
+			}
+
+			e.IdWilliamhill = nullStringToString(idWilliamhill)
+			e.IdBetfair = nullStringToString(idBetfair)
+			e.Status = statusEventoDB(e.Status)
+			e.DescontoHt = nullIntToPtr(descontoHt)
+			e.DescontoFt = nullIntToPtr(descontoFt)
+			if inicio.Valid {
+				e.Inicio = inicio.Time.Format("2006-01-02 15:04")
+			}
+			e.TimeCasa = nullStringToString(timeCasa)
+			e.SlugTimeCasa = nullStringToString(slugTimeCasa)
+			e.TimeFora = nullStringToString(timeFora)
+			e.SlugTimeFora = nullStringToString(slugTimeFora)
+			e.IdCampeonatoUnico = strconv.Itoa(e.IdCampeonato)
+			e.NomeCampeonato = nullStringToString(nomeCampeonato)
+			e.NomeCampeonatoReduzido = e.NomeCampeonato
+			e.SlugCampeonato = nullStringToString(slugCampeonato)
+			e.Prioridade = int(prioridade.Int64)
+			e.TemClassificacao = int(temClassificacao.Int64)
+			e.NomeCategoria = nullStringToString(nomeCategoria)
+			e.SlugCategoria = nullStringToString(slugCategoria)
+			e.Flag = nullStringToString(flag)
+
+			eventos = append(eventos, &e)
 		}
-
-		eventos = append(eventos, evento)
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar eventos: %w", err)
 	}
 
 	return eventos, nil
 }
 
-// GetCountsByStatus retorna contadores de eventos
-func GetCountsByStatus() (live, total, gols int, err error) {
-	query := `
-		SELECT
-			SUM(CASE WHEN status IN ('first_half', 'second_half', 'half_time', 'extra_time', 'penalties') THEN 1 ELSE 0 END) as ao_vivo,
-			COUNT(*) as total
-		FROM eventos
-	`
-
-	err = db.QueryRow(query).Scan(&live, &total)
-	if err != nil {
-		return 0, 0, 0, fmt.Errorf("erro ao buscar counts: %w", err)
+// statusEventoDB converte o status da tabela eventos para o do JSON do Laravel
+// (periodos do jogo em andamento viram inprogress; demais passam direto)
+func statusEventoDB(status string) string {
+	switch status {
+	case "first_half", "second_half", "half_time", "extra_time", "penalties":
+		return "inprogress"
 	}
-
-	return live, total, 0, nil
+	return status
 }
 
 // getEventoInfoFromDB busca status, escalacao, problemaRadar e acrescimos do evento pelo idWilliamhill
 func getEventoInfoFromDB(idWilliamhill string) (*EventoInfo, error) {
+	if db == nil {
+		return nil, fmt.Errorf("mysql nao inicializado")
+	}
 	query := `SELECT status, escalacao, problema_radar, desconto_ht, desconto_ft FROM eventos WHERE id_williamhill = ? LIMIT 1`
 
 	var (
@@ -204,7 +165,11 @@ func getEventoInfoFromDB(idWilliamhill string) (*EventoInfo, error) {
 		descontoFt    sql.NullInt64
 	)
 
-	err := db.QueryRow(query, idWilliamhill).Scan(&status, &temEscalacao, &problemaRadar, &descontoHt, &descontoFt)
+	err := mysqlBreaker.Do(func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), mysqlQueryTimeout)
+		defer cancel()
+		return db.QueryRowContext(ctx, query, idWilliamhill).Scan(&status, &temEscalacao, &problemaRadar, &descontoHt, &descontoFt)
+	})
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
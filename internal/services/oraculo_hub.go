@@ -0,0 +1,315 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"hash/fnv"
+	"log"
+	"sync"
+	"time"
+
+	"radarfutebol-sse/internal/config"
+	"radarfutebol-sse/internal/models"
+)
+
+// oraculoHubInterval cadencia de atualizacao de cada jogo assistido (oraculo.hub_interval)
+var oraculoHubInterval = 2 * time.Second
+
+// InitOraculo aplica a configuracao do oraculo (antes de iniciar o Broadcaster)
+func InitOraculo(cfg config.OraculoConfig) {
+	oraculoHubInterval = cfg.HubInterval
+	eventoInfoTTL = cfg.EventoInfoTTL
+}
+
+// oraculoHub atualiza um jogo do oraculo uma unica vez por ciclo e avisa todas as conexoes
+// Existe enquanto houver conexoes assistindo o jogo
+type oraculoHub struct {
+	idWilliamhill string
+	broadcaster   *Broadcaster
+
+	mu       sync.RWMutex
+	data     *models.Oraculo // compartilhado: nao modificar
+	err      error
+	hash     uint64
+	watchers map[*OraculoWatcher]struct{}
+
+	ready chan struct{} // fechado apos a primeira atualizacao
+	stop  chan struct{}
+}
+
+// OraculoWatcher inscricao de uma conexao no hub de um jogo
+type OraculoWatcher struct {
+	hub *oraculoHub
+
+	// C recebe um sinal quando o hub obtem dados diferentes dos anteriores
+	C chan struct{}
+}
+
+// Latest retorna os dados mais recentes do jogo (nil = jogo nao encontrado no cache)
+// O oraculo e compartilhado entre as conexoes: copiar antes de modificar
+func (w *OraculoWatcher) Latest() (*models.Oraculo, error) {
+	return w.hub.latest()
+}
+
+// WatchOraculo inscreve a conexao no hub do jogo (criando o hub se for o primeiro)
+// Retorna apos a primeira atualizacao do hub; chamar a funcao devolvida ao encerrar
+func (b *Broadcaster) WatchOraculo(idWilliamhill string) (*OraculoWatcher, func()) {
+	watcher := &OraculoWatcher{C: make(chan struct{}, 1)}
+
+	b.oraculoHubsMu.Lock()
+	hub, exists := b.oraculoHubs[idWilliamhill]
+	if !exists {
+		hub = &oraculoHub{
+			idWilliamhill: idWilliamhill,
+			broadcaster:   b,
+			watchers:      make(map[*OraculoWatcher]struct{}),
+			ready:         make(chan struct{}),
+			stop:          make(chan struct{}),
+		}
+		b.oraculoHubs[idWilliamhill] = hub
+		go hub.run()
+	}
+	watcher.hub = hub
+	hub.mu.Lock()
+	hub.watchers[watcher] = struct{}{}
+	hub.mu.Unlock()
+	b.oraculoHubsMu.Unlock()
+
+	<-hub.ready
+
+	return watcher, func() { b.unwatchOraculo(watcher) }
+}
+
+// unwatchOraculo remove a conexao e encerra o hub quando sai a ultima
+func (b *Broadcaster) unwatchOraculo(watcher *OraculoWatcher) {
+	hub := watcher.hub
+
+	b.oraculoHubsMu.Lock()
+	defer b.oraculoHubsMu.Unlock()
+
+	hub.mu.Lock()
+	delete(hub.watchers, watcher)
+	vazio := len(hub.watchers) == 0
+	hub.mu.Unlock()
+
+	if vazio && b.oraculoHubs[hub.idWilliamhill] == hub {
+		delete(b.oraculoHubs, hub.idWilliamhill)
+		close(hub.stop)
+	}
+}
+
+// activeOraculoHub retorna o hub do jogo se houver conexoes assistindo
+func (b *Broadcaster) activeOraculoHub(idWilliamhill string) *oraculoHub {
+	b.oraculoHubsMu.Lock()
+	defer b.oraculoHubsMu.Unlock()
+	return b.oraculoHubs[idWilliamhill]
+}
+
+// OraculoHubs retorna quantos jogos do oraculo estao sendo assistidos
+func (b *Broadcaster) OraculoHubs() int {
+	b.oraculoHubsMu.Lock()
+	defer b.oraculoHubsMu.Unlock()
+	return len(b.oraculoHubs)
+}
+
+// run atualiza o jogo a cada oraculoHubInterval ate o hub ser encerrado
+func (h *oraculoHub) run() {
+	h.refresh()
+	close(h.ready)
+
+	ticker := time.NewTicker(oraculoHubInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-h.stop:
+			return
+		case <-h.broadcaster.stopChan:
+			return
+		case <-ticker.C:
+			h.refresh()
+		}
+	}
+}
+
+// refresh busca o jogo no Redis (uma vez para todas as conexoes) e avisa se mudou
+func (h *oraculoHub) refresh() {
+	data, err := h.broadcaster.fetchOraculo(h.idWilliamhill)
+
+	h.mu.Lock()
+	if err != nil {
+		// Mantem os ultimos dados bons; erro so aparece se nunca houve dados
+		// Com o circuito aberto nao loga a cada ciclo (o breaker loga ao abrir)
+		if !errors.Is(err, ErrCircuitOpen) {
+			log.Printf("Oraculo hub: erro ao atualizar jogo %s: %v", h.idWilliamhill, err)
+		}
+		h.err = err
+		h.mu.Unlock()
+		return
+	}
+
+	hash := hashOraculo(data)
+	changed := hash != h.hash
+	h.data, h.err, h.hash = data, nil, hash
+
+	watchers := make([]*OraculoWatcher, 0, len(h.watchers))
+	if changed {
+		for w := range h.watchers {
+			watchers = append(watchers, w)
+		}
+	}
+	h.mu.Unlock()
+
+	for _, w := range watchers {
+		select {
+		case w.C <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (h *oraculoHub) latest() (*models.Oraculo, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if h.data == nil && h.err != nil {
+		return nil, h.err
+	}
+	return h.data, nil
+}
+
+// hashOraculo hash do conteudo serializado
+func hashOraculo(data *models.Oraculo) uint64 {
+	if data == nil {
+		return 0
+	}
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return 0
+	}
+	h := fnv.New64a()
+	h.Write(encoded)
+	return h.Sum64()
+}
+
+// oraculoFetch busca de um jogo sem hub (single-flight)
+// Depois de concluida segue valendo por oraculoHubInterval (REST de jogos nao assistidos)
+type oraculoFetch struct {
+	done chan struct{}
+	data *models.Oraculo
+	err  error
+	at   time.Time // conclusao (zero = em andamento)
+}
+
+// fetchOraculoOnce busca o jogo uma unica vez para chamadas concorrentes
+// e reaproveita o resultado por oraculoHubInterval (mesma cadencia dos hubs)
+func (b *Broadcaster) fetchOraculoOnce(idWilliamhill string) (*models.Oraculo, error) {
+	now := time.Now()
+
+	b.oraculoHubsMu.Lock()
+	// Remove buscas expiradas a cada intervalo para o mapa nao crescer com jogos avulsos
+	if now.Sub(b.oraculoFetchesSweep) > oraculoHubInterval {
+		for id, f := range b.oraculoFetches {
+			if !f.at.IsZero() && now.Sub(f.at) >= oraculoHubInterval {
+				delete(b.oraculoFetches, id)
+			}
+		}
+		b.oraculoFetchesSweep = now
+	}
+	if fetch, exists := b.oraculoFetches[idWilliamhill]; exists && (fetch.at.IsZero() || now.Sub(fetch.at) < oraculoHubInterval) {
+		b.oraculoHubsMu.Unlock()
+		<-fetch.done
+		return fetch.data, fetch.err
+	}
+	fetch := &oraculoFetch{done: make(chan struct{})}
+	b.oraculoFetches[idWilliamhill] = fetch
+	b.oraculoHubsMu.Unlock()
+
+	data, err := b.fetchOraculo(idWilliamhill)
+
+	b.oraculoHubsMu.Lock()
+	fetch.data, fetch.err, fetch.at = data, err, time.Now()
+	// Erro nao fica em cache: a proxima requisicao tenta de novo (o breaker protege o Redis)
+	if err != nil && b.oraculoFetches[idWilliamhill] == fetch {
+		delete(b.oraculoFetches, idWilliamhill)
+	}
+	b.oraculoHubsMu.Unlock()
+	close(fetch.done)
+
+	return data, err
+}
+
+// fetchOraculo busca o oraculo no Redis (ou na gravacao, em replay) e mergeia dados do evento (status, acrescimos)
+// No warm start, sem o jogo no Redis, usa o oraculo salvo no disco
+func (b *Broadcaster) fetchOraculo(idWilliamhill string) (*models.Oraculo, error) {
+	data, err := b.oraculos.Oraculo(idWilliamhill)
+	if err != nil || data == nil {
+		if salvo := b.oraculoDoDisco(idWilliamhill); salvo != nil {
+			return salvo, nil
+		}
+		return nil, err
+	}
+	b.mergeEventoNoOraculo(data, idWilliamhill)
+	return data, nil
+}
+
+// camposDesconhecidos contagem de chaves do oraculo no Redis sem campo no Evento e nao revisadas
+// camposTiposInvalidos contagem de chaves com tipo incompativel com o campo do Evento
+var (
+	camposDesconhecidos  = newContagemCampos()
+	camposTiposInvalidos = newContagemCampos()
+)
+
+// contagemCampos contador por chave do oraculo
+type contagemCampos struct {
+	sync.Mutex
+	m map[string]int64
+}
+
+func newContagemCampos() *contagemCampos {
+	return &contagemCampos{m: make(map[string]int64)}
+}
+
+// registrar conta as chaves e retorna as vistas pela primeira vez (para log)
+func (c *contagemCampos) registrar(chaves []string) []string {
+	c.Lock()
+	defer c.Unlock()
+	var novas []string
+	for _, chave := range chaves {
+		if c.m[chave] == 0 {
+			novas = append(novas, chave)
+		}
+		c.m[chave]++
+	}
+	return novas
+}
+
+// copia retorna a contagem por chave
+func (c *contagemCampos) copia() map[string]int64 {
+	c.Lock()
+	defer c.Unlock()
+	copia := make(map[string]int64, len(c.m))
+	for chave, n := range c.m {
+		copia[chave] = n
+	}
+	return copia
+}
+
+// registrarAvisosDecode conta campos desconhecidos e com tipo invalido (log na primeira ocorrencia)
+func registrarAvisosDecode(avisos models.AvisosDecode) {
+	for _, chave := range camposDesconhecidos.registrar(avisos.Desconhecidos) {
+		log.Printf("Oraculo: campo desconhecido no Redis: %q (repassado apenas para assinantes ate ser revisado em models.oraculoExtras)", chave)
+	}
+	for _, chave := range camposTiposInvalidos.registrar(avisos.TiposInvalidos) {
+		log.Printf("Oraculo: campo %q com tipo inesperado no Redis (repassado como veio)", chave)
+	}
+}
+
+// OraculoCamposDesconhecidos retorna a contagem de campos desconhecidos por chave
+func OraculoCamposDesconhecidos() map[string]int64 {
+	return camposDesconhecidos.copia()
+}
+
+// OraculoTiposInvalidos retorna a contagem de campos com tipo inesperado por chave
+func OraculoTiposInvalidos() map[string]int64 {
+	return camposTiposInvalidos.copia()
+}
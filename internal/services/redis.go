@@ -5,10 +5,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"sync"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 	"radarfutebol-sse/internal/config"
+	"radarfutebol-sse/internal/models"
 )
 
 var rdb *redis.Client
@@ -19,11 +21,11 @@ func InitRedis(cfg config.RedisConfig) error {
 	rdb = redis.NewClient(&redis.Options{
 		Addr:     fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
 		Password: cfg.Password,
-		DB:       0, // Database 0 para cache do oraculo
+		DB:       cfg.DB, // Cache principal (eventos e oraculo)
 
 		// Pool de conexoes otimizado para alta concorrencia
-		PoolSize:     100,             // Maximo de conexoes no pool
-		MinIdleConns: 10,              // Conexoes minimas mantidas abertas
+		PoolSize:     cfg.PoolSize,     // Maximo de conexoes no pool
+		MinIdleConns: cfg.MinIdleConns, // Conexoes minimas mantidas abertas
 		PoolTimeout:  10 * time.Second, // Timeout para obter conexao do pool
 
 		// Timeouts de conexao
@@ -37,12 +39,14 @@ func InitRedis(cfg config.RedisConfig) error {
 		MaxRetryBackoff: 512 * time.Millisecond,
 	})
 
+	eventosJsonKey = cfg.EventosKey
+
 	_, err := rdb.Ping(ctx).Result()
 	if err != nil {
 		return fmt.Errorf("erro ao conectar Redis: %w", err)
 	}
 
-	log.Println("Redis conectado com sucesso (pool: 100 conexoes)")
+	log.Printf("Redis conectado com sucesso (DB %d, pool: %d conexoes)", cfg.DB, cfg.PoolSize)
 	return nil
 }
 
@@ -52,13 +56,13 @@ func GetRedis() *redis.Client {
 }
 
 // GetOraculoCache busca dados do oraculo do cache Redis
-func GetOraculoCache(idWilliamhill string) (map[string]interface{}, error) {
+func GetOraculoCache(idWilliamhill string) (*models.Oraculo, error) {
 	if idWilliamhill == "" {
 		return nil, nil
 	}
 
 	key := fmt.Sprintf("oraculo-cache:idJogo-%s", idWilliamhill)
-	data, err := rdb.Get(ctx, key).Result()
+	data, err := cacheGet(key)
 	if err == redis.Nil {
 		return nil, nil // Chave nao existe
 	}
@@ -66,10 +70,11 @@ func GetOraculoCache(idWilliamhill string) (map[string]interface{}, error) {
 		return nil, fmt.Errorf("erro ao buscar cache: %w", err)
 	}
 
-	var result map[string]interface{}
-	if err := json.Unmarshal([]byte(data), &result); err != nil {
+	result, avisos, err := models.DecodeOraculo([]byte(data))
+	if err != nil {
 		return nil, fmt.Errorf("erro ao decodificar cache: %w", err)
 	}
+	registrarAvisosDecode(avisos)
 
 	return result, nil
 }
@@ -78,15 +83,15 @@ func GetOraculoCache(idWilliamhill string) (map[string]interface{}, error) {
 var rdbPrefs *redis.Client
 
 // InitRedisPreferencias inicializa conexao Redis para preferencias com pool otimizado
-func InitRedisPreferencias(host string, port int, password string) error {
+func InitRedisPreferencias(cfg config.RedisConfig) error {
 	rdbPrefs = redis.NewClient(&redis.Options{
-		Addr:     fmt.Sprintf("%s:%d", host, port),
-		Password: password,
-		DB:       2, // Database 2 para preferencias
+		Addr:     fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
+		Password: cfg.Password,
+		DB:       cfg.PrefsDB, // Database de preferencias
 
 		// Pool menor pois preferencias sao menos acessadas
-		PoolSize:     50,
-		MinIdleConns: 5,
+		PoolSize:     cfg.PrefsPoolSize,
+		MinIdleConns: cfg.PrefsMinIdleConns,
 		PoolTimeout:  10 * time.Second,
 
 		// Timeouts
@@ -105,11 +110,23 @@ func InitRedisPreferencias(host string, port int, password string) error {
 		return fmt.Errorf("erro ao conectar Redis preferencias: %w", err)
 	}
 
-	log.Println("Redis preferencias (DB 2) conectado com sucesso (pool: 50 conexoes)")
+	log.Printf("Redis preferencias (DB %d) conectado com sucesso (pool: %d conexoes)", cfg.PrefsDB, cfg.PrefsPoolSize)
 	return nil
 }
 
+// prefsFallbackTTL validade da ultima leitura boa das preferencias (usada com o Redis fora)
+const prefsFallbackTTL = 30 * time.Minute
+
+// prefsFallback ultima leitura bem sucedida das preferencias de cada usuario
+var prefsFallback = struct {
+	sync.Mutex
+	m          map[int]*PreferenciasUsuario
+	at         map[int]time.Time
+	ultimaLimp time.Time
+}{m: make(map[int]*PreferenciasUsuario), at: make(map[int]time.Time)}
+
 // GetPreferenciasUsuarioCompletas busca campeonatos e jogos favoritos
+// Com o Redis de preferencias fora (erro ou circuito aberto) usa a ultima leitura boa do usuario
 func GetPreferenciasUsuarioCompletas(userID int) (*PreferenciasUsuario, error) {
 	if rdbPrefs == nil {
 		return nil, fmt.Errorf("redis preferencias nao inicializado")
@@ -123,7 +140,10 @@ func GetPreferenciasUsuarioCompletas(userID int) (*PreferenciasUsuario, error) {
 	// Busca campeonatos favoritos
 	// Chave: preferencias:campeonatos-favoritos-{userId}
 	keyCamp := fmt.Sprintf("preferencias:campeonatos-favoritos-%d", userID)
-	dataCamp, err := rdbPrefs.Get(ctx, keyCamp).Result()
+	dataCamp, err := prefsGet(keyCamp)
+	if err != nil && err != redis.Nil {
+		return prefsDoFallback(userID, err)
+	}
 	if err == nil && dataCamp != "" {
 		var campFavs map[string]bool
 		if err := json.Unmarshal([]byte(dataCamp), &campFavs); err == nil {
@@ -134,7 +154,10 @@ func GetPreferenciasUsuarioCompletas(userID int) (*PreferenciasUsuario, error) {
 	// Busca jogos favoritos
 	// Chave: preferencias:jogos-favoritos-{userId}
 	keyJogos := fmt.Sprintf("preferencias:jogos-favoritos-%d", userID)
-	dataJogos, err := rdbPrefs.Get(ctx, keyJogos).Result()
+	dataJogos, err := prefsGet(keyJogos)
+	if err != nil && err != redis.Nil {
+		return prefsDoFallback(userID, err)
+	}
 	if err == nil && dataJogos != "" {
 		var jogosFavs map[string]bool
 		if err := json.Unmarshal([]byte(dataJogos), &jogosFavs); err == nil {
@@ -142,9 +165,43 @@ func GetPreferenciasUsuarioCompletas(userID int) (*PreferenciasUsuario, error) {
 		}
 	}
 
+	guardarPrefsFallback(userID, prefs)
 	return prefs, nil
 }
 
+// guardarPrefsFallback guarda a leitura boa e descarta as expiradas (no maximo uma vez por minuto)
+func guardarPrefsFallback(userID int, prefs *PreferenciasUsuario) {
+	now := time.Now()
+	prefsFallback.Lock()
+	defer prefsFallback.Unlock()
+
+	prefsFallback.m[userID] = prefs
+	prefsFallback.at[userID] = now
+
+	if now.Sub(prefsFallback.ultimaLimp) < time.Minute {
+		return
+	}
+	prefsFallback.ultimaLimp = now
+	for id, at := range prefsFallback.at {
+		if now.Sub(at) > prefsFallbackTTL {
+			delete(prefsFallback.m, id)
+			delete(prefsFallback.at, id)
+		}
+	}
+}
+
+// prefsDoFallback ultima leitura boa do usuario; sem ela retorna o erro (sem favoritos)
+func prefsDoFallback(userID int, err error) (*PreferenciasUsuario, error) {
+	prefsFallback.Lock()
+	prefs, at := prefsFallback.m[userID], prefsFallback.at[userID]
+	prefsFallback.Unlock()
+
+	if prefs != nil && time.Since(at) < prefsFallbackTTL {
+		return prefs, nil
+	}
+	return nil, fmt.Errorf("erro ao buscar preferencias: %w", err)
+}
+
 // GetPreferenciasUsuario busca preferencias do usuario do Redis (database 2)
 func GetPreferenciasUsuario(userID int) (map[string]interface{}, error) {
 	if rdbPrefs == nil {
@@ -152,7 +209,7 @@ func GetPreferenciasUsuario(userID int) (map[string]interface{}, error) {
 	}
 
 	key := fmt.Sprintf("preferencias-usuario:%d", userID)
-	data, err := rdbPrefs.Get(ctx, key).Result()
+	data, err := prefsGet(key)
 	if err == redis.Nil {
 		return nil, nil
 	}
@@ -170,7 +227,7 @@ func GetPreferenciasUsuario(userID int) (map[string]interface{}, error) {
 
 // GetString busca uma string do Redis
 func GetString(key string) (string, error) {
-	data, err := rdb.Get(ctx, key).Result()
+	data, err := cacheGet(key)
 	if err == redis.Nil {
 		return "", nil
 	}
@@ -182,5 +239,7 @@ func GetString(key string) (string, error) {
 
 // SetString salva uma string no Redis
 func SetString(key string, value string) error {
-	return rdb.Set(ctx, key, value, 0).Err()
+	return redisBreaker.Do(func() error {
+		return rdb.Set(ctx, key, value, 0).Err()
+	})
 }
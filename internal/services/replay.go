@@ -0,0 +1,41 @@
+package services
+
+import (
+	"fmt"
+
+	"radarfutebol-sse/internal/models"
+	"radarfutebol-sse/internal/replay"
+)
+
+// replayPlayer gravacao do cmd/recorder em reproducao (fonte replay)
+// Com replay configurado os oraculos tambem vem da gravacao; nil = Redis
+var replayPlayer *replay.Player
+
+// fonteReplay eventos da gravacao no instante atual da reproducao
+type fonteReplay struct {
+	player *replay.Player
+}
+
+func (fonteReplay) Nome() string { return "replay" }
+
+func (f fonteReplay) Buscar() (string, error) {
+	return f.player.Eventos(), nil
+}
+
+// buscarOraculo oraculo do jogo no Redis ou, em replay, na gravacao
+func buscarOraculo(idWilliamhill string) (*models.Oraculo, error) {
+	if replayPlayer == nil {
+		return GetOraculoCache(idWilliamhill)
+	}
+
+	data := replayPlayer.Oraculo(idWilliamhill)
+	if idWilliamhill == "" || data == "" {
+		return nil, nil
+	}
+	result, avisos, err := models.DecodeOraculo([]byte(data))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao decodificar oraculo da gravacao: %w", err)
+	}
+	registrarAvisosDecode(avisos)
+	return result, nil
+}
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// authRevokeChannel canal Redis (pub/sub) onde o Laravel publica mudancas de usuario
+// Payload: {"idUsuario": 123, "revogar": true}
+//   - revogar=true: cancelamento/banimento, streams do usuario sao encerrados
+//   - revogar=false: mudanca de plano, streams reavaliam o tier no lugar
+const authRevokeChannel = "sse-auth-revoke"
+
+// AuthChange mensagem de mudanca de autenticacao de um usuario
+type AuthChange struct {
+	IdUsuario int  `json:"idUsuario"`
+	Revogar   bool `json:"revogar"`
+}
+
+// ListenAuthChanges escuta o canal de revogacao ate ctx ser cancelado
+// Para cada mensagem chama onChange, que invalida o cache do usuario no autenticador em uso
+// onChange roda no loop do pub/sub: nao pode bloquear (consultas ao MySQL fora do loop)
+func ListenAuthChanges(ctx context.Context, onChange func(AuthChange)) {
+	if rdb == nil {
+		return
+	}
+
+	pubsub := rdb.Subscribe(ctx, authRevokeChannel)
+	defer pubsub.Close()
+
+	log.Printf("Auth: Escutando revogacoes no canal %s", authRevokeChannel)
+
+	ch := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-ch:
+			if !ok {
+				return
+			}
+
+			var change AuthChange
+			if err := json.Unmarshal([]byte(msg.Payload), &change); err != nil || change.IdUsuario <= 0 {
+				log.Printf("Auth: Mensagem de revogacao invalida: %s", msg.Payload)
+				continue
+			}
+
+			onChange(change)
+		}
+	}
+}
+
+// jwtRevocationTTL tempo que a revogacao/mudanca de plano vale para JWTs (auth.jwt_revocation_ttl)
+var jwtRevocationTTL = time.Hour
+
+// RevogacaoJWT ultima revogacao/mudanca de plano do usuario
+// JWTs sao validados sem MySQL nem cache: a mudanca vale para tokens emitidos antes dela (iat < Em)
+type RevogacaoJWT struct {
+	Em          int64 `json:"em"`                 // unix (segundos) da mudanca
+	Revogado    bool  `json:"revogado,omitempty"` // cancelamento/banimento: tokens anteriores recusados
+	IsAssinante bool  `json:"isAssinante"`        // mudanca de plano: tier atual do usuario
+	TeamId      int   `json:"teamId"`
+}
+
+// getJWTRevocationKey chave da revogacao do usuario no Redis
+func getJWTRevocationKey(idUsuario int) string {
+	return fmt.Sprintf("sse-auth-jwt-revogacao:%d", idUsuario)
+}
+
+// getRevogacaoFromRedis busca a revogacao do usuario (false = nenhuma ou Redis fora)
+func getRevogacaoFromRedis(idUsuario int) (RevogacaoJWT, bool) {
+	var rev RevogacaoJWT
+	if rdb == nil {
+		return rev, false
+	}
+
+	data, err := cacheGet(getJWTRevocationKey(idUsuario))
+	if err != nil {
+		if err != redis.Nil && !errors.Is(err, ErrCircuitOpen) {
+			log.Printf("Auth: Erro ao buscar revogacao do usuario %d: %v", idUsuario, err)
+		}
+		return rev, false
+	}
+	if err := json.Unmarshal([]byte(data), &rev); err != nil {
+		return rev, false
+	}
+	return rev, true
+}
+
+// saveRevogacaoToRedis grava a revogacao do usuario (compartilhada entre as instancias)
+func saveRevogacaoToRedis(idUsuario int, rev RevogacaoJWT, ttl time.Duration) {
+	if rdb == nil {
+		return
+	}
+
+	data, err := json.Marshal(rev)
+	if err != nil {
+		return
+	}
+	err = redisBreaker.Do(func() error {
+		return rdb.Set(ctx, getJWTRevocationKey(idUsuario), data, ttl).Err()
+	})
+	if err != nil && !errors.Is(err, ErrCircuitOpen) {
+		log.Printf("Auth: Erro ao salvar revogacao do usuario %d: %v", idUsuario, err)
+	}
+}
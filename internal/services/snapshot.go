@@ -0,0 +1,105 @@
+package services
+
+import (
+	"time"
+
+	"radarfutebol-sse/internal/models"
+)
+
+// eventosSnapshot estado imutavel de uma leitura do Redis: eventos, fragmentos e indices
+// Publicado inteiro por troca atomica; nada aqui e modificado depois de publicado
+type eventosSnapshot struct {
+	eventos    []*models.Evento
+	fragmentos fragmentosSnapshot // nil = pre-serializacao indisponivel
+
+	// Geracao do snapshot: incrementa apenas quando o JSON do Redis muda
+	generation uint64
+	hash       uint64
+	createdAt  time.Time // ultima mudanca do JSON na fonte (referencia de frescor)
+	fonte      string    // fonte de eventos que gerou o snapshot (vazio = disco ou nenhuma)
+	aoVivo     int       // eventos inprogress: sem jogo ao vivo o JSON pode ficar parado sem problema
+
+	// Indices construidos uma vez por snapshot
+	porId            map[int]*models.Evento
+	porIdWilliamhill map[string]*models.Evento
+	porIdBetfair     map[string]*models.Evento
+	porCampeonato    map[string][]*models.Evento // idCampeonatoUnico
+	porTime          map[int][]*models.Evento    // idTimeCasa e idTimeFora
+}
+
+// snapshotVazio snapshot antes da primeira carga (generation 0)
+var snapshotVazio = &eventosSnapshot{}
+
+// novoSnapshot monta o snapshot e os indices sobre os eventos decodificados
+func novoSnapshot(eventos []*models.Evento, fragmentos fragmentosSnapshot, generation, hash uint64) *eventosSnapshot {
+	s := &eventosSnapshot{
+		eventos:          eventos,
+		fragmentos:       fragmentos,
+		generation:       generation,
+		hash:             hash,
+		createdAt:        time.Now(),
+		porId:            make(map[int]*models.Evento, len(eventos)),
+		porIdWilliamhill: make(map[string]*models.Evento, len(eventos)),
+		porIdBetfair:     make(map[string]*models.Evento, len(eventos)),
+		porCampeonato:    make(map[string][]*models.Evento),
+		porTime:          make(map[int][]*models.Evento),
+	}
+
+	for _, e := range eventos {
+		if e.Status == "inprogress" {
+			s.aoVivo++
+		}
+		// Em caso de id repetido no Redis vale o primeiro (mesmo resultado da busca linear)
+		if _, exists := s.porId[e.IdEvento]; !exists {
+			s.porId[e.IdEvento] = e
+		}
+		if e.IdWilliamhill != "" {
+			if _, exists := s.porIdWilliamhill[e.IdWilliamhill]; !exists {
+				s.porIdWilliamhill[e.IdWilliamhill] = e
+			}
+		}
+		if e.IdBetfair != "" {
+			if _, exists := s.porIdBetfair[e.IdBetfair]; !exists {
+				s.porIdBetfair[e.IdBetfair] = e
+			}
+		}
+		if e.IdCampeonatoUnico != "" {
+			s.porCampeonato[e.IdCampeonatoUnico] = append(s.porCampeonato[e.IdCampeonatoUnico], e)
+		}
+		if e.IdTimeCasa > 0 {
+			s.porTime[e.IdTimeCasa] = append(s.porTime[e.IdTimeCasa], e)
+		}
+		if e.IdTimeFora > 0 && e.IdTimeFora != e.IdTimeCasa {
+			s.porTime[e.IdTimeFora] = append(s.porTime[e.IdTimeFora], e)
+		}
+	}
+
+	return s
+}
+
+// FindEventoById busca evento ativo pelo idEvento (nil se nao estiver no cache)
+func (b *Broadcaster) FindEventoById(idEvento int) *models.Evento {
+	return b.snapshotAtual().porId[idEvento]
+}
+
+// FindEventoByIdWilliamhill busca evento ativo pelo idWilliamhill (nil se nao estiver no cache)
+func (b *Broadcaster) FindEventoByIdWilliamhill(idWilliamhill string) *models.Evento {
+	return b.snapshotAtual().porIdWilliamhill[idWilliamhill]
+}
+
+// FindEventoByIdBetfair busca evento ativo pelo idBetfair (nil se nao estiver no cache)
+func (b *Broadcaster) FindEventoByIdBetfair(idBetfair string) *models.Evento {
+	return b.snapshotAtual().porIdBetfair[idBetfair]
+}
+
+// EventosDoCampeonato retorna os eventos ativos do campeonato (idCampeonatoUnico)
+// O slice e compartilhado entre as chamadas: nao modificar
+func (b *Broadcaster) EventosDoCampeonato(idCampeonatoUnico string) []*models.Evento {
+	return b.snapshotAtual().porCampeonato[idCampeonatoUnico]
+}
+
+// EventosDoTime retorna os eventos ativos em que o time joga (casa ou fora)
+// O slice e compartilhado entre as chamadas: nao modificar
+func (b *Broadcaster) EventosDoTime(idTime int) []*models.Evento {
+	return b.snapshotAtual().porTime[idTime]
+}
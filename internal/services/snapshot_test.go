@@ -0,0 +1,104 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"radarfutebol-sse/internal/models"
+)
+
+func TestNovoSnapshot_Indices(t *testing.T) {
+	e1 := criarEvento(1, "Flamengo", "Vasco", "inprogress")
+	e1.IdWilliamhill, e1.IdTimeCasa, e1.IdTimeFora = "wh-1", 10, 20
+	e2 := criarEvento(2, "Vasco", "Santos", "notstarted")
+	e2.IdWilliamhill, e2.IdBetfair, e2.IdTimeCasa, e2.IdTimeFora = "wh-2", "bf-2", 20, 30
+	e2.IdCampeonatoUnico = "br-serie-b"
+
+	s := novoSnapshot([]*models.Evento{e1, e2}, nil, 1, 0)
+
+	if s.porId[2] != e2 || s.porIdWilliamhill["wh-1"] != e1 || s.porIdBetfair["bf-2"] != e2 {
+		t.Error("Indices por id nao apontam para os eventos do snapshot")
+	}
+	if len(s.porCampeonato["br-serie-a"]) != 1 || len(s.porCampeonato["br-serie-b"]) != 1 {
+		t.Errorf("Indice por campeonato inesperado: %v", s.porCampeonato)
+	}
+	if vasco := s.porTime[20]; len(vasco) != 2 || vasco[0] != e1 || vasco[1] != e2 {
+		t.Errorf("Time 20 joga nos dois eventos: %v", vasco)
+	}
+	if s.porId[3] != nil {
+		t.Error("Evento inexistente deveria ser nil")
+	}
+}
+
+func TestSnapshotStatus_Idade(t *testing.T) {
+	b := &Broadcaster{}
+	b.snapshot.Store(snapshotVazio)
+	if st := b.SnapshotStatus(time.Second); st.Status != "empty" {
+		t.Errorf("Sem eventos carregados esperava empty, obteve %s", st.Status)
+	}
+
+	b.snapshot.Store(novoSnapshot([]*models.Evento{criarEvento(1, "Flamengo", "Vasco", "inprogress")}, nil, 1, 0))
+	b.eventosCacheAt = time.Now()
+	if st := b.SnapshotStatus(time.Second); st.Status != "ok" || st.Eventos != 1 {
+		t.Errorf("Esperava ok com 1 evento, obteve %+v", st)
+	}
+
+	// Leituras falhando: snapshot antigo continua servido mas fica stale com o ultimo erro
+	b.eventosCacheAt = time.Now().Add(-time.Minute)
+	b.registrarErroRefresh(errors.New("redis fora"))
+	st := b.SnapshotStatus(time.Second)
+	if st.Status != "stale" || st.LastError != "redis fora" {
+		t.Errorf("Esperava stale com ultimo erro, obteve %+v", st)
+	}
+}
+
+func TestAvaliarFrescor_Transicoes(t *testing.T) {
+	b := &Broadcaster{dadosStatus: DadosStatus{Status: "ok"}, dadosStatusChan: make(chan struct{})}
+	b.SetLimitesFrescor(10*time.Second, time.Minute)
+
+	agora := time.Now()
+	snap := novoSnapshot([]*models.Evento{criarEvento(1, "Flamengo", "Vasco", "inprogress")}, nil, 1, 0)
+	b.snapshot.Store(snap)
+	b.eventosCacheAt = agora
+
+	_, changed := b.DadosStatus()
+
+	// JSON parado ha 2 minutos com jogo ao vivo: stale
+	snap.createdAt = agora.Add(-2 * time.Minute)
+	b.avaliarFrescor(agora)
+	select {
+	case <-changed:
+	default:
+		t.Fatal("Mudanca de estado deveria fechar o canal")
+	}
+	st, changed := b.DadosStatus()
+	if st.Status != "stale" || st.Reason != "source_not_updating" {
+		t.Errorf("Esperava stale, obteve %+v", st)
+	}
+
+	// Redis sem resposta tem prioridade sobre stale
+	b.eventosCacheAt = agora.Add(-30 * time.Second)
+	b.avaliarFrescor(agora)
+	if st, _ := b.DadosStatus(); st.Status != "degraded" {
+		t.Errorf("Esperava degraded, obteve %+v", st)
+	}
+
+	// JSON voltou a mudar
+	b.snapshot.Store(novoSnapshot(snap.eventos, nil, 2, 1))
+	b.eventosCacheAt = agora
+	b.avaliarFrescor(agora)
+	if st, _ := b.DadosStatus(); st.Status != "ok" {
+		t.Errorf("Esperava ok, obteve %+v", st)
+	}
+	<-changed
+
+	// Sem jogo ao vivo o JSON parado nao e stale
+	parado := novoSnapshot([]*models.Evento{criarEvento(2, "Santos", "Vasco", "notstarted")}, nil, 3, 2)
+	parado.createdAt = agora.Add(-time.Hour)
+	b.snapshot.Store(parado)
+	b.avaliarFrescor(agora)
+	if st, _ := b.DadosStatus(); st.Status != "ok" {
+		t.Errorf("Sem jogos ao vivo esperava ok, obteve %+v", st)
+	}
+}
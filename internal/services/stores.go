@@ -0,0 +1,146 @@
+package services
+
+import (
+	"time"
+
+	"radarfutebol-sse/internal/models"
+)
+
+// PrefsStore favoritos e alertas de gol do usuario (producao: Redis de preferencias)
+type PrefsStore interface {
+	// Preferencias campeonatos e jogos favoritos do usuario
+	Preferencias(userID int) (*PreferenciasUsuario, error)
+
+	// AlertasGol placar ja notificado por evento (dedup do som de gol)
+	AlertasGol(userID int) map[string]string
+	SalvarAlertasGol(userID int, alertas map[string]string)
+}
+
+// AuthStore cache de tokens e consulta do dono do token (producao: Redis + MySQL)
+type AuthStore interface {
+	// LerCache resultado guardado para o token (false = sem cache)
+	LerCache(token string) (AuthResult, bool)
+	GravarCache(token string, result AuthResult, ttl time.Duration)
+	InvalidarCache(idUsuario int)
+
+	// ConsultarToken busca o usuario do token (IsValid false = token inexistente, Unavailable = banco fora)
+	ConsultarToken(token string) AuthResult
+
+	// ConsultarUsuario tier atual do usuario pelo id (mudanca de plano de quem usa JWT)
+	ConsultarUsuario(idUsuario int) AuthResult
+
+	// Revogacao/mudanca de plano do usuario aplicada aos JWTs emitidos antes dela (false = nenhuma)
+	LerRevogacao(idUsuario int) (RevogacaoJWT, bool)
+	GravarRevogacao(idUsuario int, rev RevogacaoJWT, ttl time.Duration)
+}
+
+// OraculoStore oraculo do jogo e status do evento fora do snapshot (producao: Redis + MySQL)
+type OraculoStore interface {
+	// Oraculo dados do jogo (nil = jogo fora do cache); o retorno e modificado pelo merge
+	Oraculo(idWilliamhill string) (*models.Oraculo, error)
+
+	// EventoInfo status e acrescimos do evento (nil = evento nao encontrado)
+	EventoInfo(idWilliamhill string) (*EventoInfo, error)
+}
+
+// APIKeyStore chaves dos parceiros B2B (producao: Redis com cache local)
+type APIKeyStore interface {
+	// APIKey chave pelo valor enviado pelo parceiro (nil = chave inexistente)
+	APIKey(rawKey string) (*APIKey, error)
+}
+
+// Stores dependencias do Broadcaster; campos vazios usam as fontes configuradas e o Redis/MySQL
+type Stores struct {
+	Eventos  []FonteEventos
+	Prefs    PrefsStore
+	Oraculos OraculoStore
+}
+
+// prefsRedis preferencias e alertas no Redis de preferencias (database 2)
+type prefsRedis struct{}
+
+func (prefsRedis) Preferencias(userID int) (*PreferenciasUsuario, error) {
+	return GetPreferenciasUsuarioCompletas(userID)
+}
+
+func (prefsRedis) AlertasGol(userID int) map[string]string {
+	return getAlertasGolUsuario(userID)
+}
+
+func (prefsRedis) SalvarAlertasGol(userID int, alertas map[string]string) {
+	setAlertasGolUsuario(userID, alertas)
+}
+
+// authRedisMySQL cache no Redis (chave HMAC do token) e usuarios no MySQL
+type authRedisMySQL struct{}
+
+func (authRedisMySQL) LerCache(token string) (AuthResult, bool) {
+	cached := getAuthFromRedis(getCacheKey(token))
+	if cached == nil {
+		return AuthResult{}, false
+	}
+	if cached.Invalid {
+		return AuthResult{IsValid: false}, true
+	}
+	return AuthResult{
+		IdUsuario:   cached.IdUsuario,
+		IsValid:     true,
+		IsAssinante: cached.IsAssinante,
+		TeamId:      cached.TeamId,
+	}, true
+}
+
+func (authRedisMySQL) GravarCache(token string, result AuthResult, ttl time.Duration) {
+	entry := &authCacheEntry{Invalid: true}
+	if result.IsValid {
+		entry = &authCacheEntry{
+			IdUsuario:   result.IdUsuario,
+			IsAssinante: result.IsAssinante,
+			TeamId:      result.TeamId,
+		}
+	}
+	saveAuthToRedis(getCacheKey(token), entry, ttl)
+}
+
+func (authRedisMySQL) InvalidarCache(idUsuario int) {
+	InvalidateAuthCache(idUsuario)
+}
+
+func (authRedisMySQL) ConsultarToken(token string) AuthResult {
+	return queryToken(token)
+}
+
+func (authRedisMySQL) ConsultarUsuario(idUsuario int) AuthResult {
+	return queryUsuario(idUsuario)
+}
+
+func (authRedisMySQL) LerRevogacao(idUsuario int) (RevogacaoJWT, bool) {
+	return getRevogacaoFromRedis(idUsuario)
+}
+
+func (authRedisMySQL) GravarRevogacao(idUsuario int, rev RevogacaoJWT, ttl time.Duration) {
+	saveRevogacaoToRedis(idUsuario, rev, ttl)
+}
+
+// apiKeysRedis chaves em sse-api-key:{sha256} no Redis (cache local de 60s)
+type apiKeysRedis struct{}
+
+func (apiKeysRedis) APIKey(rawKey string) (*APIKey, error) {
+	return GetAPIKey(rawKey)
+}
+
+// GetAPIKeyStore retorna o store padrao de API keys (Redis)
+func GetAPIKeyStore() APIKeyStore {
+	return apiKeysRedis{}
+}
+
+// oraculoRedisMySQL oraculo no Redis (ou na gravacao, em replay) e status do evento no MySQL
+type oraculoRedisMySQL struct{}
+
+func (oraculoRedisMySQL) Oraculo(idWilliamhill string) (*models.Oraculo, error) {
+	return buscarOraculo(idWilliamhill)
+}
+
+func (oraculoRedisMySQL) EventoInfo(idWilliamhill string) (*EventoInfo, error) {
+	return getEventoInfoFromDB(idWilliamhill)
+}
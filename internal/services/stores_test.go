@@ -0,0 +1,132 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"radarfutebol-sse/internal/models"
+)
+
+func TestAutenticador_CacheENegativo(t *testing.T) {
+	store := NewAuthMemoria()
+	store.AddToken("tok-assinante", 10, 4)
+	auth := NewAutenticador(store)
+
+	for i := 0; i < 3; i++ {
+		result := auth.ValidateToken("tok-assinante")
+		if !result.IsValid || result.IdUsuario != 10 || !result.IsAssinante {
+			t.Fatalf("tentativa %d: %+v", i, result)
+		}
+	}
+	if n := store.Consultas(); n != 1 {
+		t.Fatalf("consultas = %d, esperava 1 (demais do cache)", n)
+	}
+
+	// Token inexistente: cache negativo, sem nova consulta
+	for i := 0; i < 3; i++ {
+		if result := auth.ValidateToken("tok-errado"); result.IsValid || result.Unavailable {
+			t.Fatalf("token invalido aceito: %+v", result)
+		}
+	}
+	if n := store.Consultas(); n != 2 {
+		t.Fatalf("consultas = %d, esperava 2", n)
+	}
+
+	// Revalidacao ignora o cache (mudanca de plano)
+	store.AddToken("tok-assinante", 10, 5)
+	if result := auth.RevalidateToken("tok-assinante"); result.IsAssinante {
+		t.Fatalf("revalidacao usou o cache: %+v", result)
+	}
+}
+
+func TestAutenticador_Indisponivel(t *testing.T) {
+	store := NewAuthMemoria()
+	store.SetIndisponivel(true)
+	auth := NewAutenticador(store)
+	defer SetAnonymousFallback(false)
+
+	SetAnonymousFallback(false)
+	if result := auth.ValidateToken("tok"); !result.Unavailable {
+		t.Fatalf("esperava Unavailable, obteve %+v", result)
+	}
+
+	// Indisponivel nao vai para o cache: com o fallback vira anonimo
+	SetAnonymousFallback(true)
+	if result := auth.ValidateToken("tok"); !result.IsValid || result.IdUsuario != 0 || result.Unavailable {
+		t.Fatalf("esperava anonimo, obteve %+v", result)
+	}
+}
+
+func TestBroadcaster_MergeOraculo(t *testing.T) {
+	oraculos := NewOraculoMemoria()
+	b := NewBroadcaster(Stores{
+		Eventos:  []FonteEventos{NewFonteMemoria(`[{"idEvento": 1, "idWilliamhill": "111", "status": "inprogress", "temEscalacao": 1, "descontoHt": 3}]`)},
+		Prefs:    NewPrefsMemoria(),
+		Oraculos: oraculos,
+	})
+	b.refreshEventosCache()
+
+	// Jogo no snapshot: status e acrescimos vem do evento
+	oraculos.SetOraculo("111", &models.Oraculo{Evento: models.Evento{IdWilliamhill: "111", Status: "notstarted"}})
+	data, err := b.GetOraculoCached("111")
+	if err != nil || data == nil {
+		t.Fatalf("oraculo 111: %v, %v", data, err)
+	}
+	if data.Status != "inprogress" || data.TemEscalacao != 1 || data.Acrescimo1Tempo != "3" {
+		t.Fatalf("merge do snapshot: status=%q escalacao=%d acrescimo=%q", data.Status, data.TemEscalacao, data.Acrescimo1Tempo)
+	}
+
+	// Jogo fora do snapshot (finalizado): status do EventoInfo
+	descontoFt := 5
+	oraculos.SetOraculo("222", &models.Oraculo{Evento: models.Evento{IdWilliamhill: "222", Status: "inprogress"}})
+	oraculos.SetEventoInfo("222", &EventoInfo{Status: "closed", DescontoFt: &descontoFt})
+	data, err = b.GetOraculoCached("222")
+	if err != nil || data == nil || data.Status != "closed" || data.Acrescimo2Tempo != "5" {
+		t.Fatalf("merge do EventoInfo: %+v, %v", data, err)
+	}
+
+	// Sem oraculo no store
+	if data, err := b.GetOraculoCached("333"); data != nil || err != nil {
+		t.Fatalf("esperava nil, obteve %+v, %v", data, err)
+	}
+
+	// Jogo sem hub: nova leitura dentro do intervalo reaproveita a busca anterior
+	oraculos.SetOraculo("333", &models.Oraculo{Evento: models.Evento{IdWilliamhill: "333"}})
+	if data, _ := b.GetOraculoCached("333"); data != nil {
+		t.Fatal("leitura dentro do intervalo deveria vir do cache")
+	}
+	b.oraculoFetches["333"].at = time.Now().Add(-oraculoHubInterval)
+	if data, _ := b.GetOraculoCached("333"); data == nil {
+		t.Fatal("apos o intervalo deveria buscar de novo no store")
+	}
+}
+
+func TestBroadcaster_AlertasGolNoStore(t *testing.T) {
+	prefs := NewPrefsMemoria()
+	b := NewBroadcaster(Stores{
+		Eventos:  []FonteEventos{NewFonteMemoria(`[{"idEvento": 1, "idWilliamhill": "111", "status": "inprogress", "golTimeCasaFt": 1, "alertarGolTimeCasa": 1}]`)},
+		Prefs:    prefs,
+		Oraculos: NewOraculoMemoria(),
+	})
+	b.refreshEventosCache()
+
+	filtro := &models.Filtro{IdUsuario: 7, SomLigado: true}
+	gols := func() int {
+		resp, err := filtrarEventosPainel(b.GetEventosCache(), filtro, nil, b.prefs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.Counts.Gols
+	}
+
+	// Mesmo placar conta uma unica vez entre updates
+	if n := gols(); n != 1 {
+		t.Fatalf("primeiro update: gols = %d, esperava 1", n)
+	}
+	if n := gols(); n != 0 {
+		t.Fatalf("segundo update: gols = %d, esperava 0 (ja notificado)", n)
+	}
+	if alertas := prefs.AlertasGol(7); alertas["1"] != "1-0" {
+		t.Fatalf("alertas = %v", alertas)
+	}
+}
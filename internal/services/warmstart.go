@@ -0,0 +1,199 @@
+package services
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"radarfutebol-sse/internal/config"
+	"radarfutebol-sse/internal/models"
+)
+
+// Warm start: o ultimo snapshot bom (eventos e oraculos assistidos) e salvo em disco
+// periodicamente e no shutdown. No startup ele e carregado antes da primeira leitura do
+// Redis e servido como stale (event: status) ate a primeira leitura com dados do Redis;
+// assim um restart junto com o do Redis nao entrega {"eventos":[]} ate o Laravel repopular
+
+// warmStartVersion versao do formato do arquivo
+const warmStartVersion = 1
+
+// warmStartCfg configuracao do warm start (InitWarmStart)
+var warmStartCfg config.WarmStartConfig
+
+// InitWarmStart aplica a configuracao do warm start (antes de iniciar o Broadcaster)
+func InitWarmStart(cfg config.WarmStartConfig) {
+	warmStartCfg = cfg
+}
+
+// warmStartArquivo conteudo do arquivo (JSON com gzip)
+type warmStartArquivo struct {
+	Version   int       `json:"version"`
+	SavedAt   time.Time `json:"savedAt"`
+	ReadAt    time.Time `json:"readAt"`    // ultima leitura bem sucedida do Redis
+	ChangedAt time.Time `json:"changedAt"` // ultima mudanca do JSON no Redis
+
+	Eventos  []*models.Evento           `json:"eventos"`
+	Oraculos map[string]json.RawMessage `json:"oraculos,omitempty"` // por idWilliamhill
+}
+
+// carregarWarmStart publica o snapshot do disco (generation 1, stale ate a primeira leitura do Redis)
+func (b *Broadcaster) carregarWarmStart(path string) error {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	var arquivo warmStartArquivo
+	if err := json.NewDecoder(gz).Decode(&arquivo); err != nil {
+		return err
+	}
+	if arquivo.Version != warmStartVersion {
+		return fmt.Errorf("versao %d nao suportada", arquivo.Version)
+	}
+	if age := time.Since(arquivo.ReadAt); age > warmStartCfg.MaxAge {
+		log.Printf("Warm start: %s ignorado, dados de %v atras (max_age %v)", path, age.Round(time.Second), warmStartCfg.MaxAge)
+		return nil
+	}
+
+	for _, e := range arquivo.Eventos {
+		e.TemAnaliseIA = e.AnaliseIA != ""
+	}
+	oraculos := make(map[string]*models.Oraculo, len(arquivo.Oraculos))
+	for id, data := range arquivo.Oraculos {
+		if oraculo, _, err := models.DecodeOraculo(data); err == nil {
+			oraculos[id] = oraculo
+		}
+	}
+
+	fragmentos, err := construirFragmentos(arquivo.Eventos)
+	if err != nil {
+		fragmentos = nil
+	}
+	snap := novoSnapshot(arquivo.Eventos, fragmentos, 1, 0)
+	snap.createdAt = arquivo.ChangedAt
+
+	b.oraculoHubsMu.Lock()
+	b.oraculosDisco = oraculos
+	b.oraculoHubsMu.Unlock()
+	b.mu.Lock()
+	b.eventosCacheAt = arquivo.ReadAt
+	b.mu.Unlock()
+	b.warm.Store(true)
+	b.snapshot.Store(snap)
+	b.avaliarFrescor(time.Now())
+
+	log.Printf("Warm start: %d eventos e %d oraculos carregados de %s (lidos do Redis ha %v)",
+		len(arquivo.Eventos), len(oraculos), path, time.Since(arquivo.ReadAt).Round(time.Second))
+	return nil
+}
+
+// encerrarWarmStart primeira leitura do Redis com dados: descarta o que veio do disco
+func (b *Broadcaster) encerrarWarmStart() {
+	if !b.warm.Swap(false) {
+		return
+	}
+	b.oraculoHubsMu.Lock()
+	b.oraculosDisco = nil
+	b.oraculoHubsMu.Unlock()
+	log.Println("Warm start: dados do Redis recebidos, snapshot do disco descartado")
+}
+
+// oraculoDoDisco oraculo salvo no disco enquanto o Redis nao tem o jogo (apenas no warm start)
+// Ja contem o merge do evento: compartilhado, nao modificar
+func (b *Broadcaster) oraculoDoDisco(idWilliamhill string) *models.Oraculo {
+	if !b.warm.Load() {
+		return nil
+	}
+	b.oraculoHubsMu.Lock()
+	defer b.oraculoHubsMu.Unlock()
+	return b.oraculosDisco[idWilliamhill]
+}
+
+// persistirSnapshot salva o snapshot atual e os oraculos assistidos (escrita atomica por rename)
+// Nao salva snapshot vazio nem durante o warm start (o arquivo ja tem esses dados)
+func (b *Broadcaster) persistirSnapshot(path string) error {
+	snap := b.snapshotAtual()
+	if len(snap.eventos) == 0 || b.warm.Load() {
+		return nil
+	}
+
+	b.mu.RLock()
+	readAt := b.eventosCacheAt
+	b.mu.RUnlock()
+
+	arquivo := warmStartArquivo{
+		Version:   warmStartVersion,
+		SavedAt:   time.Now(),
+		ReadAt:    readAt,
+		ChangedAt: snap.createdAt,
+		Eventos:   snap.eventos,
+		Oraculos:  make(map[string]json.RawMessage),
+	}
+
+	b.oraculoHubsMu.Lock()
+	hubs := make([]*oraculoHub, 0, len(b.oraculoHubs))
+	for _, hub := range b.oraculoHubs {
+		hubs = append(hubs, hub)
+	}
+	b.oraculoHubsMu.Unlock()
+	for _, hub := range hubs {
+		if data, err := hub.latest(); err == nil && data != nil {
+			if raw, err := json.Marshal(data); err == nil {
+				arquivo.Oraculos[hub.idWilliamhill] = raw
+			}
+		}
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // sem efeito apos o rename
+
+	gz := gzip.NewWriter(tmp)
+	if err := json.NewEncoder(gz).Encode(&arquivo); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// warmStartPersister salva o snapshot a cada warm_start.interval
+func (b *Broadcaster) warmStartPersister(path string) {
+	ticker := time.NewTicker(warmStartCfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-b.stopChan:
+			return
+		case <-ticker.C:
+			if err := b.persistirSnapshot(path); err != nil {
+				log.Printf("Warm start: erro ao salvar %s: %v", path, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"radarfutebol-sse/internal/config"
+	"radarfutebol-sse/internal/models"
+)
+
+func novoBroadcasterTeste() *Broadcaster {
+	b := NewBroadcaster(Stores{})
+	b.SetLimitesFrescor(10*time.Second, time.Minute)
+	return b
+}
+
+func TestWarmStart_SalvaECarrega(t *testing.T) {
+	InitWarmStart(config.WarmStartConfig{Interval: time.Minute, MaxAge: time.Hour})
+	path := filepath.Join(t.TempDir(), "snapshot.json.gz")
+
+	// Processo anterior: snapshot lido do Redis e um jogo do oraculo assistido
+	antigo := novoBroadcasterTeste()
+	evento := criarEvento(1, "Flamengo", "Vasco", "inprogress")
+	evento.IdWilliamhill, evento.AnaliseIA = "wh-1", "analise"
+	antigo.snapshot.Store(novoSnapshot([]*models.Evento{evento}, nil, 7, 99))
+	antigo.eventosCacheAt = time.Now().Add(-5 * time.Second)
+
+	oraculo := &models.Oraculo{Evento: *evento}
+	hub := &oraculoHub{idWilliamhill: "wh-1", data: oraculo}
+	antigo.oraculoHubs["wh-1"] = hub
+
+	if err := antigo.persistirSnapshot(path); err != nil {
+		t.Fatalf("Erro ao salvar: %v", err)
+	}
+
+	// Processo novo: carrega antes da primeira leitura do Redis
+	novo := novoBroadcasterTeste()
+	if err := novo.carregarWarmStart(path); err != nil {
+		t.Fatalf("Erro ao carregar: %v", err)
+	}
+	if novo.Generation() != 1 || novo.FindEventoByIdWilliamhill("wh-1") == nil {
+		t.Fatal("Snapshot do disco deveria ser publicado com indices")
+	}
+	if novo.Live() {
+		t.Error("Snapshot do disco nao deveria contar como leitura da fonte")
+	}
+	if !novo.FindEventoByIdWilliamhill("wh-1").TemAnaliseIA {
+		t.Error("TemAnaliseIA deveria ser recalculado na carga")
+	}
+	if st, _ := novo.DadosStatus(); st.Status != "stale" || st.Reason != "warm_start" {
+		t.Errorf("Snapshot do disco deveria ser marcado stale, obteve %+v", st)
+	}
+	if o := novo.oraculoDoDisco("wh-1"); o == nil || o.TimeCasa != "Flamengo" {
+		t.Errorf("Oraculo assistido deveria vir do disco, obteve %+v", o)
+	}
+
+	// Durante o warm start nao sobrescreve o arquivo
+	if err := novo.persistirSnapshot(path); err != nil {
+		t.Fatal(err)
+	}
+
+	// Primeira leitura do Redis com dados encerra o warm start
+	novo.encerrarWarmStart()
+	novo.avaliarFrescor(time.Now())
+	if st, _ := novo.DadosStatus(); st.Status != "ok" {
+		t.Errorf("Apos leitura do Redis esperava ok, obteve %+v", st)
+	}
+	if !novo.Live() {
+		t.Error("Apos leitura do Redis o snapshot deveria ser live")
+	}
+	if novo.oraculoDoDisco("wh-1") != nil {
+		t.Error("Oraculos do disco devem ser descartados apos o warm start")
+	}
+}
+
+func TestWarmStart_IgnoraArquivoAntigo(t *testing.T) {
+	InitWarmStart(config.WarmStartConfig{Interval: time.Minute, MaxAge: time.Minute})
+	path := filepath.Join(t.TempDir(), "snapshot.json.gz")
+
+	antigo := novoBroadcasterTeste()
+	antigo.snapshot.Store(novoSnapshot([]*models.Evento{criarEvento(1, "Flamengo", "Vasco", "inprogress")}, nil, 1, 0))
+	antigo.eventosCacheAt = time.Now().Add(-time.Hour)
+	if err := antigo.persistirSnapshot(path); err != nil {
+		t.Fatal(err)
+	}
+
+	novo := novoBroadcasterTeste()
+	if err := novo.carregarWarmStart(path); err != nil {
+		t.Fatal(err)
+	}
+	if novo.Generation() != 0 {
+		t.Error("Arquivo mais velho que max_age deveria ser ignorado")
+	}
+
+	// Arquivo inexistente nao e erro (primeiro start)
+	if err := novo.carregarWarmStart(filepath.Join(t.TempDir(), "nao-existe")); err != nil {
+		t.Errorf("Arquivo inexistente nao deveria ser erro: %v", err)
+	}
+}